@@ -1,7 +1,11 @@
 package activation
 
-import "math"
+import (
+	"math"
 
-func Sigmoid(x float64) float64 {
-	return 1.0 / (1.0 + math.Exp(-x))
+	"github.com/itsubaki/neu/math/vector"
+)
+
+func Sigmoid[T vector.Float](x T) T {
+	return T(1.0 / (1.0 + math.Exp(-float64(x))))
 }
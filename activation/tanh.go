@@ -1,7 +1,11 @@
 package activation
 
-import "math"
+import (
+	"math"
 
-func Tanh(x float64) float64 {
-	return math.Tanh(x)
+	"github.com/itsubaki/neu/math/vector"
+)
+
+func Tanh[T vector.Float](x T) T {
+	return T(math.Tanh(float64(x)))
 }
@@ -0,0 +1,70 @@
+package autograd
+
+import "github.com/itsubaki/neu/math/matrix"
+
+// Add returns x + y. y is broadcasted to the shape of x.
+func Add(x, y *Variable) *Variable {
+	return apply("Add", x.Data.Add(y.Data), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{
+			gy,
+			sumTo(gy, y.Data),
+		}
+	}, x, y)
+}
+
+// Sub returns x - y. y is broadcasted to the shape of x.
+func Sub(x, y *Variable) *Variable {
+	return apply("Sub", x.Data.Sub(y.Data), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{
+			gy,
+			sumTo(gy.MulC(-1), y.Data),
+		}
+	}, x, y)
+}
+
+// Mul returns x * y. y is broadcasted to the shape of x.
+func Mul(x, y *Variable) *Variable {
+	return apply("Mul", x.Data.Mul(y.Data), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{
+			gy.Mul(y.Data),                // gy * y
+			sumTo(gy.Mul(x.Data), y.Data), // gy * x
+		}
+	}, x, y)
+}
+
+// Div returns x / y. y is broadcasted to the shape of x.
+func Div(x, y *Variable) *Variable {
+	return apply("Div", x.Data.Div(y.Data), func(gy matrix.Matrix) []matrix.Matrix {
+		yb := y.Data.Broadcast(x.Dim())
+		return []matrix.Matrix{
+			gy.Div(yb), // gy / y
+			sumTo(gy.Mul(x.Data).Div(yb.Pow2()).MulC(-1), y.Data), // -gy * x / y^2
+		}
+	}, x, y)
+}
+
+// AddC returns c + x.
+func AddC(c float64, x *Variable) *Variable {
+	return apply("AddC", x.Data.AddC(c), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy}
+	}, x)
+}
+
+// SubC returns c - x.
+func SubC(c float64, x *Variable) *Variable {
+	return apply("SubC", matrix.SubC(c, x.Data), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.MulC(-1)}
+	}, x)
+}
+
+// MulC returns c * x.
+func MulC(c float64, x *Variable) *Variable {
+	return apply("MulC", x.Data.MulC(c), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.MulC(c)}
+	}, x)
+}
+
+// Neg returns -x.
+func Neg(x *Variable) *Variable {
+	return MulC(-1, x)
+}
@@ -0,0 +1,46 @@
+package autograd
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// Function is an operation recorded on the graph.
+type Function struct {
+	Name       string
+	Input      []*Variable
+	Output     *Variable
+	Generation int
+	backward   func(gy matrix.Matrix) []matrix.Matrix
+}
+
+func (f *Function) String() string {
+	return fmt.Sprintf("%v%v", f.Name, f.Input)
+}
+
+// apply records a function with the output y and returns the output variable.
+// backward returns the gradients for each of the inputs. A nil gradient is not propagated.
+func apply(name string, y matrix.Matrix, backward func(gy matrix.Matrix) []matrix.Matrix, x ...*Variable) *Variable {
+	var gen int
+	for _, v := range x {
+		if v != nil && v.Generation > gen {
+			gen = v.Generation
+		}
+	}
+
+	f := &Function{
+		Name:       name,
+		Input:      x,
+		Generation: gen,
+		backward:   backward,
+	}
+
+	f.Output = &Variable{
+		Data:       y,
+		Creator:    f,
+		Generation: gen + 1,
+	}
+
+	return f.Output
+}
@@ -0,0 +1,128 @@
+package autograd_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/itsubaki/neu/autograd"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+)
+
+// numerical returns the central-difference gradients of sum(f(x) * w) for each input.
+func numerical(f func(x ...*autograd.Variable) *autograd.Variable, w matrix.Matrix, x ...matrix.Matrix) []matrix.Matrix {
+	loss := func() float64 {
+		v := make([]*autograd.Variable, len(x))
+		for i := range x {
+			v[i] = autograd.New(x[i])
+		}
+
+		return f(v...).Data.Mul(w).Sum()
+	}
+
+	h := 1e-4
+	grads := make([]matrix.Matrix, len(x))
+	for k := range x {
+		grads[k] = matrix.ZeroLike(x[k])
+		for i := range x[k] {
+			for j := range x[k][i] {
+				tmp := x[k][i][j]
+
+				x[k][i][j] = tmp + h
+				fxh1 := loss()
+
+				x[k][i][j] = tmp - h
+				fxh2 := loss()
+
+				grads[k][i][j] = (fxh1 - fxh2) / (2 * h)
+				x[k][i][j] = tmp
+			}
+		}
+	}
+
+	return grads
+}
+
+func TestBackward(t *testing.T) {
+	s := rand.Const(1)
+	x := func(m, n int) matrix.Matrix { return matrix.Randn(m, n, s) }
+	p := func(m, n int) matrix.Matrix { return matrix.Rand(m, n, s).AddC(0.5) } // positive
+
+	cases := []struct {
+		name string
+		x    []matrix.Matrix
+		f    func(x ...*autograd.Variable) *autograd.Variable
+	}{
+		{"Add", []matrix.Matrix{x(2, 3), x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Add(x[0], x[1]) }},
+		{"Add_row", []matrix.Matrix{x(2, 3), x(1, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Add(x[0], x[1]) }},
+		{"Add_column", []matrix.Matrix{x(2, 3), x(2, 1)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Add(x[0], x[1]) }},
+		{"Add_scalar", []matrix.Matrix{x(2, 3), x(1, 1)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Add(x[0], x[1]) }},
+		{"Sub", []matrix.Matrix{x(2, 3), x(1, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Sub(x[0], x[1]) }},
+		{"Mul", []matrix.Matrix{x(2, 3), x(2, 1)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Mul(x[0], x[1]) }},
+		{"Div", []matrix.Matrix{x(2, 3), p(1, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Div(x[0], x[1]) }},
+		{"AddC", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.AddC(2, x[0]) }},
+		{"SubC", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.SubC(2, x[0]) }},
+		{"MulC", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.MulC(3, x[0]) }},
+		{"Neg", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Neg(x[0]) }},
+		{"Sqrt", []matrix.Matrix{p(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Sqrt(x[0], 1e-7) }},
+		{"Pow2", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Pow2(x[0]) }},
+		{"Abs", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Abs(x[0]) }},
+		{"Exp", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Exp(x[0]) }},
+		{"Log", []matrix.Matrix{p(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Log(x[0]) }},
+		{"Tanh", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Tanh(x[0]) }},
+		{"Sigmoid", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Sigmoid(x[0]) }},
+		{"ReLU", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.ReLU(x[0]) }},
+		{"Softmax", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Softmax(x[0]) }},
+		{"Sum", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Sum(x[0]) }},
+		{"Mean", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Mean(x[0]) }},
+		{"SumAxis0", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.SumAxis0(x[0]) }},
+		{"SumAxis1", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.SumAxis1(x[0]) }},
+		{"MeanAxis0", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.MeanAxis0(x[0]) }},
+		{"MaxAxis1", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.MaxAxis1(x[0]) }},
+		{"Broadcast", []matrix.Matrix{x(1, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Broadcast(x[0], 4, 3) }},
+		{"Dot", []matrix.Matrix{x(2, 3), x(3, 4)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Dot(x[0], x[1]) }},
+		{"T", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.T(x[0]) }},
+		{"Reshape", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Reshape(x[0], 3, 2) }},
+		{"HStack", []matrix.Matrix{x(2, 3), x(2, 1)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.HStack(x[0], x[1]) }},
+		{"Split", []matrix.Matrix{x(2, 4)}, func(x ...*autograd.Variable) *autograd.Variable {
+			s := autograd.Split(x[0], 2)
+			return autograd.Mul(s[0], s[1])
+		}},
+		{"Columns", []matrix.Matrix{x(2, 4)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Columns(x[0], 1, 3) }},
+		{"Batch", []matrix.Matrix{x(4, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Batch(x[0], []int{0, 2, 2, 3}) }},
+		{"Padding", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Padding(x[0], 1) }},
+		{"Unpadding", []matrix.Matrix{x(4, 5)}, func(x ...*autograd.Variable) *autograd.Variable { return autograd.Unpadding(x[0], 1) }},
+		{"SoftmaxCrossEntropy", []matrix.Matrix{x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable {
+			return autograd.SoftmaxCrossEntropy(x[0], autograd.New(matrix.OneHot([]int{0, 2}, 3)))
+		}},
+		{"MeanSquaredError", []matrix.Matrix{x(2, 3), x(2, 3)}, func(x ...*autograd.Variable) *autograd.Variable {
+			return autograd.MeanSquaredError(x[0], x[1])
+		}},
+		{"Affine", []matrix.Matrix{x(2, 3), x(3, 4), x(1, 4)}, func(x ...*autograd.Variable) *autograd.Variable {
+			return autograd.Tanh(autograd.Add(autograd.Dot(x[0], x[1]), x[2]))
+		}},
+	}
+
+	for _, c := range cases {
+		v := make([]*autograd.Variable, len(c.x))
+		for i := range c.x {
+			v[i] = autograd.New(c.x[i])
+		}
+
+		y := c.f(v...)
+		w := matrix.Randn(len(y.Data), len(y.Data[0]), s)
+		autograd.Sum(autograd.Mul(y, autograd.New(w))).Backward()
+
+		want := numerical(c.f, w, c.x...)
+		for i := range v {
+			if v[i].Grad == nil {
+				t.Errorf("%v: grad[%v] is nil", c.name, i)
+				continue
+			}
+
+			if eps := v[i].Grad.Sub(want[i]).Abs().Sum(); math.IsNaN(eps) || eps > 1e-6 {
+				t.Errorf("%v: grad[%v]=%v, want=%v", c.name, i, v[i].Grad, want[i])
+			}
+		}
+	}
+}
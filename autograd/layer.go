@@ -0,0 +1,89 @@
+package autograd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// Func is a function of variables that defines a layer.
+// t is nil unless the layer is the last layer, such as a loss.
+type Func func(x, t *Variable, p []*Variable, opts ...layer.Opts) *Variable
+
+// Layer is a layer defined by a function of variables.
+// Backward computes the gradients from the recorded graph instead of a hand-written derivation.
+type Layer struct {
+	P    []*Variable // params
+	Func Func
+	x, y *Variable
+}
+
+func (l *Layer) Params() []matrix.Matrix {
+	out := make([]matrix.Matrix, len(l.P))
+	for i := range l.P {
+		out[i] = l.P[i].Data
+	}
+
+	return out
+}
+
+func (l *Layer) Grads() []matrix.Matrix {
+	out := make([]matrix.Matrix, len(l.P))
+	for i := range l.P {
+		if l.P[i].Grad == nil {
+			// not used in the graph
+			out[i] = matrix.ZeroLike(l.P[i].Data)
+			continue
+		}
+
+		out[i] = l.P[i].Grad
+	}
+
+	return out
+}
+
+func (l *Layer) SetParams(p ...matrix.Matrix) {
+	for i := range p {
+		l.P[i].Data = p[i]
+	}
+}
+
+func (l *Layer) String() string {
+	var size int
+	dim := make([]string, len(l.P))
+	for i := range l.P {
+		a, b := l.P[i].Dim()
+		dim[i], size = fmt.Sprintf("(%v, %v)", a, b), size+a*b
+	}
+
+	return fmt.Sprintf("%T: P[%v]: %v", l, strings.Join(dim, ", "), size)
+}
+
+func (l *Layer) Forward(x, t matrix.Matrix, opts ...layer.Opts) matrix.Matrix {
+	var tv *Variable
+	if t != nil {
+		tv = New(t)
+	}
+
+	l.x = New(x)
+	l.y = l.Func(l.x, tv, l.P, opts...)
+	return l.y.Data
+}
+
+func (l *Layer) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+	for i := range l.P {
+		l.P[i].ClearGrad()
+	}
+
+	l.y.Grad = dout
+	l.y.Backward()
+
+	if l.x.Grad == nil {
+		// x is not used
+		return matrix.ZeroLike(l.x.Data), nil
+	}
+
+	return l.x.Grad, nil
+}
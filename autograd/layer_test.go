@@ -0,0 +1,105 @@
+package autograd_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/autograd"
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+)
+
+func affine(x, _ *autograd.Variable, p []*autograd.Variable, _ ...layer.Opts) *autograd.Variable {
+	return autograd.Add(autograd.Dot(x, p[0]), p[1]) // x.W + B
+}
+
+func ExampleLayer() {
+	W := matrix.New([]float64{0.1, 0.2, 0.3}, []float64{0.4, 0.5, 0.6})
+	B := matrix.New([]float64{0.1, 0.2, 0.3})
+
+	l := &autograd.Layer{
+		P:    []*autograd.Variable{autograd.New(W), autograd.New(B)},
+		Func: affine,
+	}
+	fmt.Println(l)
+
+	x := matrix.New([]float64{1, 2}, []float64{3, 4})
+	fmt.Println(l.Forward(x, nil))
+
+	dout := matrix.New([]float64{1, 0, 1}, []float64{0, 1, 0})
+	fmt.Println(l.Backward(dout))
+	fmt.Println(l.Grads())
+
+	// same as layer.Affine
+	a := &layer.Affine{W: W, B: B}
+	a.Forward(x, nil)
+	fmt.Println(a.Backward(dout))
+	fmt.Println(a.Grads())
+
+	// Output:
+	// *autograd.Layer: P[(2, 3), (1, 3)]: 9
+	// [[1 1.4 1.8] [2 2.8000000000000003 3.5999999999999996]]
+	// [[0.4 1] [0.2 0.5]] []
+	// [[[1 3 1] [2 4 2]] [[1 1 1]]]
+	// [[0.4 1] [0.2 0.5]] []
+	// [[[1 3 1] [2 4 2]] [[1 1 1]]]
+}
+
+func ExampleLayer_sequential() {
+	s := rand.Const(1)
+	m := model.NewSequential([]model.Layer{
+		&autograd.Layer{
+			P: []*autograd.Variable{
+				autograd.New(matrix.Randn(2, 3, s).MulC(0.1)),
+				autograd.New(matrix.Zero(1, 3)),
+			},
+			Func: func(x, _ *autograd.Variable, p []*autograd.Variable, _ ...layer.Opts) *autograd.Variable {
+				return autograd.ReLU(affine(x, nil, p))
+			},
+		},
+		&autograd.Layer{
+			P: []*autograd.Variable{
+				autograd.New(matrix.Randn(3, 2, s).MulC(0.1)),
+				autograd.New(matrix.Zero(1, 2)),
+			},
+			Func: affine,
+		},
+		&autograd.Layer{
+			Func: func(x, t *autograd.Variable, _ []*autograd.Variable, _ ...layer.Opts) *autograd.Variable {
+				return autograd.SoftmaxCrossEntropy(x, t)
+			},
+		},
+	}, s)
+
+	for _, s := range m.Summary() {
+		fmt.Println(s)
+	}
+
+	x := matrix.New([]float64{0.5, 0.5}, []float64{1, 0}, []float64{0, 1})
+	t := matrix.New([]float64{1, 0}, []float64{0, 1}, []float64{0, 1})
+
+	for i := 0; i < 3; i++ {
+		loss := m.Forward(x, t)
+		m.Backward()
+
+		params, grads := m.Params(), m.Grads()
+		for k := range params {
+			for j := range params[k] {
+				params[k][j] = params[k][j].Sub(grads[k][j].MulC(0.5))
+			}
+		}
+		m.SetParams(params)
+
+		fmt.Printf("%.8f\n", loss[0][0])
+	}
+
+	// Output:
+	// *model.Sequential
+	// *autograd.Layer: P[(2, 3), (1, 3)]: 9
+	// *autograd.Layer: P[(3, 2), (1, 2)]: 8
+	// *autograd.Layer: P[]: 0
+	// 0.69378249
+	// 0.66905007
+	// 0.65517986
+}
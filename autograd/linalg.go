@@ -0,0 +1,110 @@
+package autograd
+
+import "github.com/itsubaki/neu/math/matrix"
+
+// Dot returns the dot product of x and w.
+func Dot(x, w *Variable) *Variable {
+	return apply("Dot", matrix.Dot(x.Data, w.Data), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{
+			matrix.Dot(gy, w.Data.T()), // gy.wT
+			matrix.Dot(x.Data.T(), gy), // xT.gy
+		}
+	}, x, w)
+}
+
+// T returns the transpose of x.
+func T(x *Variable) *Variable {
+	return apply("T", x.Data.T(), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.T()}
+	}, x)
+}
+
+// Reshape returns x with the shape (m, n).
+func Reshape(x *Variable, m, n int) *Variable {
+	a, b := x.Dim()
+	return apply("Reshape", matrix.Reshape(x.Data, m, n), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{matrix.Reshape(gy, a, b)}
+	}, x)
+}
+
+// HStack returns the variables horizontally stacked.
+func HStack(x ...*Variable) *Variable {
+	data := make([]matrix.Matrix, len(x))
+	for i := range x {
+		data[i] = x[i].Data
+	}
+
+	return apply("HStack", matrix.HStack(data...), func(gy matrix.Matrix) []matrix.Matrix {
+		gxs, begin := make([]matrix.Matrix, len(x)), 0
+		for i := range x {
+			_, n := x[i].Dim()
+			gxs[i] = columns(gy, begin, begin+n)
+			begin = begin + n
+		}
+
+		return gxs
+	}, x...)
+}
+
+// Split returns x split into the variables with H columns.
+func Split(x *Variable, H int) []*Variable {
+	_, n := x.Dim()
+
+	out := make([]*Variable, n/H)
+	for i := range out {
+		out[i] = Columns(x, i*H, (i+1)*H)
+	}
+
+	return out
+}
+
+// Columns returns the columns [begin, end) of x.
+func Columns(x *Variable, begin, end int) *Variable {
+	return apply("Columns", columns(x.Data, begin, end), func(gy matrix.Matrix) []matrix.Matrix {
+		gx := matrix.ZeroLike(x.Data)
+		for i := range gx {
+			copy(gx[i][begin:end], gy[i])
+		}
+
+		return []matrix.Matrix{gx}
+	}, x)
+}
+
+// Batch returns the rows of x with the specified index.
+// The gradients of the same row are accumulated, as in an embedding.
+func Batch(x *Variable, index []int) *Variable {
+	return apply("Batch", matrix.Batch(x.Data, index), func(gy matrix.Matrix) []matrix.Matrix {
+		gx := matrix.ZeroLike(x.Data)
+		for i, idx := range index {
+			for j := range gx[idx] {
+				gx[idx][j] = gx[idx][j] + gy[i][j]
+			}
+		}
+
+		return []matrix.Matrix{gx}
+	}, x)
+}
+
+// Padding returns x padded with zeros.
+func Padding(x *Variable, pad int) *Variable {
+	return apply("Padding", matrix.Padding(x.Data, pad), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{matrix.Unpadding(gy, pad)}
+	}, x)
+}
+
+// Unpadding returns x without the padding.
+func Unpadding(x *Variable, pad int) *Variable {
+	return apply("Unpadding", matrix.Unpadding(x.Data, pad), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{matrix.Padding(gy, pad)}
+	}, x)
+}
+
+// columns returns a copy of the columns [begin, end) of x.
+func columns(x matrix.Matrix, begin, end int) matrix.Matrix {
+	out := matrix.Zero(len(x), end-begin)
+	for i := range x {
+		copy(out[i], x[i][begin:end])
+	}
+
+	return out
+}
@@ -0,0 +1,21 @@
+package autograd
+
+import (
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// SoftmaxCrossEntropy returns the mean cross-entropy loss of softmax(x) and the one-hot labels t.
+// t does not receive a gradient.
+func SoftmaxCrossEntropy(x, t *Variable) *Variable {
+	y := softmax(x.Data)
+	return apply("SoftmaxCrossEntropy", matrix.New([]float64{layer.Loss(y, t.Data)}), func(gy matrix.Matrix) []matrix.Matrix {
+		gx := y.Sub(t.Data).MulC(gy[0][0] / float64(len(t.Data))) // (y - t) * gy / size
+		return []matrix.Matrix{gx, nil}
+	}, x, t)
+}
+
+// MeanSquaredError returns the mean of (x - t)^2.
+func MeanSquaredError(x, t *Variable) *Variable {
+	return Mean(Pow2(Sub(x, t)))
+}
@@ -0,0 +1,99 @@
+package autograd
+
+import (
+	"math"
+
+	"github.com/itsubaki/neu/activation"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// Sqrt returns sqrt(x + eps).
+func Sqrt(x *Variable, eps float64) *Variable {
+	y := x.Data.Sqrt(eps)
+	return apply("Sqrt", y, func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.Div(y).MulC(0.5)} // gy * 0.5 / sqrt(x + eps)
+	}, x)
+}
+
+// Pow2 returns x^2.
+func Pow2(x *Variable) *Variable {
+	return apply("Pow2", x.Data.Pow2(), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.Mul(x.Data).MulC(2)} // gy * 2x
+	}, x)
+}
+
+// Abs returns |x|.
+func Abs(x *Variable) *Variable {
+	return apply("Abs", x.Data.Abs(), func(gy matrix.Matrix) []matrix.Matrix {
+		sign := matrix.F(x.Data, func(v float64) float64 {
+			switch {
+			case v > 0:
+				return 1
+			case v < 0:
+				return -1
+			default:
+				return 0
+			}
+		})
+
+		return []matrix.Matrix{gy.Mul(sign)}
+	}, x)
+}
+
+// Exp returns exp(x).
+func Exp(x *Variable) *Variable {
+	y := matrix.F(x.Data, math.Exp)
+	return apply("Exp", y, func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.Mul(y)} // gy * exp(x)
+	}, x)
+}
+
+// Log returns log(x).
+func Log(x *Variable) *Variable {
+	return apply("Log", matrix.F(x.Data, math.Log), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.Div(x.Data)} // gy / x
+	}, x)
+}
+
+// Tanh returns tanh(x).
+func Tanh(x *Variable) *Variable {
+	y := matrix.F(x.Data, activation.Tanh)
+	return apply("Tanh", y, func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.Mul(matrix.SubC(1, y.Pow2()))} // gy * (1 - y^2)
+	}, x)
+}
+
+// Sigmoid returns sigmoid(x).
+func Sigmoid(x *Variable) *Variable {
+	y := matrix.F(x.Data, activation.Sigmoid)
+	return apply("Sigmoid", y, func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.Mul(y).Mul(matrix.SubC(1, y))} // gy * y * (1 - y)
+	}, x)
+}
+
+// ReLU returns max(0, x).
+func ReLU(x *Variable) *Variable {
+	return apply("ReLU", matrix.F(x.Data, activation.ReLU), func(gy matrix.Matrix) []matrix.Matrix {
+		mask := matrix.Mask(x.Data, func(v float64) bool { return v > 0 })
+		return []matrix.Matrix{gy.Mul(mask)}
+	}, x)
+}
+
+// Softmax returns the softmax of each row of x.
+func Softmax(x *Variable) *Variable {
+	y := softmax(x.Data)
+	return apply("Softmax", y, func(gy matrix.Matrix) []matrix.Matrix {
+		gx := y.Mul(gy)                      // (N, H)
+		sum := matrix.New(gx.SumAxis1()).T() // (N, 1)
+		return []matrix.Matrix{gx.Sub(y.Mul(sum))}
+	}, x)
+}
+
+func softmax(x matrix.Matrix) matrix.Matrix {
+	out := make(matrix.Matrix, len(x))
+	for i, r := range x {
+		out[i] = activation.Softmax(r)
+	}
+
+	return out
+}
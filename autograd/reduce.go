@@ -0,0 +1,90 @@
+package autograd
+
+import (
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// Sum returns the sum of all elements as a (1, 1) variable.
+func Sum(x *Variable) *Variable {
+	return apply("Sum", matrix.New([]float64{x.Data.Sum()}), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.Broadcast(x.Dim())}
+	}, x)
+}
+
+// Mean returns the average of all elements as a (1, 1) variable.
+func Mean(x *Variable) *Variable {
+	return MulC(1.0/float64(x.Data.Size()), Sum(x))
+}
+
+// SumAxis0 returns the sum of each column as a (1, N) variable.
+func SumAxis0(x *Variable) *Variable {
+	return apply("SumAxis0", matrix.New(x.Data.SumAxis0()), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.Broadcast(x.Dim())}
+	}, x)
+}
+
+// SumAxis1 returns the sum of each row as a (N, 1) variable.
+func SumAxis1(x *Variable) *Variable {
+	return apply("SumAxis1", matrix.New(x.Data.SumAxis1()).T(), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{gy.Broadcast(x.Dim())}
+	}, x)
+}
+
+// MeanAxis0 returns the mean of each column as a (1, N) variable.
+func MeanAxis0(x *Variable) *Variable {
+	return MulC(1.0/float64(len(x.Data)), SumAxis0(x))
+}
+
+// MaxAxis1 returns the maximum value of each row as a (N, 1) variable.
+// The gradient flows to the first maximum of each row.
+func MaxAxis1(x *Variable) *Variable {
+	return apply("MaxAxis1", matrix.New(x.Data.MaxAxis1()).T(), func(gy matrix.Matrix) []matrix.Matrix {
+		gx := matrix.ZeroLike(x.Data)
+		for i, j := range argmax(x.Data) {
+			gx[i][j] = gy[i][0]
+		}
+
+		return []matrix.Matrix{gx}
+	}, x)
+}
+
+// Broadcast returns x broadcasted to (a, b).
+func Broadcast(x *Variable, a, b int) *Variable {
+	return apply("Broadcast", x.Data.Broadcast(a, b), func(gy matrix.Matrix) []matrix.Matrix {
+		return []matrix.Matrix{sumTo(gy, x.Data)}
+	}, x)
+}
+
+// sumTo returns gy summed to the shape of x.
+// It is the gradient of the broadcast of x.
+func sumTo(gy, x matrix.Matrix) matrix.Matrix {
+	p, q := gy.Dim()
+	a, b := x.Dim()
+
+	switch {
+	case a == p && b == q:
+		return gy
+	case a == 1 && b == 1:
+		return matrix.New([]float64{gy.Sum()})
+	case a == 1:
+		return matrix.New(gy.SumAxis0())
+	case b == 1:
+		return matrix.New(gy.SumAxis1()).T()
+	}
+
+	return gy
+}
+
+// argmax returns the index of the first maximum value of each row.
+func argmax(x matrix.Matrix) []int {
+	out := make([]int, len(x))
+	for i := range x {
+		for j := range x[i] {
+			if x[i][j] > x[i][out[i]] {
+				out[i] = j
+			}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,96 @@
+package autograd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// Variable is a matrix that records the function that created it.
+type Variable struct {
+	Name       string
+	Data       matrix.Matrix
+	Grad       matrix.Matrix
+	Creator    *Function
+	Generation int
+}
+
+// New returns a variable with the data.
+func New(data matrix.Matrix) *Variable {
+	return &Variable{Data: data}
+}
+
+// Dim returns the dimension of the data.
+func (v *Variable) Dim() (int, int) {
+	return v.Data.Dim()
+}
+
+// ClearGrad clears the gradient.
+func (v *Variable) ClearGrad() {
+	v.Grad = nil
+}
+
+// Backward computes the gradients of all variables that v depends on.
+// The functions on the graph are replayed in the reverse order of the generation,
+// and the gradients of a variable used more than once are accumulated.
+func (v *Variable) Backward() {
+	if v.Grad == nil {
+		v.Grad = matrix.One(v.Dim())
+	}
+
+	if v.Creator == nil {
+		return
+	}
+
+	fs, seen := make([]*Function, 0), make(map[*Function]bool)
+	add := func(f *Function) {
+		if seen[f] {
+			return
+		}
+
+		// keep fs sorted by the generation
+		seen[f] = true
+		i := sort.Search(len(fs), func(i int) bool { return fs[i].Generation > f.Generation })
+		fs = append(fs, nil)
+		copy(fs[i+1:], fs[i:])
+		fs[i] = f
+	}
+
+	add(v.Creator)
+	for len(fs) > 0 {
+		// pop the function with the largest generation
+		f := fs[len(fs)-1]
+		fs = fs[:len(fs)-1]
+
+		gxs := f.backward(f.Output.Grad)
+		for i, x := range f.Input {
+			if x == nil || gxs[i] == nil {
+				continue
+			}
+
+			if x.Grad == nil {
+				x.Grad = gxs[i]
+			} else {
+				x.Grad = x.Grad.Add(gxs[i])
+			}
+
+			if x.Creator != nil {
+				add(x.Creator)
+			}
+		}
+	}
+}
+
+// Unchain removes the creator, so that Backward does not go through v.
+func (v *Variable) Unchain() {
+	v.Creator = nil
+}
+
+func (v *Variable) String() string {
+	if v.Name == "" {
+		return fmt.Sprintf("variable(%v)", v.Data)
+	}
+
+	return fmt.Sprintf("%v(%v)", v.Name, v.Data)
+}
@@ -0,0 +1,84 @@
+package autograd_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/autograd"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+func ExampleVariable_Backward() {
+	// y = x^2 + 2x
+	x := autograd.New(matrix.New([]float64{1, 2, 3}))
+	y := autograd.Add(autograd.Pow2(x), autograd.MulC(2, x))
+	y.Backward()
+
+	fmt.Println(y.Data)
+	fmt.Println(x.Grad) // 2x + 2
+
+	// Output:
+	// [[3 8 15]]
+	// [[4 6 8]]
+}
+
+func ExampleVariable_Backward_branch() {
+	// a = x^2, y = a^2 + a^2
+	x := autograd.New(matrix.New([]float64{2}))
+	a := autograd.Pow2(x)
+	y := autograd.Add(autograd.Pow2(a), autograd.Pow2(a))
+	y.Backward()
+
+	fmt.Println(y.Data)
+	fmt.Println(x.Grad) // 16x^3
+
+	// Output:
+	// [[32]]
+	// [[64]]
+}
+
+func ExampleVariable_Backward_broadcast() {
+	x := autograd.New(matrix.New([]float64{1, 2}, []float64{3, 4}))
+	b := autograd.New(matrix.New([]float64{10, 20}))
+	y := autograd.Sum(autograd.Add(x, b))
+	y.Backward()
+
+	fmt.Println(y.Data)
+	fmt.Println(x.Grad)
+	fmt.Println(b.Grad)
+
+	// Output:
+	// [[70]]
+	// [[1 1] [1 1]]
+	// [[2 2]]
+}
+
+func ExampleVariable_Unchain() {
+	x := autograd.New(matrix.New([]float64{3}))
+	a := autograd.Pow2(x)
+	a.Unchain()
+
+	y := autograd.MulC(2, a)
+	y.Backward()
+
+	fmt.Println(a.Grad)
+	fmt.Println(x.Grad)
+
+	// Output:
+	// [[2]]
+	// []
+}
+
+func ExampleFunction() {
+	x := autograd.New(matrix.New([]float64{1, 2}))
+	x.Name = "x"
+
+	y := autograd.Sum(autograd.Pow2(x))
+	fmt.Println(y.Creator)
+	fmt.Println(y.Creator.Input[0].Creator)
+	fmt.Println(y.Generation)
+
+	// Output:
+	// Sum[variable([[1 4]])]
+	// Pow2[x([[1 2]])]
+	// 2
+}
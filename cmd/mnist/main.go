@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/itsubaki/neu/dataset"
 	"github.com/itsubaki/neu/dataset/mnist"
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/metrics"
 	"github.com/itsubaki/neu/model"
 	"github.com/itsubaki/neu/optimizer"
 	"github.com/itsubaki/neu/optimizer/hook"
@@ -18,10 +20,11 @@ import (
 func main() {
 	// flags
 	var dir string
-	var epochs, hiddenSize, batchSize int
+	var epochs, hiddenSize, batchSize, patience int
 	var momentum, learningRate, lambda float64
 	flag.StringVar(&dir, "dir", "./testdata", "")
 	flag.IntVar(&epochs, "epochs", 10, "")
+	flag.IntVar(&patience, "patience", 3, "")
 	flag.IntVar(&hiddenSize, "hidden-size", 50, "")
 	flag.IntVar(&batchSize, "batch-size", 100, "")
 	flag.Float64Var(&momentum, "batch-norm-momentum", 0.9, "")
@@ -32,10 +35,6 @@ func main() {
 	// data
 	train, test := mnist.Must(mnist.Load(dir))
 
-	// train data
-	x := matrix.New(mnist.Normalize(train.Image)...) // 60000 * 784
-	t := matrix.New(mnist.OneHot(train.Label)...)    // 60000 * 10
-
 	// test data
 	xt := matrix.New(mnist.Normalize(test.Image)...) // 10000 * 784
 	tt := matrix.New(mnist.OneHot(test.Label)...)    // 10000 * 10
@@ -66,36 +65,38 @@ func main() {
 
 	now := time.Now()
 	tr.Fit(&trainer.Input{
-		Train:      x,
-		TrainLabel: t,
-		Epochs:     epochs,
-		BatchSize:  batchSize,
+		Loader: &dataset.DataLoader{
+			Dataset:   train, // 60000 * 784, 60000 * 10
+			BatchSize: batchSize,
+			Shuffle:   true,
+			Prefetch:  2,
+		},
+		Epochs: epochs,
 		Verbose: func(epoch, j int, loss float64, m trainer.Model) {
 			if j%(train.N/batchSize/10) != 0 {
 				return
 			}
 
-			// batch
-			mask := trainer.Random(train.N, batchSize)
-			xbatch := matrix.Batch(x, mask)
-			tbatch := matrix.Batch(t, mask)
-
-			maskt := trainer.Random(test.N, batchSize)
-			xtbatch := matrix.Batch(xt, maskt)
-			ttbatch := matrix.Batch(tt, maskt)
-
-			// accuracy
-			acc := trainer.Accuracy(m.Predict(xbatch), tbatch)
-			y := m.Predict(xtbatch)
-			acct := trainer.Accuracy(y, ttbatch)
-
-			// print
-			fmt.Printf("%3d,%4d: loss=%.04f, train_acc=%.04f, test_acc=%.04f\n", epoch, j, loss, acc, acct)
-			fmt.Printf("predict: %v\n", y.Argmax()[:20])
-			fmt.Printf("label  : %v\n", ttbatch.Argmax()[:20])
+			fmt.Printf("%3d,%4d: loss=%.04f\n", epoch, j, loss)
+		},
+		Valid:      xt,
+		ValidLabel: tt,
+		ValidVerbose: func(epoch int, metrics trainer.Metrics, m trainer.Model) {
+			fmt.Printf("%3d: test_loss=%.04f, test_acc=%.04f\n", epoch, metrics.Loss, metrics.Accuracy)
 			fmt.Println()
 		},
+		EarlyStopping: &trainer.EarlyStopping{
+			Monitor:     trainer.MonitorAccuracy,
+			Patience:    patience,
+			RestoreBest: true,
+		},
 	})
 
 	fmt.Printf("elapsed=%v\n", time.Since(now))
+	fmt.Println()
+
+	// error analysis
+	a := metrics.Evaluate(m, xt, tt, batchSize, 3)
+	fmt.Println(a.Confusion)
+	fmt.Printf("test_acc=%.04f, test_top3_acc=%.04f, macro_f1=%.04f\n", a.Accuracy(), a.TopKAccuracy(), a.Confusion.MacroF1())
 }
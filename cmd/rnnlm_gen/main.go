@@ -3,17 +3,18 @@ package main
 import (
 	"flag"
 	"fmt"
-	"math"
+	randv2 "math/rand/v2"
+	"runtime"
 	"strings"
 	"time"
 
 	"github.com/itsubaki/neu/dataset/ptb"
 	"github.com/itsubaki/neu/math/matrix"
-	"github.com/itsubaki/neu/math/tensor"
 	"github.com/itsubaki/neu/math/vector"
 	"github.com/itsubaki/neu/model"
 	"github.com/itsubaki/neu/optimizer"
 	"github.com/itsubaki/neu/optimizer/hook"
+	"github.com/itsubaki/neu/optimizer/schedule"
 	"github.com/itsubaki/neu/trainer"
 	"github.com/itsubaki/neu/weight"
 )
@@ -22,7 +23,7 @@ func main() {
 	// flag
 	var dir string
 	var length int
-	var epochs, wordvecSize, hiddenSize, batchSize, timeSize int
+	var epochs, wordvecSize, hiddenSize, batchSize, timeSize, workers, every int
 	var learningRate, dropoutRatio, max float64
 	flag.StringVar(&dir, "dir", "./testdata", "")
 	flag.IntVar(&length, "length", 100, "")
@@ -34,14 +35,23 @@ func main() {
 	flag.Float64Var(&dropoutRatio, "dropout-ratio", 0.5, "")
 	flag.Float64Var(&learningRate, "learning-rate", 20, "")
 	flag.Float64Var(&max, "grads-cliping-max", 0.25, "")
+	flag.IntVar(&workers, "workers", runtime.NumCPU(), "")
+	flag.IntVar(&every, "checkpoint-every", 100, "")
 	flag.Parse()
 
+	// parallel
+	matrix.SetWorkers(workers)
+
 	// data
 	train := ptb.Must(ptb.Load(dir, ptb.TrainTxt))
-	valid := ptb.Must(ptb.Load(dir, ptb.ValidTxt))
+	valid, err := ptb.Must(ptb.Load(dir, ptb.ValidTxt)).Convert(train.WordToID)
+	if err != nil {
+		fmt.Printf("failed to convert validation data: %v\n", err)
+		return
+	}
 
 	// model
-	m := model.NewRNNLMGen(&model.LSTMLMConfig{
+	c := &model.LSTMLMConfig{
 		RNNLMConfig: model.RNNLMConfig{
 			VocabSize:   vector.Max(train.Corpus) + 1,
 			WordVecSize: wordvecSize,
@@ -49,7 +59,15 @@ func main() {
 			WeightInit:  weight.Xavier,
 		},
 		DropoutRatio: dropoutRatio,
-	})
+	}
+	m := model.NewRNNLMGen(c)
+
+	filename := fmt.Sprintf("%s/rnnlm_gen.gob", dir)
+	if loaded, err := model.Load(filename); err == nil {
+		if g, ok := loaded.(*model.RNNLMGen); ok && model.Check(m.Params(), g.Params()) == nil {
+			m = g
+		}
+	}
 
 	// summary
 	fmt.Println(m.Summary()[0])
@@ -58,12 +76,6 @@ func main() {
 	}
 	fmt.Println()
 
-	// params
-	filename := fmt.Sprintf("%s/rnnlm_gen.gob", dir)
-	if params, ok := model.Load(filename); ok {
-		m.SetParams(params)
-	}
-
 	// train
 	o := &optimizer.SGD{
 		LearningRate: learningRate,
@@ -71,32 +83,55 @@ func main() {
 			hook.GradsClipping(max),
 		},
 	}
-	tr := trainer.NewRNNLM(m, o)
-
-	now, min := time.Now(), math.MaxFloat64
-	for i := 0; i < epochs; i++ {
-		tr.Fit(&trainer.RNNLMInput{
-			Train:      train.Corpus[:len(train.Corpus)-1],
-			TrainLabel: train.Corpus[1:],
-			Epochs:     1,
-			BatchSize:  batchSize,
-			TimeSize:   timeSize,
-			Verbose: func(_, j int, perplexity float64, m trainer.RNNLM) {
-				fmt.Printf("%2d, %2d: train_ppl=%.04f\n", i, j, perplexity)
-			},
-		})
-
-		ppl := perplexity(m, valid.Corpus, batchSize, timeSize)
-		if min > ppl {
-			min = ppl
-			if err := model.Save(filename, m.Params()); err != nil {
-				fmt.Printf("failed to save params: :%v\n", err)
-			}
-		} else {
-			o.LearningRate /= 4
-		}
-
-		m.ResetState()
+	plateau := &schedule.ReduceOnPlateau{Initial: learningRate, Factor: 0.25}
+	tr := trainer.NewRNNLM(m, schedule.New(o, plateau))
+
+	// the early stopping keeps the best perplexity in the checkpoint, and does not stop the training
+	es := &trainer.EarlyStopping{Monitor: trainer.MonitorPerplexity, Patience: epochs}
+
+	now := time.Now()
+	if err := tr.Fit(&trainer.RNNLMInput{
+		Train:      train.Corpus[:len(train.Corpus)-1],
+		TrainLabel: train.Corpus[1:],
+		Epochs:     epochs,
+		BatchSize:  batchSize,
+		TimeSize:   timeSize,
+		Verbose: func(epoch, j int, perplexity float64, m trainer.RNNLM) {
+			fmt.Printf("%2d, %2d: train_ppl=%.04f\n", epoch, j, perplexity)
+		},
+		Valid:      valid[:len(valid)-1],
+		ValidLabel: valid[1:],
+		ValidVerbose: func(epoch int, metrics trainer.Metrics, m trainer.RNNLM) {
+			fmt.Printf("%2d: valid_ppl=%.04f\n", epoch, metrics.Perplexity)
+		},
+		Checkpoint: &trainer.Checkpoint{
+			Filename: fmt.Sprintf("%s/rnnlm_gen.ckpt", dir),
+			Every:    every,
+			Resume:   true,
+			Sources:  []randv2.Source{m.Source},
+		},
+		EarlyStopping: es,
+		Callbacks: []trainer.Callback{
+			&onEpochEnd{f: func(ctx *trainer.Context) {
+				if ctx.Metrics == nil {
+					return
+				}
+
+				// save the model of the best perplexity
+				if es.BestEpoch == ctx.Epoch {
+					if err := model.Save(filename, m, c); err != nil {
+						fmt.Printf("failed to save model: %v\n", err)
+					}
+				}
+
+				// divide the learning rate by 4 when the perplexity worsens
+				plateau.Step(ctx.Metrics.Perplexity)
+				m.ResetState()
+			}},
+		},
+	}); err != nil {
+		fmt.Printf("failed to fit: %v\n", err)
+		return
 	}
 	fmt.Printf("elapsed=%v\n", time.Since(now))
 	fmt.Println()
@@ -124,33 +159,10 @@ func main() {
 	fmt.Println(strings.Join(query, " "), txt)
 }
 
-func perplexity(m trainer.RNNLM, corpus []int, batchSize, timeSize int) float64 {
-	corpusSize := len(corpus)
-	maxIter := (corpusSize - 1) / (batchSize * timeSize)
-	jump := (corpusSize - 1) / batchSize
-
-	var total float64
-	for j := 0; j < maxIter; j++ {
-		xs, ts := tensor.Zero(timeSize, batchSize, 1), tensor.Zero(timeSize, batchSize, 1)
-
-		timeOffset := j * timeSize
-		offsets := make([]int, batchSize)
-		for i := 0; i < batchSize; i++ {
-			offsets[i] = timeOffset + (i * jump)
-		}
-
-		for t := 0; t < timeSize; t++ {
-			for i, offset := range offsets {
-				xs[t][i] = []float64{float64(corpus[(offset+t)%corpusSize])}
-				ts[t][i] = []float64{float64(corpus[(offset+t+1)%corpusSize])}
-			}
-		}
-
-		loss := m.Forward(xs, ts)
-		total += loss[0][0][0]
-
-		fmt.Printf("%3d/%3d: loss=%.04f\n", j, maxIter, loss)
-	}
-
-	return trainer.Perplexity(total, maxIter)
+// onEpochEnd is a callback that calls f at the end of each epoch.
+type onEpochEnd struct {
+	trainer.NopCallback
+	f func(c *trainer.Context)
 }
+
+func (e *onEpochEnd) OnEpochEnd(c *trainer.Context) { e.f(c) }
@@ -17,8 +17,9 @@ import (
 func main() {
 	// flags
 	var dir string
-	var epochs, corpusSize, wordvecSize, hiddenSize, batchSize, timeSize int
+	var epochs, corpusSize, wordvecSize, hiddenSize, batchSize, timeSize, layers int
 	var learningRate, dropoutRatio, max float64
+	var weightTying bool
 	flag.StringVar(&dir, "dir", "./testdata", "")
 	flag.IntVar(&epochs, "epochs", 10, "")
 	flag.IntVar(&corpusSize, "corpus-size", -1, "")
@@ -26,6 +27,8 @@ func main() {
 	flag.IntVar(&hiddenSize, "hidden-size", 100, "")
 	flag.IntVar(&batchSize, "batch-size", 10, "")
 	flag.IntVar(&timeSize, "time-size", 5, "")
+	flag.IntVar(&layers, "layers", 2, "")
+	flag.BoolVar(&weightTying, "weight-tying", false, "wordvec-size must equal hidden-size")
 	flag.Float64Var(&dropoutRatio, "dropout-ratio", 0.5, "")
 	flag.Float64Var(&learningRate, "learning-rate", 0.1, "")
 	flag.Float64Var(&max, "grads-cliping-max", 0.25, "")
@@ -47,6 +50,8 @@ func main() {
 			WeightInit:  weight.Xavier,
 		},
 		DropoutRatio: dropoutRatio,
+		Layers:       layers,
+		WeightTying:  weightTying,
 	})
 
 	// summary
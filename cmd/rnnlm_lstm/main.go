@@ -10,6 +10,7 @@ import (
 	"github.com/itsubaki/neu/model"
 	"github.com/itsubaki/neu/optimizer"
 	"github.com/itsubaki/neu/optimizer/hook"
+	"github.com/itsubaki/neu/optimizer/schedule"
 	"github.com/itsubaki/neu/trainer"
 	"github.com/itsubaki/neu/weight"
 )
@@ -17,8 +18,9 @@ import (
 func main() {
 	// flags
 	var dir string
-	var epochs, corpusSize, wordvecSize, hiddenSize, batchSize, timeSize int
-	var learningRate, dropoutRatio, max float64
+	var epochs, corpusSize, wordvecSize, hiddenSize, batchSize, timeSize, layers int
+	var learningRate, dropoutRatio, max, factor float64
+	var weightTying bool
 	flag.StringVar(&dir, "dir", "./testdata", "")
 	flag.IntVar(&epochs, "epochs", 10, "")
 	flag.IntVar(&corpusSize, "corpus-size", -1, "")
@@ -26,9 +28,12 @@ func main() {
 	flag.IntVar(&hiddenSize, "hidden-size", 100, "")
 	flag.IntVar(&batchSize, "batch-size", 10, "")
 	flag.IntVar(&timeSize, "time-size", 5, "")
+	flag.IntVar(&layers, "layers", 2, "")
+	flag.BoolVar(&weightTying, "weight-tying", false, "wordvec-size must equal hidden-size")
 	flag.Float64Var(&dropoutRatio, "dropout-ratio", 0.5, "")
 	flag.Float64Var(&learningRate, "learning-rate", 0.1, "")
 	flag.Float64Var(&max, "grads-cliping-max", 0.25, "")
+	flag.Float64Var(&factor, "plateau-factor", 1.0, "multiply the learning rate when the validation perplexity worsens")
 	flag.Parse()
 
 	// data
@@ -38,6 +43,27 @@ func main() {
 		corpus = train.Corpus[:corpusSize]
 	}
 
+	// the validation and test data with the word ids of the training data.
+	// they are evaluated only with the full corpus, since the vocabulary of a part of the corpus is smaller.
+	var vcorpus, tcorpus []int
+	if corpusSize <= 0 {
+		var err error
+		if vcorpus, err = ptb.Must(ptb.Load(dir, ptb.ValidTxt)).Convert(train.WordToID); err != nil {
+			fmt.Printf("failed to convert validation data: %v\n", err)
+			return
+		}
+
+		if tcorpus, err = ptb.Must(ptb.Load(dir, ptb.TestTxt)).Convert(train.WordToID); err != nil {
+			fmt.Printf("failed to convert test data: %v\n", err)
+			return
+		}
+	}
+
+	var valid, validLabel []int
+	if len(vcorpus) > 0 {
+		valid, validLabel = vcorpus[:len(vcorpus)-1], vcorpus[1:]
+	}
+
 	// model
 	m := model.NewLSTMLM(&model.LSTMLMConfig{
 		RNNLMConfig: model.RNNLMConfig{
@@ -47,6 +73,8 @@ func main() {
 			WeightInit:  weight.Xavier,
 		},
 		DropoutRatio: dropoutRatio,
+		Layers:       layers,
+		WeightTying:  weightTying,
 	})
 
 	// summary
@@ -63,9 +91,10 @@ func main() {
 			hook.GradsClipping(max),
 		},
 	})
+	plateau := &schedule.ReduceOnPlateau{Initial: learningRate, Factor: factor}
 
 	now := time.Now()
-	tr.Fit(&trainer.RNNLMInput{
+	if err := tr.Fit(&trainer.RNNLMInput{
 		Train:      corpus[:len(corpus)-1],
 		TrainLabel: corpus[1:],
 		Epochs:     epochs,
@@ -74,8 +103,39 @@ func main() {
 		Verbose: func(epoch, j int, perplexity float64, m trainer.RNNLM) {
 			fmt.Printf("%2d, %2d: train_ppl=%.04f\n", epoch, j, perplexity)
 		},
-	})
+		Valid:      valid,
+		ValidLabel: validLabel,
+		ValidVerbose: func(epoch int, metrics trainer.Metrics, m trainer.RNNLM) {
+			fmt.Printf("%2d: valid_ppl=%.04f\n", epoch, metrics.Perplexity)
+		},
+		Callbacks: []trainer.Callback{
+			&trainer.LearningRateScheduler{
+				Schedule: func(c *trainer.Context) float64 {
+					if c.Metrics == nil {
+						return plateau.LearningRate(c.Epoch)
+					}
+
+					return plateau.Step(c.Metrics.Perplexity)
+				},
+			},
+		},
+	}); err != nil {
+		fmt.Printf("failed to fit: %v\n", err)
+		return
+	}
 
 	fmt.Printf("elapsed=%v\n", time.Since(now))
 	fmt.Println()
+
+	if len(tcorpus) == 0 {
+		return
+	}
+
+	metrics, err := trainer.EvaluateRNNLM(m, tcorpus[:len(tcorpus)-1], tcorpus[1:], batchSize, timeSize)
+	if err != nil {
+		fmt.Printf("failed to evaluate: %v\n", err)
+		return
+	}
+
+	fmt.Printf("test_ppl=%.04f\n", metrics.Perplexity)
 }
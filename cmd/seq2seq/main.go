@@ -15,12 +15,22 @@ import (
 	"github.com/itsubaki/neu/weight"
 )
 
+// Seq2Seq is a seq2seq model with the summary.
+type Seq2Seq interface {
+	trainer.Seq2Seq
+	Summary() []string
+}
+
 func main() {
 	// flag
-	var dir string
+	var dir, file, name string
 	var epochs, dataSize, wordvecSize, hiddenSize, batchSize int
-	var alpha, beta1, beta2, max float64
+	var heads, ffSize, layers int
+	var learned bool
+	var alpha, beta1, beta2, max, dropout float64
 	flag.StringVar(&dir, "dir", "./testdata", "")
+	flag.StringVar(&file, "file", sequence.AdditionTxt, "addition.txt or date.txt")
+	flag.StringVar(&name, "model", "peeky", "peeky or transformer")
 	flag.IntVar(&epochs, "epochs", 100, "")
 	flag.IntVar(&dataSize, "data-size", -1, "")
 	flag.IntVar(&wordvecSize, "wordvec-size", 64, "")
@@ -30,10 +40,15 @@ func main() {
 	flag.Float64Var(&beta1, "beta1", 0.9, "")
 	flag.Float64Var(&beta2, "beta2", 0.999, "")
 	flag.Float64Var(&max, "grads-cliping-max", 5.0, "")
+	flag.IntVar(&heads, "heads", 4, "transformer only")
+	flag.IntVar(&ffSize, "ff-size", 256, "transformer only")
+	flag.IntVar(&layers, "layers", 2, "transformer only")
+	flag.BoolVar(&learned, "learned-position", false, "transformer only")
+	flag.Float64Var(&dropout, "dropout", 0.1, "transformer only")
 	flag.Parse()
 
 	// data
-	x, t, v := sequence.Must(sequence.Load(dir, sequence.AdditionTxt))
+	x, t, v := sequence.Must(sequence.Load(dir, file))
 	xt, tt := x.Train, t.Train
 	xv, tv := x.Test, t.Test
 	if dataSize > 0 {
@@ -42,12 +57,33 @@ func main() {
 	}
 
 	// model
-	m := model.NewPeekySeq2Seq(&model.RNNLMConfig{
-		VocabSize:   len(v.RuneToID), // 13
-		WordVecSize: wordvecSize,
-		HiddenSize:  hiddenSize,
-		WeightInit:  weight.Xavier,
-	})
+	T := len(x.Train[0])
+	if len(t.Train[0]) > T {
+		T = len(t.Train[0])
+	}
+
+	var m Seq2Seq
+	switch name {
+	case "transformer":
+		m = model.NewTransformerSeq2Seq(&model.TransformerConfig{
+			VocabSize:       len(v.RuneToID),
+			HiddenSize:      hiddenSize,
+			Heads:           heads,
+			FeedForwardSize: ffSize,
+			Layers:          layers,
+			MaxLength:       T,
+			LearnedPosition: learned,
+			DropoutRatio:    dropout,
+			WeightInit:      weight.Xavier,
+		})
+	default:
+		m = model.NewPeekySeq2Seq(&model.RNNLMConfig{
+			VocabSize:   len(v.RuneToID), // 13 for addition.txt
+			WordVecSize: wordvecSize,
+			HiddenSize:  hiddenSize,
+			WeightInit:  weight.Xavier,
+		})
+	}
 
 	// summary
 	fmt.Println(m.Summary()[0])
@@ -17,10 +17,11 @@ import (
 
 func main() {
 	// flag
-	var dir string
+	var dir, score string
 	var epochs, dataSize, wordvecSize, hiddenSize, batchSize int
 	var alpha, beta1, beta2, max float64
 	flag.StringVar(&dir, "dir", "./testdata", "")
+	flag.StringVar(&score, "score", "dot", "dot, general or additive")
 	flag.IntVar(&epochs, "epochs", 100, "")
 	flag.IntVar(&dataSize, "data-size", -1, "")
 	flag.IntVar(&wordvecSize, "wordvec-size", 64, "")
@@ -42,11 +43,17 @@ func main() {
 	}
 
 	// model
+	if err := model.AttentionScore(score).Validate(); err != nil {
+		fmt.Println(err)
+		return
+	}
+
 	m := model.NewAttentionSeq2Seq(&model.RNNLMConfig{
-		VocabSize:   len(v.RuneToID),
-		WordVecSize: wordvecSize,
-		HiddenSize:  hiddenSize,
-		WeightInit:  weight.Xavier,
+		VocabSize:      len(v.RuneToID),
+		WordVecSize:    wordvecSize,
+		HiddenSize:     hiddenSize,
+		WeightInit:     weight.Xavier,
+		AttentionScore: model.AttentionScore(score),
 	})
 
 	// summary
@@ -0,0 +1,158 @@
+package cifar10
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+const (
+	TrainBin = "data_batch_%d.bin" // 1 ~ 5
+	TestBin  = "test_batch.bin"
+)
+
+const (
+	Width        = 32
+	Height       = 32
+	Channels     = 3
+	Labels       = 10 // airplane, automobile, bird, cat, deer, dog, frog, horse, ship, truck
+	TrainBatches = 5
+	Records      = 10000                     // the number of records in a batch file
+	RecordSize   = 1 + Channels*Height*Width // label + CHW pixels
+)
+
+type (
+	Image [Channels * Height * Width]byte // CHW. red, green and blue planes
+	Label uint8
+)
+
+// Dataset is a dataset of CIFAR-10.
+type Dataset struct {
+	N     int
+	Image []Image
+	Label []Label
+}
+
+// Len returns the number of the images.
+func (d *Dataset) Len() int {
+	return d.N
+}
+
+// Get returns the normalized image and the one-hot label of the index i.
+func (d *Dataset) Get(i int) ([]float64, []float64) {
+	return Normalize(d.Image[i : i+1])[0], OneHot(d.Label[i : i+1])[0]
+}
+
+// batch returns the images and the labels of the binary batch file.
+// Each record is 1 label byte and 3072 bytes of the pixels.
+func batch(fileName string) ([]Image, []Label, error) {
+	bytes, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("open file=%v: %v", fileName, err)
+	}
+
+	if len(bytes) == 0 || len(bytes)%RecordSize != 0 {
+		return nil, nil, fmt.Errorf("invalid size=%v", len(bytes))
+	}
+
+	n := len(bytes) / RecordSize
+	images, labels := make([]Image, n), make([]Label, n)
+	for i := 0; i < n; i++ {
+		r := bytes[i*RecordSize : (i+1)*RecordSize]
+		if r[0] >= Labels {
+			return nil, nil, fmt.Errorf("invalid label[%v]=%v", i, r[0])
+		}
+
+		labels[i] = Label(r[0])
+		copy(images[i][:], r[1:])
+	}
+
+	return images, labels, nil
+}
+
+// load returns the dataset of the batch files.
+// Each file must have n records, or any number of records if n is 0.
+func load(n int, fileName ...string) (*Dataset, error) {
+	images, labels := make([]Image, 0), make([]Label, 0)
+	for _, f := range fileName {
+		img, lbl, err := batch(f)
+		if err != nil {
+			return nil, fmt.Errorf("load=%v: %v", f, err)
+		}
+
+		if n > 0 && len(img) != n {
+			return nil, fmt.Errorf("load=%v: records=%v, want=%v", f, len(img), n)
+		}
+
+		images = append(images, img...)
+		labels = append(labels, lbl...)
+	}
+
+	return &Dataset{
+		N:     len(images),
+		Image: images,
+		Label: labels,
+	}, nil
+}
+
+// Must returns training and test dataset or panic.
+func Must(train, test *Dataset, err error) (*Dataset, *Dataset) {
+	if err != nil {
+		panic(err)
+	}
+
+	return train, test
+}
+
+// Load returns training and test dataset.
+// dir is the directory of the binary version, e.g. cifar-10-batches-bin.
+// Each batch file must have 10000 records.
+func Load(dir string) (*Dataset, *Dataset, error) {
+	return loadDir(dir, Records)
+}
+
+// loadDir returns training and test dataset of the batch files of n records.
+func loadDir(dir string, n int) (*Dataset, *Dataset, error) {
+	files := make([]string, TrainBatches)
+	for i := range files {
+		files[i] = path.Join(dir, fmt.Sprintf(TrainBin, i+1))
+	}
+
+	train, err := load(n, files...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load training data: %v", err)
+	}
+
+	test, err := load(n, path.Join(dir, TestBin))
+	if err != nil {
+		return nil, nil, fmt.Errorf("load test data: %v", err)
+	}
+
+	return train, test, nil
+}
+
+// OneHot returns one-hot vector.
+func OneHot(label []Label) [][]float64 {
+	out := make([][]float64, len(label))
+	for i, v := range label {
+		out[i] = make([]float64, Labels) // 0 ~ 9
+		out[i][v] = 1.0
+	}
+
+	return out
+}
+
+// Normalize returns normalized image in CHW.
+func Normalize(img []Image) [][]float64 {
+	out := make([][]float64, len(img))
+	for i := range img {
+		out[i] = make([]float64, len(img[i]))
+		for j := range img[i] {
+			out[i][j] = float64(img[i][j]) / float64(math.MaxUint8)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,205 @@
+package cifar10_test
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/itsubaki/neu/dataset/cifar10"
+)
+
+// fixture writes the training and test batch files of n records in a temporary directory.
+// The label of the record i is i % 10, and the pixels of each channel are i, i + 1 and i + 2.
+func fixture(n int) string {
+	dir, err := os.MkdirTemp("", "cifar10")
+	if err != nil {
+		panic(err)
+	}
+
+	files := []string{cifar10.TestBin}
+	for i := 0; i < cifar10.TrainBatches; i++ {
+		files = append(files, fmt.Sprintf(cifar10.TrainBin, i+1))
+	}
+
+	for _, f := range files {
+		if err := os.WriteFile(path.Join(dir, f), records(n), 0o600); err != nil {
+			panic(err)
+		}
+	}
+
+	return dir
+}
+
+func records(n int) []byte {
+	size := cifar10.Height * cifar10.Width
+
+	out := make([]byte, 0, n*cifar10.RecordSize)
+	for i := 0; i < n; i++ {
+		out = append(out, byte(i%cifar10.Labels))
+		for c := 0; c < cifar10.Channels; c++ {
+			for j := 0; j < size; j++ {
+				out = append(out, byte(i+c))
+			}
+		}
+	}
+
+	return out
+}
+
+func ExampleLoad() {
+	dir := fixture(3)
+	defer os.RemoveAll(dir)
+
+	train, test := cifar10.Must(cifar10.LoadDir(dir, 3))
+	fmt.Println(train.N)
+	fmt.Println(test.N)
+
+	fmt.Println(len(train.Image[0]))
+	fmt.Println(train.Label)
+	fmt.Println(train.Image[2][0], train.Image[2][1024], train.Image[2][2048])
+
+	// Output:
+	// 15
+	// 3
+	// 3072
+	// [0 1 2 0 1 2 0 1 2 0 1 2 0 1 2]
+	// 2 3 4
+}
+
+func ExampleLoad_records() {
+	dir := fixture(3)
+	defer os.RemoveAll(dir)
+
+	_, _, err := cifar10.Load(dir)
+	fmt.Println(strings.ReplaceAll(err.Error(), dir, "dir"))
+
+	// Output:
+	// load training data: load=dir/data_batch_1.bin: records=3, want=10000
+}
+
+func ExampleLoad_notfound() {
+	_, _, err := cifar10.Load("invalid_dir")
+	fmt.Println(err)
+
+	// Output:
+	// load training data: load=invalid_dir/data_batch_1.bin: open file=invalid_dir/data_batch_1.bin: open invalid_dir/data_batch_1.bin: no such file or directory
+}
+
+func ExampleLoad_test() {
+	dir := fixture(1)
+	defer os.RemoveAll(dir)
+
+	if err := os.Remove(path.Join(dir, cifar10.TestBin)); err != nil {
+		panic(err)
+	}
+
+	_, _, err := cifar10.LoadDir(dir, 1)
+	fmt.Println(err != nil)
+
+	// Output:
+	// true
+}
+
+func ExampleLoadBatch_invalidSize() {
+	dir := fixture(1)
+	defer os.RemoveAll(dir)
+
+	f := path.Join(dir, cifar10.TestBin)
+	if err := os.WriteFile(f, records(2)[:cifar10.RecordSize+1], 0o600); err != nil {
+		panic(err)
+	}
+
+	_, _, err := cifar10.LoadBatch(f)
+	fmt.Println(err)
+
+	// Output:
+	// invalid size=3074
+}
+
+func ExampleLoadBatch_invalidLabel() {
+	dir := fixture(1)
+	defer os.RemoveAll(dir)
+
+	r := records(2)
+	r[cifar10.RecordSize] = 10
+
+	f := path.Join(dir, cifar10.TestBin)
+	if err := os.WriteFile(f, r, 0o600); err != nil {
+		panic(err)
+	}
+
+	_, _, err := cifar10.LoadBatch(f)
+	fmt.Println(err)
+
+	// Output:
+	// invalid label[1]=10
+}
+
+func ExampleLoadFile() {
+	_, err := cifar10.LoadFile(0, "invalid_file")
+	fmt.Println(err)
+
+	// Output:
+	// load=invalid_file: open file=invalid_file: open invalid_file: no such file or directory
+}
+
+func ExampleNormalize() {
+	img := []cifar10.Image{{byte(0)}, {byte(10)}, {byte(20)}, {byte(255)}}
+	for _, r := range cifar10.Normalize(img) {
+		fmt.Printf("%.4f %v\n", r[0], len(r))
+	}
+
+	// Output:
+	// 0.0000 3072
+	// 0.0392 3072
+	// 0.0784 3072
+	// 1.0000 3072
+}
+
+func ExampleOneHot() {
+	label := []cifar10.Label{0, 3, 9}
+	for _, r := range cifar10.OneHot(label) {
+		fmt.Printf("%.0f\n", r)
+	}
+
+	// Output:
+	// [1 0 0 0 0 0 0 0 0 0]
+	// [0 0 0 1 0 0 0 0 0 0]
+	// [0 0 0 0 0 0 0 0 0 1]
+}
+
+func ExampleDataset_Get() {
+	dir := fixture(3)
+	defer os.RemoveAll(dir)
+
+	_, test := cifar10.Must(cifar10.LoadDir(dir, 3))
+	x, t := test.Get(1)
+	fmt.Println(test.Len())
+	fmt.Printf("%.4f %v\n", []float64{x[0], x[1024], x[2048]}, len(x))
+	fmt.Println(t)
+
+	// Output:
+	// 3
+	// [0.0039 0.0078 0.0118] 3072
+	// [0 1 0 0 0 0 0 0 0 0]
+}
+
+func TestMust(t *testing.T) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err, ok := rec.(error)
+			if !ok {
+				t.Fail()
+			}
+
+			if err.Error() != "something went wrong" {
+				t.Fail()
+			}
+		}
+	}()
+
+	cifar10.Must(nil, nil, fmt.Errorf("something went wrong"))
+	t.Fail()
+}
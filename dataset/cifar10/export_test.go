@@ -0,0 +1,7 @@
+package cifar10
+
+var (
+	LoadDir   = loadDir
+	LoadFile  = load
+	LoadBatch = batch
+)
@@ -0,0 +1,42 @@
+package dataset
+
+import "github.com/itsubaki/neu/math/matrix"
+
+var (
+	_ Dataset = (*Matrix)(nil)
+	_ Dataset = (*Ints)(nil)
+)
+
+// Dataset is a dataset of the samples, which are read one by one.
+type Dataset interface {
+	Len() int
+	Get(i int) (x, t []float64)
+}
+
+// Matrix is the dataset of the rows of X and T.
+type Matrix struct {
+	X, T matrix.Matrix
+}
+
+func (d *Matrix) Len() int                         { return len(d.X) }
+func (d *Matrix) Get(i int) ([]float64, []float64) { return d.X[i], d.T[i] }
+
+// Ints is the dataset of the sequences of ids, e.g. the questions and the answers of the sequence dataset.
+type Ints struct {
+	X, T [][]int
+}
+
+func (d *Ints) Len() int { return len(d.X) }
+
+func (d *Ints) Get(i int) ([]float64, []float64) {
+	return float(d.X[i]), float(d.T[i])
+}
+
+func float(v []int) []float64 {
+	out := make([]float64, len(v))
+	for i := range v {
+		out[i] = float64(v[i])
+	}
+
+	return out
+}
@@ -0,0 +1,43 @@
+package dataset_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/dataset"
+	"github.com/itsubaki/neu/dataset/cifar10"
+	"github.com/itsubaki/neu/dataset/mnist"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+var (
+	_ dataset.Dataset = (*cifar10.Dataset)(nil)
+	_ dataset.Dataset = (*mnist.Dataset)(nil)
+)
+
+func ExampleMatrix() {
+	d := &dataset.Matrix{
+		X: matrix.New([]float64{0, 1}, []float64{2, 3}),
+		T: matrix.New([]float64{1, 0}, []float64{0, 1}),
+	}
+
+	fmt.Println(d.Len())
+	fmt.Println(d.Get(1))
+
+	// Output:
+	// 2
+	// [2 3] [0 1]
+}
+
+func ExampleInts() {
+	d := &dataset.Ints{
+		X: [][]int{{1, 2, 3}, {4, 5, 6}},
+		T: [][]int{{7, 8}, {9, 10}},
+	}
+
+	fmt.Println(d.Len())
+	fmt.Println(d.Get(1))
+
+	// Output:
+	// 2
+	// [4 5 6] [9 10]
+}
@@ -0,0 +1,131 @@
+package dataset
+
+import (
+	randv2 "math/rand/v2"
+	"sync"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+)
+
+// DataLoader reads the batches of the dataset.
+type DataLoader struct {
+	Dataset   Dataset
+	BatchSize int
+	Shuffle   bool // shuffle the samples in each epoch
+	DropLast  bool // drop the last batch if it is smaller than BatchSize
+	Prefetch  int  // the number of the batches read in the background. 0 reads each batch in Next
+}
+
+// Len returns the number of the batches in an epoch.
+func (l *DataLoader) Len() int {
+	if l.DropLast {
+		return l.Dataset.Len() / l.BatchSize
+	}
+
+	return (l.Dataset.Len() + l.BatchSize - 1) / l.BatchSize
+}
+
+// Iter returns the iterator over the batches of an epoch, which starts from the batch begin.
+// If Shuffle is true, the samples are shuffled by s, in the same order as vector.Shuffle.
+// The iterator should be closed to stop the prefetching, if it is not read to the end.
+func (l *DataLoader) Iter(begin int, s ...randv2.Source) *Iterator {
+	index := make([]int, l.Dataset.Len())
+	for i := range index {
+		index[i] = i
+	}
+
+	if l.Shuffle {
+		if len(s) == 0 {
+			s = append(s, rand.NewSource(rand.MustRead()))
+		}
+		g := randv2.New(s[0])
+
+		for i := range index {
+			j := g.IntN(i + 1)
+			index[i], index[j] = index[j], index[i]
+		}
+	}
+
+	batches := l.Len()
+	if l.Prefetch < 1 {
+		j := begin
+		return &Iterator{
+			next: func() (matrix.Matrix, matrix.Matrix, bool) {
+				if j >= batches {
+					return nil, nil, false
+				}
+
+				x, t := l.batch(index, j)
+				j++
+				return x, t, true
+			},
+		}
+	}
+
+	ch := make(chan [2]matrix.Matrix, l.Prefetch)
+	done := make(chan struct{})
+	go func() {
+		defer close(ch)
+
+		for j := begin; j < batches; j++ {
+			x, t := l.batch(index, j)
+
+			select {
+			case ch <- [2]matrix.Matrix{x, t}:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return &Iterator{
+		next: func() (matrix.Matrix, matrix.Matrix, bool) {
+			b, ok := <-ch
+			return b[0], b[1], ok
+		},
+		done: done,
+	}
+}
+
+// batch returns the batch j of the samples in the order of index.
+func (l *DataLoader) batch(index []int, j int) (matrix.Matrix, matrix.Matrix) {
+	begin := j * l.BatchSize
+	end := min(begin+l.BatchSize, len(index))
+
+	x, t := make(matrix.Matrix, end-begin), make(matrix.Matrix, end-begin)
+	for k, i := range index[begin:end] {
+		x[k], t[k] = l.Dataset.Get(i)
+	}
+
+	return x, t
+}
+
+// Iterator is the iterator over the batches.
+type Iterator struct {
+	next func() (matrix.Matrix, matrix.Matrix, bool)
+	x, t matrix.Matrix
+	done chan struct{}
+	once sync.Once
+}
+
+// Next reads the next batch, and returns false if there are no more batches.
+func (it *Iterator) Next() bool {
+	var ok bool
+	it.x, it.t, ok = it.next()
+	return ok
+}
+
+// Batch returns the batch read by Next.
+func (it *Iterator) Batch() (matrix.Matrix, matrix.Matrix) {
+	return it.x, it.t
+}
+
+// Close stops the prefetching.
+func (it *Iterator) Close() {
+	if it.done == nil {
+		return
+	}
+
+	it.once.Do(func() { close(it.done) })
+}
@@ -0,0 +1,96 @@
+package dataset_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/dataset"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/math/vector"
+)
+
+func newMatrix() *dataset.Matrix {
+	return &dataset.Matrix{
+		X: matrix.New([]float64{0}, []float64{1}, []float64{2}, []float64{3}, []float64{4}),
+		T: matrix.New([]float64{0}, []float64{10}, []float64{20}, []float64{30}, []float64{40}),
+	}
+}
+
+func ExampleDataLoader() {
+	l := &dataset.DataLoader{Dataset: newMatrix(), BatchSize: 2}
+	fmt.Println(l.Len())
+
+	it := l.Iter(0)
+	for it.Next() {
+		fmt.Println(it.Batch())
+	}
+
+	// Output:
+	// 3
+	// [[0] [1]] [[0] [10]]
+	// [[2] [3]] [[20] [30]]
+	// [[4]] [[40]]
+}
+
+func ExampleDataLoader_dropLast() {
+	l := &dataset.DataLoader{Dataset: newMatrix(), BatchSize: 2, DropLast: true}
+	fmt.Println(l.Len())
+
+	it := l.Iter(0)
+	for it.Next() {
+		fmt.Println(it.Batch())
+	}
+
+	// Output:
+	// 2
+	// [[0] [1]] [[0] [10]]
+	// [[2] [3]] [[20] [30]]
+}
+
+func ExampleDataLoader_shuffle() {
+	d := newMatrix()
+	l := &dataset.DataLoader{Dataset: d, BatchSize: 5, Shuffle: true}
+
+	it := l.Iter(0, rand.Const(1))
+	for it.Next() {
+		fmt.Println(it.Batch())
+	}
+
+	// the same order as vector.Shuffle
+	fmt.Println(vector.Shuffle(d.X, d.T, rand.Const(1)))
+
+	// Output:
+	// [[3] [0] [2] [4] [1]] [[30] [0] [20] [40] [10]]
+	// [[3] [0] [2] [4] [1]] [[30] [0] [20] [40] [10]]
+}
+
+func ExampleDataLoader_prefetch() {
+	l := &dataset.DataLoader{Dataset: newMatrix(), BatchSize: 2, Shuffle: true, Prefetch: 2}
+
+	// from the batch 1
+	it := l.Iter(1, rand.Const(1))
+	defer it.Close()
+
+	for it.Next() {
+		fmt.Println(it.Batch())
+	}
+
+	// Output:
+	// [[2] [4]] [[20] [40]]
+	// [[1]] [[10]]
+}
+
+func ExampleIterator_Close() {
+	l := &dataset.DataLoader{Dataset: newMatrix(), BatchSize: 1, Prefetch: 1}
+
+	it := l.Iter(0)
+	it.Next()
+	fmt.Println(it.Batch())
+
+	// stop the prefetching
+	it.Close()
+	it.Close()
+
+	// Output:
+	// [[0]] [[0]]
+}
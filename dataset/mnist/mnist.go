@@ -35,6 +35,16 @@ type Dataset struct {
 	Label []Label
 }
 
+// Len returns the number of the images.
+func (d *Dataset) Len() int {
+	return d.N
+}
+
+// Get returns the normalized image and the one-hot label of the index i.
+func (d *Dataset) Get(i int) ([]float64, []float64) {
+	return Normalize(d.Image[i : i+1])[0], OneHot(d.Label[i : i+1])[0]
+}
+
 func image(fileName string) ([]Image, error) {
 	f, err := os.Open(filepath.Clean(fileName))
 	if err != nil {
@@ -105,6 +105,24 @@ func ExampleOneHot() {
 	// [0 0 0 0 0 0 0 0 0 1]
 }
 
+func ExampleDataset_Get() {
+	d := &mnist.Dataset{
+		N:     2,
+		Image: []mnist.Image{{byte(0), byte(255)}, {byte(20)}},
+		Label: []mnist.Label{3, 7},
+	}
+
+	x, t := d.Get(1)
+	fmt.Println(d.Len())
+	fmt.Printf("%.4f %v\n", x[:2], len(x))
+	fmt.Println(t)
+
+	// Output:
+	// 2
+	// [0.0784 0.0000] 784
+	// [0 0 0 0 0 0 0 1 0 0]
+}
+
 func TestMust(t *testing.T) {
 	defer func() {
 		if rec := recover(); rec != nil {
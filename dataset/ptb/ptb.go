@@ -35,6 +35,23 @@ func Load(dir, fileName string) (*Dataset, error) {
 	}, nil
 }
 
+// Convert returns the corpus with the word ids of w2id, e.g. the ids of the training data.
+// The validation and test data of PTB have their own ids, since Load builds the ids of each file.
+func (d *Dataset) Convert(w2id map[string]int) ([]int, error) {
+	out := make([]int, len(d.Corpus))
+	for i, id := range d.Corpus {
+		w := d.IDToWord[id]
+		v, ok := w2id[w]
+		if !ok {
+			return nil, fmt.Errorf("word=%v is not in the vocabulary", w)
+		}
+
+		out[i] = v
+	}
+
+	return out, nil
+}
+
 func PreProcess(text string) ([]int, map[int]string, map[string]int) {
 	rep := strings.TrimSpace(strings.ReplaceAll(text, "\n", "<eos>"))
 	words := strings.Split(rep, " ")
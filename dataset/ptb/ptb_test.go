@@ -43,6 +43,25 @@ func ExamplePreProcess() {
 	// 6: .
 }
 
+func ExampleDataset_Convert() {
+	train, _, w2id := ptb.PreProcess("you say goodbye and i say hello .")
+	fmt.Println(train)
+
+	corpus, id2w, _ := ptb.PreProcess("i say goodbye .")
+	valid := &ptb.Dataset{Corpus: corpus, IDToWord: id2w}
+	fmt.Println(valid.Corpus)
+	fmt.Println(valid.Convert(w2id))
+
+	corpus, id2w, _ = ptb.PreProcess("you say hi")
+	fmt.Println((&ptb.Dataset{Corpus: corpus, IDToWord: id2w}).Convert(w2id))
+
+	// Output:
+	// [0 1 2 3 4 1 5 6]
+	// [0 1 2 3]
+	// [4 1 2 6] <nil>
+	// [] word=hi is not in the vocabulary
+}
+
 func ExampleCreateContextsTarget() {
 	corpus := []int{0, 1, 2, 3, 4, 1, 5, 6}
 	contexts, targets := ptb.CreateContextsTarget(corpus, 1)
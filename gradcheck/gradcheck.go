@@ -0,0 +1,222 @@
+package gradcheck
+
+import (
+	"math"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/math/vector"
+	"github.com/itsubaki/neu/model"
+	"github.com/itsubaki/neu/trainer"
+)
+
+// H is the step of the central difference.
+const H = 1e-5
+
+// Opts is the options of the gradient check.
+type Opts struct {
+	Train bool   // passed to the layer as layer.Opts
+	Seed  uint64 // the random source of layer.Opts is reset with the seed before each Forward
+}
+
+// Result is the maximum relative error between the analytic and the numerical gradients.
+type Result struct {
+	Params []float64 // for each of Params()
+	X      float64   // for the input x
+	T      float64   // for the second input, if Backward returns the gradient
+}
+
+// Max returns the maximum relative error of all gradients.
+func (r Result) Max() float64 {
+	max := math.Max(r.X, r.T)
+	for _, e := range r.Params {
+		max = math.Max(max, e)
+	}
+
+	return max
+}
+
+// Layer checks the gradients of a layer.
+// The loss is sum(Forward(x, t) * dout), where dout is random with the seed.
+// The params are perturbed in place, so Params() must return the matrices used by Forward.
+func Layer(l model.Layer, x, t matrix.Matrix, opts ...Opts) Result {
+	o := option(opts...)
+	a, b := l.Forward(x, t, o.layer()).Dim()
+	dout := matrix.Randn(a, b, rand.Const(o.Seed))
+
+	loss := func() float64 {
+		return l.Forward(x, t, o.layer()).Mul(dout).Sum()
+	}
+
+	l.Forward(x, t, o.layer())
+	dx, dt := l.Backward(dout)
+	grads := l.Grads()
+
+	var r Result
+	r.Params = check(l.Params(), grads, loss)
+	if dx != nil {
+		r.X = check([]matrix.Matrix{x}, []matrix.Matrix{dx}, loss)[0]
+	}
+
+	if dt != nil && t != nil {
+		r.T = check([]matrix.Matrix{t}, []matrix.Matrix{dt}, loss)[0]
+	}
+
+	return r
+}
+
+// TimeLayer checks the gradients of a time layer.
+// The loss is sum(Forward(xs, ts) * douts), where douts is random with the seed.
+// The state is reset before each Forward.
+func TimeLayer(l model.TimeLayer, xs, ts []matrix.Matrix, opts ...Opts) Result {
+	o := option(opts...)
+
+	forward := func() []matrix.Matrix {
+		l.ResetState()
+		return l.Forward(xs, ts, o.layer())
+	}
+
+	s := rand.Const(o.Seed)
+	ys := forward()
+	douts := make([]matrix.Matrix, len(ys))
+	for i := range ys {
+		a, b := ys[i].Dim()
+		douts[i] = matrix.Randn(a, b, s)
+	}
+
+	loss := func() float64 {
+		var sum float64
+		for i, y := range forward() {
+			sum = sum + y.Mul(douts[i]).Sum()
+		}
+
+		return sum
+	}
+
+	forward()
+	dxs := l.Backward(douts)
+	grads := l.Grads()
+
+	var r Result
+	r.Params = check(l.Params(), grads, loss)
+	if dxs != nil {
+		r.X = vector.Max(check(xs, dxs, loss))
+	}
+
+	return r
+}
+
+// Model checks the gradients of a model.
+// The loss is Forward(x, t), and Result.Params is in the order of the flattened Params().
+func Model(m trainer.Model, x, t matrix.Matrix) Result {
+	loss := func() float64 {
+		return m.Forward(x, t).Sum()
+	}
+
+	m.Forward(x, t)
+	dx := m.Backward()
+
+	params, grads := make([]matrix.Matrix, 0), make([]matrix.Matrix, 0)
+	for i, p := range m.Params() {
+		params, grads = append(params, p...), append(grads, m.Grads()[i]...)
+	}
+
+	var r Result
+	r.Params = check(params, grads, loss)
+	if dx != nil {
+		r.X = check([]matrix.Matrix{x}, []matrix.Matrix{dx}, loss)[0]
+	}
+
+	return r
+}
+
+// RNNLM checks the gradients of a language model.
+// The loss is Forward(xs, ts), and Result.Params is in the order of the flattened Params().
+// The state is reset before each Forward if the model has ResetState, and the dropout ratio should be 0.
+func RNNLM(m trainer.RNNLM, xs, ts []matrix.Matrix) Result {
+	loss := func() float64 {
+		if r, ok := m.(interface{ ResetState() }); ok {
+			r.ResetState()
+		}
+
+		return m.Forward(xs, ts)[0][0][0]
+	}
+
+	loss()
+	m.Backward()
+
+	params, grads := make([]matrix.Matrix, 0), make([]matrix.Matrix, 0)
+	for i, p := range m.Params() {
+		params, grads = append(params, p...), append(grads, m.Grads()[i]...)
+	}
+
+	var r Result
+	r.Params = check(params, grads, loss)
+	return r
+}
+
+// check returns the maximum relative error of each of grads.
+// The params that share the same storage are tied, so the numerical gradient is compared with the sum of their grads.
+func check(params, grads []matrix.Matrix, loss func() float64) []float64 {
+	out := make([]float64, len(params))
+	for k := range params {
+		// tied params
+		grad := grads[k]
+		for m := range params {
+			if m != k && same(params[m], params[k]) {
+				grad = grad.Add(grads[m])
+			}
+		}
+
+		for i := range params[k] {
+			for j := range params[k][i] {
+				tmp := params[k][i][j]
+
+				params[k][i][j] = tmp + H
+				fxh1 := loss()
+
+				params[k][i][j] = tmp - H
+				fxh2 := loss()
+
+				params[k][i][j] = tmp
+				numerical := (fxh1 - fxh2) / (2 * H)
+
+				out[k] = math.Max(out[k], relerr(grad[i][j], numerical))
+			}
+		}
+	}
+
+	return out
+}
+
+// relerr returns the relative error of a and b.
+func relerr(a, b float64) float64 {
+	d := math.Abs(a - b)
+	if d == 0 {
+		return 0
+	}
+
+	return d / math.Max(math.Max(math.Abs(a), math.Abs(b)), 1e-8)
+}
+
+// same returns true if x and y share the same storage.
+func same(x, y matrix.Matrix) bool {
+	if len(x) == 0 || len(y) == 0 || len(x[0]) == 0 || len(y[0]) == 0 {
+		return false
+	}
+
+	return &x[0][0] == &y[0][0]
+}
+
+func (o Opts) layer() layer.Opts {
+	return layer.Opts{Train: o.Train, Source: rand.Const(o.Seed)}
+}
+
+func option(opts ...Opts) Opts {
+	if len(opts) == 0 {
+		return Opts{}
+	}
+
+	return opts[0]
+}
@@ -0,0 +1,123 @@
+package gradcheck_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/gradcheck"
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+	"github.com/itsubaki/neu/weight"
+)
+
+// broken is an Affine with the gradient of B doubled.
+type broken struct {
+	layer.Affine
+}
+
+func (l *broken) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+	dx, _ := l.Affine.Backward(dout)
+	l.DB = l.DB.MulC(2)
+	return dx, nil
+}
+
+func ExampleLayer() {
+	s := rand.Const(1)
+	l := &layer.Affine{
+		W: matrix.Randn(3, 4, s),
+		B: matrix.Randn(1, 4, s),
+	}
+
+	x := matrix.Randn(2, 3, s)
+	r := gradcheck.Layer(l, x, nil)
+
+	fmt.Println(len(r.Params))
+	fmt.Println(r.Max() < 1e-6)
+
+	// Output:
+	// 2
+	// true
+}
+
+func ExampleLayer_broken() {
+	s := rand.Const(1)
+	l := &broken{layer.Affine{
+		W: matrix.Randn(3, 4, s),
+		B: matrix.Randn(1, 4, s),
+	}}
+
+	r := gradcheck.Layer(l, matrix.Randn(2, 3, s), nil)
+	fmt.Printf("W: %v\n", r.Params[0] < 1e-6)
+	fmt.Printf("B: %.4f\n", r.Params[1])
+	fmt.Printf("X: %v\n", r.X < 1e-6)
+
+	// Output:
+	// W: true
+	// B: 0.5000
+	// X: true
+}
+
+func ExampleTimeLayer() {
+	s := rand.Const(1)
+	l := &layer.TimeLSTM{
+		Wx: matrix.Randn(3, 4*2, s),
+		Wh: matrix.Randn(2, 4*2, s),
+		B:  matrix.Randn(1, 4*2, s),
+	}
+
+	xs := []matrix.Matrix{
+		matrix.Randn(2, 3, s),
+		matrix.Randn(2, 3, s),
+	}
+
+	r := gradcheck.TimeLayer(l, xs, nil)
+	fmt.Println(len(r.Params))
+	fmt.Println(r.Max() < 1e-6)
+
+	// Output:
+	// 3
+	// true
+}
+
+func ExampleModel() {
+	s := rand.Const(1)
+	m := model.NewMLP(&model.MLPConfig{
+		InputSize:  3,
+		OutputSize: 2,
+		HiddenSize: []int{4},
+		WeightInit: weight.Std(0.1),
+	}, s)
+
+	x := matrix.Randn(4, 3, s)
+	t := matrix.OneHot([]int{0, 1, 1, 0}, 2)
+
+	r := gradcheck.Model(m, x, t)
+	fmt.Println(len(r.Params))
+	fmt.Println(r.Max() < 1e-4)
+
+	// Output:
+	// 6
+	// true
+}
+
+func ExampleRNNLM() {
+	s := rand.Const(1)
+	m := model.NewRNNLM(&model.RNNLMConfig{
+		VocabSize:   5,
+		WordVecSize: 4,
+		HiddenSize:  4,
+		WeightInit:  weight.Xavier,
+	}, s)
+
+	xs := []matrix.Matrix{{{0}, {3}}, {{1}, {4}}}
+	ts := []matrix.Matrix{{{1}, {4}}, {{2}, {0}}}
+
+	r := gradcheck.RNNLM(m, xs, ts)
+	fmt.Println(len(r.Params))
+	fmt.Println(r.Max() < 1e-4)
+
+	// Output:
+	// 6
+	// true
+}
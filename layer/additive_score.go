@@ -0,0 +1,68 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/activation"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// AdditiveScore is the additive score of the attention, tanh(hs W1 + h W2) . V, by Bahdanau et al.
+// W1 and W2 are (H, A), and V is (A, 1).
+type AdditiveScore struct {
+	W1, W2, V    matrix.Matrix // params
+	DW1, DW2, DV matrix.Matrix // grads
+	hs, e        []matrix.Matrix
+	h            matrix.Matrix
+}
+
+func (l *AdditiveScore) Params() []matrix.Matrix { return []matrix.Matrix{l.W1, l.W2, l.V} }
+func (l *AdditiveScore) ParamNames() []string    { return []string{"W1", "W2", "V"} }
+func (l *AdditiveScore) Grads() []matrix.Matrix  { return []matrix.Matrix{l.DW1, l.DW2, l.DV} }
+func (l *AdditiveScore) SetParams(p ...matrix.Matrix) {
+	l.W1, l.W2, l.V = p[0], p[1], p[2]
+}
+
+func (l *AdditiveScore) Clone() Scorer { return &AdditiveScore{W1: l.W1, W2: l.W2, V: l.V} }
+func (l *AdditiveScore) String() string {
+	a, b := l.W1.Dim()
+	c, d := l.W2.Dim()
+	e, f := l.V.Dim()
+	return fmt.Sprintf("%T: W1(%v, %v), W2(%v, %v), V(%v, %v): %v", l, a, b, c, d, e, f, a*b+c*d+e*f)
+}
+
+func (l *AdditiveScore) Forward(hs []matrix.Matrix, h matrix.Matrix) matrix.Matrix {
+	T := len(hs)
+	l.hs, l.h = hs, h
+	l.e = make([]matrix.Matrix, T)
+	hW2 := matrix.Dot(h, l.W2) // (N, A)
+
+	s := make(matrix.Matrix, T) // (T, N)
+	for i := 0; i < T; i++ {
+		l.e[i] = matrix.F(matrix.Dot(hs[i], l.W1).Add(hW2), activation.Tanh) // tanh(hs W1 + h W2) (N, A)
+		s[i] = matrix.Dot(l.e[i], l.V).T()[0]                                // (N, 1) -> (1, N)
+	}
+
+	return s
+}
+
+func (l *AdditiveScore) Backward(ds matrix.Matrix) ([]matrix.Matrix, matrix.Matrix) {
+	T := len(l.hs)
+	l.DW1 = matrix.ZeroLike(l.W1)
+	l.DV = matrix.ZeroLike(l.V)
+
+	dhs := make([]matrix.Matrix, T)
+	dhW2 := matrix.Zero(len(l.h), len(l.W2[0])) // (N, A)
+	for i := 0; i < T; i++ {
+		dsi := matrix.New(ds[i]).T()                                // (1, N) -> (N, 1)
+		l.DV = l.DV.Add(matrix.Dot(l.e[i].T(), dsi))                // (A, 1)
+		dz := matrix.Dot(dsi, l.V.T()).Mul(matrix.F(l.e[i], dTanh)) // (N, A)
+		l.DW1 = l.DW1.Add(matrix.Dot(l.hs[i].T(), dz))              // (H, A)
+		dhs[i] = matrix.Dot(dz, l.W1.T())                           // (N, H)
+		dhW2 = dhW2.Add(dz)
+	}
+
+	l.DW2 = matrix.Dot(l.h.T(), dhW2) // (H, A)
+	dh := matrix.Dot(dhW2, l.W2.T())  // (N, H)
+	return dhs, dh
+}
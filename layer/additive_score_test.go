@@ -0,0 +1,58 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+func ExampleAdditiveScore() {
+	l := &layer.AdditiveScore{
+		W1: matrix.New([]float64{0.1, -0.2}, []float64{0.0, 0.1}, []float64{-0.1, 0.0}),
+		W2: matrix.New([]float64{0.2, 0.1}, []float64{-0.1, 0.0}, []float64{0.0, -0.1}),
+		V:  matrix.New([]float64{1}, []float64{2}),
+	}
+	fmt.Println(l)
+
+	// (T, N, H) = (2, 2, 3)
+	hs := []matrix.Matrix{
+		{{1, 2, 3}, {4, 5, 6}},
+		{{4, 5, 6}, {4, 5, 6}},
+	}
+	h := matrix.New([]float64{1, 2, 3}, []float64{2, 2, 2})
+	for _, r := range l.Forward(hs, h) {
+		fmt.Printf("%.4f\n", r)
+	}
+
+	dhs, dh := l.Backward(matrix.New([]float64{1, 0}, []float64{0, 1}))
+	fmt.Println(len(dhs), len(dhs[0]), len(dhs[0][0]))
+	fmt.Println(len(dh), len(dh[0]))
+	for _, g := range l.Grads() {
+		fmt.Println(g.Dim())
+	}
+
+	// Output:
+	// *layer.AdditiveScore: W1(3, 2), W2(3, 2), V(2, 1): 14
+	// [-0.5921 -0.5826]
+	// [-1.1216 -0.5826]
+	// 2 2 3
+	// 2 3
+	// 3 2
+	// 3 2
+	// 2 1
+}
+
+func ExampleAdditiveScore_Params() {
+	l := &layer.AdditiveScore{}
+	l.SetParams(make([]matrix.Matrix, 3)...)
+
+	fmt.Println(l.Params())
+	fmt.Println(l.ParamNames())
+	fmt.Println(l.Grads())
+
+	// Output:
+	// [[] [] []]
+	// [W1 W2 V]
+	// [[] [] []]
+}
@@ -4,32 +4,37 @@ import (
 	"fmt"
 
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
 // Affine is a layer that performs an affine transformation.
-type Affine struct {
-	W, B   matrix.Matrix // params
-	DW, DB matrix.Matrix // grads
-	x      matrix.Matrix
+type Affine = AffineOf[float64]
+
+// AffineOf is Affine for the parameters of E.
+type AffineOf[E vector.Float] struct {
+	W, B   matrix.Dense[E] // params
+	DW, DB matrix.Dense[E] // grads
+	x      matrix.Dense[E]
 }
 
-func (l *Affine) Params() []matrix.Matrix      { return []matrix.Matrix{l.W, l.B} }
-func (l *Affine) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DW, l.DB} }
-func (l *Affine) SetParams(p ...matrix.Matrix) { l.W, l.B = p[0], p[1] }
-func (l *Affine) String() string {
+func (l *AffineOf[E]) Params() []matrix.Dense[E]      { return []matrix.Dense[E]{l.W, l.B} }
+func (l *AffineOf[E]) ParamNames() []string           { return []string{"W", "B"} }
+func (l *AffineOf[E]) Grads() []matrix.Dense[E]       { return []matrix.Dense[E]{l.DW, l.DB} }
+func (l *AffineOf[E]) SetParams(p ...matrix.Dense[E]) { l.W, l.B = p[0], p[1] }
+func (l *AffineOf[E]) String() string {
 	a, b := l.W.Dim()
 	c, d := l.B.Dim()
-	return fmt.Sprintf("%T: W(%v, %v), B(%v, %v): %v", l, a, b, c, d, a*b+c*d)
+	return fmt.Sprintf("%v: W(%v, %v), B(%v, %v): %v", typeName(l), a, b, c, d, a*b+c*d)
 }
 
-func (l *Affine) Forward(x, _ matrix.Matrix, _ ...Opts) matrix.Matrix {
+func (l *AffineOf[E]) Forward(x, _ matrix.Dense[E], _ ...Opts) matrix.Dense[E] {
 	l.x = x
 	return matrix.Dot(l.x, l.W).Add(l.B) // x.W + B
 }
 
-func (l *Affine) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+func (l *AffineOf[E]) Backward(dout matrix.Dense[E]) (matrix.Dense[E], matrix.Dense[E]) {
 	dx := matrix.Dot(dout, l.W.T())
 	l.DW = matrix.Dot(l.x.T(), dout)
-	l.DB = matrix.New(dout.SumAxis0()) // Adjusting the shape
+	l.DB = matrix.NewOf(dout.SumAxis0()) // Adjusting the shape
 	return dx, nil
 }
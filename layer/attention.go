@@ -12,9 +12,10 @@ type Attention struct {
 	WeightSum       *WeightSum
 }
 
-func (l *Attention) Params() []matrix.Matrix      { return make([]matrix.Matrix, 0) }
-func (l *Attention) Grads() []matrix.Matrix       { return make([]matrix.Matrix, 0) }
-func (l *Attention) SetParams(p ...matrix.Matrix) {}
+func (l *Attention) Params() []matrix.Matrix      { return l.weight().Params() }
+func (l *Attention) ParamNames() []string         { return l.weight().ParamNames() }
+func (l *Attention) Grads() []matrix.Matrix       { return l.weight().Grads() }
+func (l *Attention) SetParams(p ...matrix.Matrix) { l.weight().SetParams(p...) }
 func (l *Attention) String() string               { return fmt.Sprintf("%T", l) }
 
 func (l *Attention) Forward(hs []matrix.Matrix, h matrix.Matrix) matrix.Matrix {
@@ -29,3 +30,11 @@ func (l *Attention) Backward(dout matrix.Matrix) ([]matrix.Matrix, matrix.Matrix
 	dhs := tensor.Add(dhs0, dhs1)
 	return dhs, dh
 }
+
+func (l *Attention) weight() *AttentionWeight {
+	if l.AttentionWeight == nil {
+		l.AttentionWeight = &AttentionWeight{Softmax: &Softmax{}}
+	}
+
+	return l.AttentionWeight
+}
@@ -45,9 +45,9 @@ func ExampleAttention() {
 
 	// Output:
 	// *layer.Attention
-	// [[3.5231884244466913 4.403985614959736 5.28478280547278] [4.476811237947822 5.596014047434778 6.7152168569217325]]
-	// [[[-1.8005623649265908e-06 -3.6011247298531815e-06] [2.000003826195054 4.00000360112473]] [[1.7615941559557682 3.5231883119115364] [0.23840584404423598 0.4768116880884711]]]
-	// [[-2.025632674825926e-06 -4.051265360309993e-06 -6.07689804579406e-06] [8.102530758336002e-06 1.0128163447920002e-05 1.2153796137504003e-05]]
+	// [[3.9999999543100615 4.999999954310062 5.999999954310062] [4 5 6]]
+	// [[[-2.4367966802902457e-07 -4.873593360580491e-07] [1 2]] [[2.0000002436796676 4.000000487359335] [1 2]]]
+	// [[8.224188791649352e-07 8.2241887866526e-07 8.224188781655848e-07] [0 0 0]]
 }
 
 func ExampleAttention_Params() {
@@ -4,43 +4,52 @@ import (
 	"fmt"
 
 	"github.com/itsubaki/neu/math/matrix"
-	"github.com/itsubaki/neu/math/tensor"
 	"github.com/itsubaki/neu/math/vector"
 )
 
+// Scorer is the score function of the attention.
+// Forward returns the scores (T, N) of the encoder outputs hs (T, N, H) for the decoder hidden state h (N, H),
+// and Backward returns the gradients of hs and h.
+// Clone returns the scorer that shares the params, and has its own cache for a time step.
+type Scorer interface {
+	Forward(hs []matrix.Matrix, h matrix.Matrix) matrix.Matrix
+	Backward(ds matrix.Matrix) ([]matrix.Matrix, matrix.Matrix)
+	Params() []matrix.Matrix
+	ParamNames() []string
+	Grads() []matrix.Matrix
+	SetParams(p ...matrix.Matrix)
+	Clone() Scorer
+	String() string
+}
+
 type AttentionWeight struct {
 	Softmax *Softmax
-	hs, hr  []matrix.Matrix
+	Score   Scorer // DotScore if nil
 }
 
-func (l *AttentionWeight) Params() []matrix.Matrix      { return make([]matrix.Matrix, 0) }
-func (l *AttentionWeight) Grads() []matrix.Matrix       { return make([]matrix.Matrix, 0) }
-func (l *AttentionWeight) SetParams(p ...matrix.Matrix) {}
+func (l *AttentionWeight) Params() []matrix.Matrix      { return l.score().Params() }
+func (l *AttentionWeight) ParamNames() []string         { return l.score().ParamNames() }
+func (l *AttentionWeight) Grads() []matrix.Matrix       { return l.score().Grads() }
+func (l *AttentionWeight) SetParams(p ...matrix.Matrix) { l.score().SetParams(p...) }
 func (l *AttentionWeight) String() string               { return fmt.Sprintf("%T", l) }
 
+// Forward returns the attention weights (T, N), which are the softmax of the scores over T.
 func (l *AttentionWeight) Forward(hs []matrix.Matrix, h matrix.Matrix) matrix.Matrix {
-	T := len(hs)                         // (T, N, H)
-	l.hs, l.hr = hs, tensor.Repeat(h, T) // (N, H) -> (T, N, H)
-	t := tensor.Mul(hs, l.hr)            // (T, N, H)
-
-	c := make(matrix.Matrix, T) // (T, N)
-	for i := 0; i < T; i++ {
-		c[i] = t[i].SumAxis1() // (N, H) -> (1, N)
-	}
-
-	return l.Softmax.Forward(c, nil) // (T, N)
+	s := l.score().Forward(hs, h)            // (T, N)
+	return l.Softmax.Forward(s.T(), nil).T() // (T, N) -> (N, T) -> (T, N)
 }
 
 func (l *AttentionWeight) Backward(da matrix.Matrix) ([]matrix.Matrix, matrix.Matrix) {
-	T, N, H := len(l.hs), len(l.hs[0]), len(l.hs[0][0])
+	ds, _ := l.Softmax.Backward(da.T()) // (N, T)
+	return l.score().Backward(ds.T())   // (T, N, H), (N, H)
+}
 
-	ds, _ := l.Softmax.Backward(da) // (T, N)
-	dt := Expand(ds, T, N, H)       // (T, N, H)
-	dhs := tensor.Mul(dt, l.hr)     // (T, N, H)
-	dhr := tensor.Mul(dt, l.hs)     // (T, N, H)
-	dh := tensor.SumAxis0(dhr)      // (N, H)
+func (l *AttentionWeight) score() Scorer {
+	if l.Score == nil {
+		l.Score = &DotScore{}
+	}
 
-	return dhs, dh // (T, N, H), (N, H)
+	return l.Score
 }
 
 func Expand(ds matrix.Matrix, T, N, H int) []matrix.Matrix {
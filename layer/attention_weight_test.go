@@ -22,30 +22,56 @@ func ExampleAttentionWeight() {
 		},
 		{
 			{4, 5, 6},
-			{4, 5, 6},
+			{1, 2, 3},
 		},
 	}
 	h := matrix.New(
-		[]float64{1, 2, 3},
-		[]float64{2, 2, 2},
+		[]float64{0.1, 0.2, 0.3},
+		[]float64{0.2, 0.2, 0.2},
 	)
-	fmt.Println(at.Forward(hs, h))
+	fmt.Printf("%.4f\n", at.Forward(hs, h))
 
 	// backward
 	da := matrix.Matrix{
 		// (T, N) (2, 2)
-		{2, 4},
-		{2, 4},
+		{1, 4},
+		{3, 2},
 	}
 	dhs, dh := at.Backward(da)
-	fmt.Println(dhs)
-	fmt.Println(dh)
+	fmt.Printf("%.4f\n", dhs)
+	fmt.Printf("%.4f\n", dh)
 
 	// Output:
 	// *layer.AttentionWeight
-	// [[1.12535162055095e-07 0.9999998874648379] [0.8807970779778823 0.11920292202211755]]
-	// [[[-2.2507029878186457e-07 -4.5014059756372915e-07 -6.752108963455937e-07] [4.5014059768533343e-07 4.5014059768533343e-07 4.5014059768533343e-07]] [[-0.20998717080701268 -0.41997434161402536 -0.629961512421038] [0.41997434161402614 0.41997434161402614 0.41997434161402614]]]
-	// [[-0.8399489082983495 -1.0499363041756609 -1.2599237000529724] [0.8399495835092476 1.0499369793865596 1.2599243752638714]]
+	// [[0.1419 0.8581] [0.8581 0.1419]]
+	// [[[-0.0243 -0.0487 -0.0730] [0.0487 0.0487 0.0487]] [[0.0243 0.0487 0.0730] [-0.0487 -0.0487 -0.0487]]]
+	// [[0.7304 0.7304 0.7304] [0.7304 0.7304 0.7304]]
+}
+
+func ExampleAttentionWeight_softmax() {
+	at := &layer.AttentionWeight{
+		Softmax: &layer.Softmax{},
+	}
+
+	hs := []matrix.Matrix{
+		// (T, N, H) (3, 2, 2)
+		{{1, 0}, {0, 1}},
+		{{0, 1}, {1, 0}},
+		{{1, 1}, {1, 1}},
+	}
+	h := matrix.New(
+		[]float64{1, 0},
+		[]float64{0, 2},
+	)
+
+	// the softmax over the encoder time steps T, not the batch N
+	a := at.Forward(hs, h) // (T, N)
+	fmt.Printf("%.4f\n", a)
+	fmt.Printf("%.4f\n", a.SumAxis0())
+
+	// Output:
+	// [[0.4223 0.4683] [0.1554 0.0634] [0.4223 0.4683]]
+	// [1.0000 1.0000]
 }
 
 func ExampleAttentionWeight_Params() {
@@ -0,0 +1,69 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/tensor"
+	"github.com/itsubaki/neu/math/vector"
+)
+
+// AvgPooling is a layer that performs an average pooling.
+// The input is (N, C*H*W) and the output is (N, C*OH*OW).
+type AvgPooling struct {
+	Channel, Height, Width int // input shape
+	PoolHeight, PoolWidth  int // pool shape
+	Stride, Pad            int
+}
+
+func (l *AvgPooling) Params() []matrix.Matrix      { return make([]matrix.Matrix, 0) }
+func (l *AvgPooling) Grads() []matrix.Matrix       { return make([]matrix.Matrix, 0) }
+func (l *AvgPooling) SetParams(p ...matrix.Matrix) {}
+func (l *AvgPooling) String() string {
+	return fmt.Sprintf("%T: Pool(%v, %v), Stride(%v), Pad(%v)", l, l.PoolHeight, l.PoolWidth, l.Stride, l.Pad)
+}
+
+// OutputSize returns the shape of the output image.
+func (l *AvgPooling) OutputSize() (int, int, int) {
+	oh, ow := outhw(l.Height, l.Width, l.PoolHeight, l.PoolWidth, l.Pad, l.Stride)
+	return l.Channel, oh, ow
+}
+
+func (l *AvgPooling) Forward(x, _ matrix.Matrix, _ ...Opts) matrix.Matrix {
+	N := len(x)
+	size := float64(l.PoolHeight * l.PoolWidth)
+	out := make(matrix.Matrix, N)
+
+	matrix.Parallel(N, func(begin, end int) {
+		for i := begin; i < end; i++ {
+			img := image(x[i], l.Channel, l.Height, l.Width) // (C, H, W)
+			for c := 0; c < l.Channel; c++ {
+				col := im2col(img[c], l.PoolHeight, l.PoolWidth, l.Pad, l.Stride) // (OH*OW, PH*PW)
+				out[i] = append(out[i], vector.Div(col.SumAxis1(), size)...)      // (C*OH*OW)
+			}
+		}
+	})
+
+	return out // (N, C*OH*OW)
+}
+
+func (l *AvgPooling) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+	N, size := len(dout), l.PoolHeight*l.PoolWidth
+	_, oh, ow := l.OutputSize()
+	dx := make(matrix.Matrix, N)
+
+	matrix.Parallel(N, func(begin, end int) {
+		for i := begin; i < end; i++ {
+			dimg := make([]matrix.Matrix, l.Channel)
+			for c := 0; c < l.Channel; c++ {
+				d := vector.T(dout[i][c*oh*ow : (c+1)*oh*ow])                                         // (OH*OW, 1)
+				dcol := matrix.New(d...).Broadcast(oh*ow, size).MulC(1.0 / float64(size))             // (OH*OW, PH*PW)
+				dimg[c] = col2im(dcol, l.Height, l.Width, l.PoolHeight, l.PoolWidth, l.Pad, l.Stride) // (H, W)
+			}
+
+			dx[i] = tensor.Flatten(dimg) // (C*H*W)
+		}
+	})
+
+	return dx, nil // (N, C*H*W)
+}
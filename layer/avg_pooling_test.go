@@ -0,0 +1,59 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+func ExampleAvgPooling() {
+	// N, C, H, W := 1, 2, 4, 4
+	pool := &layer.AvgPooling{
+		Channel:    2,
+		Height:     4,
+		Width:      4,
+		PoolHeight: 2,
+		PoolWidth:  2,
+		Stride:     2,
+	}
+	fmt.Println(pool)
+	fmt.Println(pool.OutputSize())
+
+	// forward
+	x := matrix.New([]float64{
+		1, 2, 0, 1,
+		3, 4, 1, 0,
+		0, 0, 5, 6,
+		0, 8, 8, 7,
+		//
+		-1, -2, -3, -4,
+		-5, -6, -7, -8,
+		-9, -8, -7, -6,
+		-5, -4, -3, -2,
+	})
+	fmt.Println(pool.Forward(x, nil))
+
+	// backward
+	dout := matrix.New([]float64{4, 8, 12, 16, 20, 24, 28, 32})
+	dx, _ := pool.Backward(dout)
+	fmt.Println(matrix.Reshape(dx, 8, 4))
+
+	// Output:
+	// *layer.AvgPooling: Pool(2, 2), Stride(2), Pad(0)
+	// 2 2 2
+	// [[2.5 0.5 2 6.5 -3.5 -5.5 -6.5 -4.5]]
+	// [[1 1 2 2] [1 1 2 2] [3 3 4 4] [3 3 4 4] [5 5 6 6] [5 5 6 6] [7 7 8 8] [7 7 8 8]]
+}
+
+func ExampleAvgPooling_Params() {
+	pool := &layer.AvgPooling{}
+
+	pool.SetParams(make([]matrix.Matrix, 0)...)
+	fmt.Println(pool.Params())
+	fmt.Println(pool.Grads())
+
+	// Output:
+	// []
+	// []
+}
@@ -16,8 +16,16 @@ type BatchNorm struct {
 }
 
 func (l *BatchNorm) Params() []matrix.Matrix      { return []matrix.Matrix{l.Gamma, l.Beta} }
+func (l *BatchNorm) ParamNames() []string         { return []string{"Gamma", "Beta"} }
 func (l *BatchNorm) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DGamma, l.DBeta} }
 func (l *BatchNorm) SetParams(p ...matrix.Matrix) { l.Gamma, l.Beta = p[0], p[1] }
+
+// Stats returns the running mean and variance, which are used in the inference.
+func (l *BatchNorm) Stats() []matrix.Matrix { return []matrix.Matrix{l.mu, l.va} }
+
+// SetStats sets the running mean and variance.
+func (l *BatchNorm) SetStats(s ...matrix.Matrix) { l.mu, l.va = s[0], s[1] }
+
 func (l *BatchNorm) String() string {
 	a, b := l.Gamma.Dim()
 	c, d := l.Beta.Dim()
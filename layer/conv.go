@@ -9,7 +9,6 @@ func outhw(xh, xw, fh, fw, pad, stride int) (int, int) {
 }
 
 // im2col converts image to column.
-// TODO: "チャンネル方向に複数の特徴マップがある場合、チャンネルごとに入力データとフィルターの畳み込み演算を行い、それらの結果を加算してひとつの出力を得ます。" ゼロから作るDeepLearning　p214
 func im2col(im matrix.Matrix, fh, fw, pad, stride int) matrix.Matrix {
 	pick := func(img matrix.Matrix, y, x, ymax, xmax, stride int) []float64 {
 		// NOTE: double loop. no loop (m[y:ymax:stride, x:xmax:stride]) in numpy.
@@ -100,7 +99,7 @@ func col2im(col matrix.Matrix, xh, xw, fh, fw, pad, stride int) matrix.Matrix {
 	// [0 12 16 0]
 	// [0  0  0 0]
 	outh, outw := outhw(xh, xw, fh, fw, pad, stride)
-	img := matrix.Zero(xh+2*pad+stride-1, xw+2*pad+stride-1)
+	img := matrix.Zero(xh+2*pad, xw+2*pad)
 	for y := 0; y < fh; y++ {
 		ymax := y + stride*outh
 		for x := 0; x < fw; x++ {
@@ -130,3 +129,39 @@ func col2im(col matrix.Matrix, xh, xw, fh, fw, pad, stride int) matrix.Matrix {
 	// [12 16]
 	return matrix.Unpadding(img, pad)
 }
+
+// im2colc converts multi-channel image to column.
+// "チャンネル方向に複数の特徴マップがある場合、チャンネルごとに入力データとフィルターの畳み込み演算を行い、それらの結果を加算してひとつの出力を得ます。" ゼロから作るDeepLearning　p214
+// The columns of each channel are stacked horizontally, so that the dot product with the filter sums over the channels.
+func im2colc(img []matrix.Matrix, fh, fw, pad, stride int) matrix.Matrix {
+	cols := make([]matrix.Matrix, len(img))
+	for c := range img {
+		cols[c] = im2col(img[c], fh, fw, pad, stride) // (OH*OW, FH*FW)
+	}
+
+	return matrix.HStack(cols...) // (OH*OW, C*FH*FW)
+}
+
+// col2imc converts column to multi-channel image.
+func col2imc(col matrix.Matrix, xh, xw, fh, fw, pad, stride int) []matrix.Matrix {
+	cols := matrix.Split(col, fh*fw) // (C, OH*OW, FH*FW)
+
+	out := make([]matrix.Matrix, len(cols))
+	for c := range cols {
+		out[c] = col2im(cols[c], xh, xw, fh, fw, pad, stride) // (H, W)
+	}
+
+	return out // (C, H, W)
+}
+
+// image returns the multi-channel image of the row.
+func image(x []float64, c, h, w int) []matrix.Matrix {
+	m := matrix.Reshape(matrix.New(x), c*h, w) // (C*H, W)
+
+	out := make([]matrix.Matrix, c)
+	for i := 0; i < c; i++ {
+		out[i] = m[i*h : (i+1)*h] // (H, W)
+	}
+
+	return out // (C, H, W)
+}
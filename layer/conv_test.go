@@ -45,25 +45,25 @@ func Example_im2col() {
 
 }
 
-func Example_im2col_c2() {
+func Example_im2colc() {
 	// N, C, H, W := 1, 2, 2, 2
 	// pad := 1
 	// [0 0 0 0] [0 0 0 0]
 	// [0 1 2 0] [0 5 6 0]
 	// [0 3 4 0] [0 7 8 0]
 	// [0 0 0 0] [0 0 0 0]
-	//
-	// FC, FH, FW, stride := 2, 2, 2, 1
-	// [0 0 0 0 1 2 0 3 4]
-	// [0 0 0 1 2 0 3 4 0]
-	// [0 1 2 0 3 4 0 0 0]
-	// [1 2 0 3 4 0 0 0 0]
-	// [0 0 0 0 5 6 0 7 8]
-	// [0 0 0 5 6 0 7 8 0]
-	// [0 5 6 0 7 8 0 0 0]
-	// [5 6 0 7 8 0 0 0 0]
-	//
-	// transpose
+	x := []matrix.Matrix{
+		{{1, 2}, {3, 4}},
+		{{5, 6}, {7, 8}},
+	}
+
+	fh, fw := 2, 2
+	pad, stride := 1, 1
+	for _, r := range layer.Im2colc(x, fh, fw, pad, stride) {
+		fmt.Println(r)
+	}
+
+	// Output:
 	// [0 0 0 1 0 0 0 5]
 	// [0 0 1 2 0 0 5 6]
 	// [0 0 2 0 0 0 6 0]
@@ -99,3 +99,54 @@ func Example_col2im() {
 	// [ 4  8]
 	// [12 16]
 }
+
+func Example_col2imc() {
+	x := matrix.New(
+		[]float64{0, 0, 0, 1, 0, 0, 0, 5},
+		[]float64{0, 0, 1, 2, 0, 0, 5, 6},
+		[]float64{0, 0, 2, 0, 0, 0, 6, 0},
+		[]float64{0, 1, 0, 3, 0, 5, 0, 7},
+		[]float64{1, 2, 3, 4, 5, 6, 7, 8},
+		[]float64{2, 0, 4, 0, 6, 0, 8, 0},
+		[]float64{0, 3, 0, 0, 0, 7, 0, 0},
+		[]float64{3, 4, 0, 0, 7, 8, 0, 0},
+		[]float64{4, 0, 0, 0, 8, 0, 0, 0},
+	)
+
+	xh, xw := 2, 2
+	fh, fw := 2, 2
+	pad, stride := 1, 1
+	for _, c := range layer.Col2imc(x, xh, xw, fh, fw, pad, stride) {
+		for _, r := range c {
+			fmt.Printf("%2v\n", r)
+		}
+	}
+
+	// Output:
+	// [ 4  8]
+	// [12 16]
+	// [20 24]
+	// [28 32]
+}
+
+func Example_col2im_stride() {
+	x := matrix.New(
+		[]float64{1, 1, 1, 1},
+		[]float64{2, 2, 2, 2},
+		[]float64{3, 3, 3, 3},
+		[]float64{4, 4, 4, 4},
+	)
+
+	xh, xw := 4, 4
+	fh, fw := 2, 2
+	pad, stride := 0, 2
+	for _, r := range layer.Col2im(x, xh, xw, fh, fw, pad, stride) {
+		fmt.Println(r)
+	}
+
+	// Output:
+	// [1 1 2 2]
+	// [1 1 2 2]
+	// [3 3 4 4]
+	// [3 3 4 4]
+}
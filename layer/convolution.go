@@ -0,0 +1,80 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/tensor"
+)
+
+// Convolution is a layer that performs a 2D convolution.
+// The input is (N, C*H*W) and the output is (N, FN*OH*OW).
+type Convolution struct {
+	W, B                      matrix.Matrix // params. W(C*FH*FW, FN), B(1, FN)
+	DW, DB                    matrix.Matrix // grads
+	Channel, Height, Width    int           // input shape
+	FilterHeight, FilterWidth int           // filter shape
+	Stride, Pad               int
+	col                       []matrix.Matrix
+}
+
+func (l *Convolution) Params() []matrix.Matrix      { return []matrix.Matrix{l.W, l.B} }
+func (l *Convolution) ParamNames() []string         { return []string{"W", "B"} }
+func (l *Convolution) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DW, l.DB} }
+func (l *Convolution) SetParams(p ...matrix.Matrix) { l.W, l.B = p[0], p[1] }
+func (l *Convolution) String() string {
+	a, b := l.W.Dim()
+	c, d := l.B.Dim()
+	return fmt.Sprintf("%T: W(%v, %v), B(%v, %v): %v", l, a, b, c, d, a*b+c*d)
+}
+
+// OutputSize returns the shape of the output image.
+func (l *Convolution) OutputSize() (int, int, int) {
+	_, fn := l.W.Dim()
+	oh, ow := outhw(l.Height, l.Width, l.FilterHeight, l.FilterWidth, l.Pad, l.Stride)
+	return fn, oh, ow
+}
+
+func (l *Convolution) Forward(x, _ matrix.Matrix, _ ...Opts) matrix.Matrix {
+	N := len(x)
+	l.col = make([]matrix.Matrix, N)
+	out := make(matrix.Matrix, N)
+
+	matrix.Parallel(N, func(begin, end int) {
+		for i := begin; i < end; i++ {
+			img := image(x[i], l.Channel, l.Height, l.Width)                        // (C, H, W)
+			l.col[i] = im2colc(img, l.FilterHeight, l.FilterWidth, l.Pad, l.Stride) // (OH*OW, C*FH*FW)
+			out[i] = matrix.Flatten(matrix.Dot(l.col[i], l.W).Add(l.B).T())         // (OH*OW, FN) -> (FN, OH*OW) -> (FN*OH*OW)
+		}
+	})
+
+	return out // (N, FN*OH*OW)
+}
+
+func (l *Convolution) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+	N, FN := len(dout), len(l.B[0])
+	dx := make(matrix.Matrix, N)
+	dW, dB := make([]matrix.Matrix, N), make([]matrix.Matrix, N)
+
+	matrix.Parallel(N, func(begin, end int) {
+		for i := begin; i < end; i++ {
+			d := matrix.Reshape(matrix.New(dout[i]), FN, -1).T() // (FN*OH*OW) -> (FN, OH*OW) -> (OH*OW, FN)
+			dW[i] = matrix.Dot(l.col[i].T(), d)                  // (C*FH*FW, FN)
+			dB[i] = matrix.New(d.SumAxis0())                     // (1, FN)
+
+			dcol := matrix.Dot(d, l.W.T())
+			dimg := col2imc(dcol, l.Height, l.Width, l.FilterHeight, l.FilterWidth, l.Pad, l.Stride)
+			dx[i] = tensor.Flatten(dimg)
+		}
+	})
+
+	// grads are added in the order of samples
+	l.DW = matrix.Zero(1, 1)
+	l.DB = matrix.Zero(1, 1)
+	for i := 0; i < N; i++ {
+		l.DW = dW[i].Add(l.DW) // Broadcast
+		l.DB = dB[i].Add(l.DB) // Broadcast
+	}
+
+	return dx, nil // (N, C*H*W)
+}
@@ -0,0 +1,148 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+)
+
+func ExampleConvolution() {
+	// N, C, H, W := 1, 1, 3, 3
+	// FN, FH, FW := 1, 2, 2
+	conv := &layer.Convolution{
+		W:            matrix.New([]float64{1}, []float64{0}, []float64{0}, []float64{1}),
+		B:            matrix.New([]float64{1}),
+		Channel:      1,
+		Height:       3,
+		Width:        3,
+		FilterHeight: 2,
+		FilterWidth:  2,
+		Stride:       1,
+	}
+	fmt.Println(conv)
+	fmt.Println(conv.OutputSize())
+
+	// forward
+	x := matrix.New([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	fmt.Println(conv.Forward(x, nil))
+
+	// backward
+	dout := matrix.New([]float64{1, 1, 1, 1})
+	fmt.Println(conv.Backward(dout))
+
+	// grads
+	for _, g := range conv.Grads() {
+		fmt.Println(g)
+	}
+
+	// Output:
+	// *layer.Convolution: W(4, 1), B(1, 1): 5
+	// 1 2 2
+	// [[7 9 13 15]]
+	// [[1 1 0 1 2 1 0 1 1]] []
+	// [[12] [16] [24] [28]]
+	// [[4]]
+}
+
+func ExampleConvolution_channel() {
+	// N, C, H, W := 2, 2, 2, 2
+	// FN, FH, FW := 2, 2, 2
+	conv := &layer.Convolution{
+		W: matrix.New(
+			// (C*FH*FW, FN)
+			[]float64{1, 0},
+			[]float64{0, 0},
+			[]float64{0, 0},
+			[]float64{0, 0},
+			[]float64{0, 0},
+			[]float64{0, 0},
+			[]float64{0, 0},
+			[]float64{1, 1},
+		),
+		B:            matrix.New([]float64{0, 0.5}),
+		Channel:      2,
+		Height:       2,
+		Width:        2,
+		FilterHeight: 2,
+		FilterWidth:  2,
+		Stride:       1,
+		Pad:          0,
+	}
+	fmt.Println(conv)
+	fmt.Println(conv.OutputSize())
+
+	// forward
+	x := matrix.New(
+		[]float64{1, 2, 3, 4, 5, 6, 7, 8},
+		[]float64{8, 7, 6, 5, 4, 3, 2, 1},
+	)
+	fmt.Println(conv.Forward(x, nil))
+
+	// backward
+	dout := matrix.New([]float64{1, 1}, []float64{1, 0})
+	fmt.Println(conv.Backward(dout))
+	fmt.Println(conv.DB)
+
+	// Output:
+	// *layer.Convolution: W(8, 2), B(1, 2): 18
+	// 2 1 1
+	// [[9 8.5] [9 1.5]]
+	// [[1 0 0 0 0 0 0 2] [1 0 0 0 0 0 0 1]] []
+	// [[2 1]]
+}
+
+func ExampleConvolution_Params() {
+	conv := &layer.Convolution{}
+
+	conv.SetParams(make([]matrix.Matrix, 2)...)
+	fmt.Println(conv.Params())
+	fmt.Println(conv.Grads())
+
+	// Output:
+	// [[] []]
+	// [[] []]
+}
+
+func ExampleConvolution_parallel() {
+	defer matrix.SetWorkers(matrix.Workers())
+
+	s := rand.Const(1)
+	x := matrix.Randn(8, 2*5*5, s)
+	dout := matrix.Randn(8, 3*5*5, s)
+	conv := func() *layer.Convolution {
+		return &layer.Convolution{
+			W:            matrix.Randn(2*3*3, 3, rand.Const(2)),
+			B:            matrix.Randn(1, 3, rand.Const(3)),
+			Channel:      2,
+			Height:       5,
+			Width:        5,
+			FilterHeight: 3,
+			FilterWidth:  3,
+			Stride:       1,
+			Pad:          1,
+		}
+	}
+
+	matrix.SetWorkers(1)
+	c1 := conv()
+	y1 := c1.Forward(x, nil)
+	dx1, _ := c1.Backward(dout)
+
+	matrix.SetWorkers(4)
+	c4 := conv()
+	y4 := c4.Forward(x, nil)
+	dx4, _ := c4.Backward(dout)
+
+	fmt.Println(y1.Sub(y4).Abs().Sum())
+	fmt.Println(dx1.Sub(dx4).Abs().Sum())
+	fmt.Println(c1.DW.Sub(c4.DW).Abs().Sum())
+	fmt.Println(c1.DB.Sub(c4.DB).Abs().Sum())
+
+	// Output:
+	// 0
+	// 0
+	// 0
+	// 0
+}
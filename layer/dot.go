@@ -14,6 +14,7 @@ type Dot struct {
 }
 
 func (l *Dot) Params() []matrix.Matrix      { return []matrix.Matrix{l.W} }
+func (l *Dot) ParamNames() []string         { return []string{"W"} }
 func (l *Dot) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DW} }
 func (l *Dot) SetParams(p ...matrix.Matrix) { l.W = p[0] }
 func (l *Dot) String() string {
@@ -0,0 +1,44 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/tensor"
+)
+
+// DotScore is the dot score of the attention, hs . h.
+type DotScore struct {
+	hs, hr []matrix.Matrix
+}
+
+func (l *DotScore) Params() []matrix.Matrix      { return make([]matrix.Matrix, 0) }
+func (l *DotScore) ParamNames() []string         { return make([]string, 0) }
+func (l *DotScore) Grads() []matrix.Matrix       { return make([]matrix.Matrix, 0) }
+func (l *DotScore) SetParams(p ...matrix.Matrix) {}
+func (l *DotScore) Clone() Scorer                { return &DotScore{} }
+func (l *DotScore) String() string               { return fmt.Sprintf("%T", l) }
+
+func (l *DotScore) Forward(hs []matrix.Matrix, h matrix.Matrix) matrix.Matrix {
+	T := len(hs)                         // (T, N, H)
+	l.hs, l.hr = hs, tensor.Repeat(h, T) // (N, H) -> (T, N, H)
+	t := tensor.Mul(hs, l.hr)            // (T, N, H)
+
+	s := make(matrix.Matrix, T) // (T, N)
+	for i := 0; i < T; i++ {
+		s[i] = t[i].SumAxis1() // (N, H) -> (1, N)
+	}
+
+	return s
+}
+
+func (l *DotScore) Backward(ds matrix.Matrix) ([]matrix.Matrix, matrix.Matrix) {
+	T, N, H := len(l.hs), len(l.hs[0]), len(l.hs[0][0])
+
+	dt := Expand(ds, T, N, H)   // (T, N, H)
+	dhs := tensor.Mul(dt, l.hr) // (T, N, H)
+	dhr := tensor.Mul(dt, l.hs) // (T, N, H)
+	dh := tensor.SumAxis0(dhr)  // (N, H)
+
+	return dhs, dh // (T, N, H), (N, H)
+}
@@ -0,0 +1,47 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+func ExampleDotScore() {
+	l := &layer.DotScore{}
+	fmt.Println(l)
+
+	// (T, N, H) = (2, 2, 3)
+	hs := []matrix.Matrix{
+		{{1, 2, 3}, {4, 5, 6}},
+		{{4, 5, 6}, {4, 5, 6}},
+	}
+	h := matrix.New([]float64{1, 2, 3}, []float64{2, 2, 2})
+	fmt.Println(l.Forward(hs, h))
+
+	dhs, dh := l.Backward(matrix.New([]float64{1, 0}, []float64{0, 1}))
+	fmt.Println(dhs)
+	fmt.Println(dh)
+
+	// Output:
+	// *layer.DotScore
+	// [[14 30] [32 30]]
+	// [[[1 2 3] [0 0 0]] [[0 0 0] [2 2 2]]]
+	// [[1 2 3] [4 5 6]]
+}
+
+func ExampleDotScore_Params() {
+	l := &layer.DotScore{}
+	l.SetParams(matrix.New())
+
+	fmt.Println(l.Params())
+	fmt.Println(l.ParamNames())
+	fmt.Println(l.Grads())
+	fmt.Println(l.Clone())
+
+	// Output:
+	// []
+	// []
+	// []
+	// *layer.DotScore
+}
@@ -7,27 +7,33 @@ import (
 	"github.com/itsubaki/neu/math/vector"
 )
 
-type Embedding struct {
-	W   matrix.Matrix // params
-	DW  matrix.Matrix // grads
+type Embedding = EmbeddingOf[float64]
+
+// EmbeddingOf is Embedding for the parameters of E.
+type EmbeddingOf[E vector.Float] struct {
+	W   matrix.Dense[E] // params
+	DW  matrix.Dense[E] // grads
+	dW  *matrix.SparseOf[E]
 	idx []int
 }
 
-func (l *Embedding) Params() []matrix.Matrix      { return []matrix.Matrix{l.W} }
-func (l *Embedding) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DW} }
-func (l *Embedding) SetParams(p ...matrix.Matrix) { l.W = p[0] }
-func (l *Embedding) String() string {
+func (l *EmbeddingOf[E]) Params() []matrix.Dense[E]          { return []matrix.Dense[E]{l.W} }
+func (l *EmbeddingOf[E]) ParamNames() []string               { return []string{"W"} }
+func (l *EmbeddingOf[E]) Grads() []matrix.Dense[E]           { return []matrix.Dense[E]{l.DW} }
+func (l *EmbeddingOf[E]) SparseGrads() []*matrix.SparseOf[E] { return []*matrix.SparseOf[E]{l.dW} }
+func (l *EmbeddingOf[E]) SetParams(p ...matrix.Dense[E])     { l.W = p[0] }
+func (l *EmbeddingOf[E]) String() string {
 	a, b := l.W.Dim()
-	return fmt.Sprintf("%T: W(%v, %v): %v", l, a, b, a*b)
+	return fmt.Sprintf("%v: W(%v, %v): %v", typeName(l), a, b, a*b)
 }
 
-func (l *Embedding) Forward(idx, _ matrix.Matrix, _ ...Opts) matrix.Matrix {
+func (l *EmbeddingOf[E]) Forward(idx, _ matrix.Dense[E], _ ...Opts) matrix.Dense[E] {
 	l.idx = make([]int, len(idx)) // idx(N, 1)
 	for i := range idx {
 		l.idx[i] = int(idx[i][0])
 	}
 
-	out := matrix.New()
+	out := matrix.NewOf[E]()
 	for _, i := range l.idx {
 		out = append(out, l.W[i]) // W(13, 16)
 	}
@@ -35,11 +41,10 @@ func (l *Embedding) Forward(idx, _ matrix.Matrix, _ ...Opts) matrix.Matrix {
 	return out // (128, 16)
 }
 
-func (l *Embedding) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
-	l.DW = matrix.ZeroLike(l.W) // DW(V, D) (13, 16)
-	for i, v := range l.idx {   // idx(N, 1) (128, 1)
-		l.DW[v] = vector.Add(l.DW[v], dout[i]) // dout(N, D) (128, 16)
-	}
+func (l *EmbeddingOf[E]) Backward(dout matrix.Dense[E]) (matrix.Dense[E], matrix.Dense[E]) {
+	a, b := l.W.Dim()                          // DW(V, D) (13, 16)
+	l.dW = matrix.NewSparse(a, b, l.idx, dout) // idx(N, 1) (128, 1), dout(N, D) (128, 16)
+	l.DW = l.dW.View()                         // only the rows in idx are non-zero
 
 	return nil, nil
 }
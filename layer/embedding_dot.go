@@ -11,9 +11,11 @@ type EmbeddingDot struct {
 	H, W      matrix.Matrix
 }
 
-func (l *EmbeddingDot) Params() []matrix.Matrix      { return []matrix.Matrix{l.Embedding.W} }
-func (l *EmbeddingDot) Grads() []matrix.Matrix       { return []matrix.Matrix{l.Embedding.DW} }
-func (l *EmbeddingDot) SetParams(p ...matrix.Matrix) { l.Embedding.W = p[0] }
+func (l *EmbeddingDot) Params() []matrix.Matrix       { return []matrix.Matrix{l.Embedding.W} }
+func (l *EmbeddingDot) ParamNames() []string          { return []string{"W"} }
+func (l *EmbeddingDot) Grads() []matrix.Matrix        { return []matrix.Matrix{l.Embedding.DW} }
+func (l *EmbeddingDot) SparseGrads() []*matrix.Sparse { return l.Embedding.SparseGrads() }
+func (l *EmbeddingDot) SetParams(p ...matrix.Matrix)  { l.Embedding.W = p[0] }
 func (l *EmbeddingDot) String() string {
 	a, b := l.Embedding.W.Dim()
 	return fmt.Sprintf("%T: W(%v, %v): %v", l, a, b, a*b)
@@ -29,7 +31,7 @@ func (l *EmbeddingDot) Forward(h, idx matrix.Matrix, _ ...Opts) matrix.Matrix {
 
 func (l *EmbeddingDot) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
 	h, targetW := l.H, l.W
-	dout = matrix.Reshape(dout, len(dout), 1)
+	dout = matrix.Reshape(dout, -1, 1) // (1, N) -> (N, 1)
 
 	dtargetW := h.Mul(dout)        // Broadcast
 	dh := targetW.Mul(dout)        // Broadcast
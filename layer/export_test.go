@@ -1,7 +1,15 @@
 package layer
 
+import randv2 "math/rand/v2"
+
 var (
-	Outhw  = outhw
-	Im2col = im2col
-	Col2im = col2im
+	Outhw   = outhw
+	Im2col  = im2col
+	Col2im  = col2im
+	Im2colc = im2colc
+	Col2imc = col2imc
 )
+
+func (l *NegativeSamplingLoss) SetSource(s randv2.Source) {
+	l.s = s
+}
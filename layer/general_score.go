@@ -0,0 +1,38 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// GeneralScore is the general score of the attention, hs . (h W), by Luong et al.
+type GeneralScore struct {
+	W   matrix.Matrix // params
+	DW  matrix.Matrix // grads
+	dot DotScore
+	h   matrix.Matrix
+}
+
+func (l *GeneralScore) Params() []matrix.Matrix      { return []matrix.Matrix{l.W} }
+func (l *GeneralScore) ParamNames() []string         { return []string{"W"} }
+func (l *GeneralScore) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DW} }
+func (l *GeneralScore) SetParams(p ...matrix.Matrix) { l.W = p[0] }
+func (l *GeneralScore) Clone() Scorer                { return &GeneralScore{W: l.W} }
+func (l *GeneralScore) String() string {
+	a, b := l.W.Dim()
+	return fmt.Sprintf("%T: W(%v, %v): %v", l, a, b, a*b)
+}
+
+func (l *GeneralScore) Forward(hs []matrix.Matrix, h matrix.Matrix) matrix.Matrix {
+	l.h = h
+	u := matrix.Dot(h, l.W)     // (N, H)
+	return l.dot.Forward(hs, u) // (T, N)
+}
+
+func (l *GeneralScore) Backward(ds matrix.Matrix) ([]matrix.Matrix, matrix.Matrix) {
+	dhs, du := l.dot.Backward(ds)  // (T, N, H), (N, H)
+	l.DW = matrix.Dot(l.h.T(), du) // (H, H)
+	dh := matrix.Dot(du, l.W.T())  // (N, H)
+	return dhs, dh
+}
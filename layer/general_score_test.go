@@ -0,0 +1,49 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+func ExampleGeneralScore() {
+	l := &layer.GeneralScore{W: matrix.Identity(3)}
+	fmt.Println(l)
+
+	// (T, N, H) = (2, 2, 3)
+	hs := []matrix.Matrix{
+		{{1, 2, 3}, {4, 5, 6}},
+		{{4, 5, 6}, {4, 5, 6}},
+	}
+	h := matrix.New([]float64{1, 2, 3}, []float64{2, 2, 2})
+
+	// the same as DotScore if W is the identity
+	fmt.Println(l.Forward(hs, h))
+
+	dhs, dh := l.Backward(matrix.New([]float64{1, 0}, []float64{0, 1}))
+	fmt.Println(dhs)
+	fmt.Println(dh)
+	fmt.Println(l.Grads())
+
+	// Output:
+	// *layer.GeneralScore: W(3, 3): 9
+	// [[14 30] [32 30]]
+	// [[[1 2 3] [0 0 0]] [[0 0 0] [2 2 2]]]
+	// [[1 2 3] [4 5 6]]
+	// [[[9 12 15] [10 14 18] [11 16 21]]]
+}
+
+func ExampleGeneralScore_Params() {
+	l := &layer.GeneralScore{}
+	l.SetParams(make([]matrix.Matrix, 1)...)
+
+	fmt.Println(l.Params())
+	fmt.Println(l.ParamNames())
+	fmt.Println(l.Grads())
+
+	// Output:
+	// [[]]
+	// [W]
+	// [[]]
+}
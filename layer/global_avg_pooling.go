@@ -0,0 +1,51 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// GlobalAvgPooling is a layer that performs a global average pooling.
+// The input is (N, C*H*W) and the output is (N, C).
+type GlobalAvgPooling struct {
+	Channel int
+	size    int // H*W
+}
+
+func (l *GlobalAvgPooling) Params() []matrix.Matrix      { return make([]matrix.Matrix, 0) }
+func (l *GlobalAvgPooling) Grads() []matrix.Matrix       { return make([]matrix.Matrix, 0) }
+func (l *GlobalAvgPooling) SetParams(p ...matrix.Matrix) {}
+func (l *GlobalAvgPooling) String() string               { return fmt.Sprintf("%T: Channel(%v)", l, l.Channel) }
+
+func (l *GlobalAvgPooling) Forward(x, _ matrix.Matrix, _ ...Opts) matrix.Matrix {
+	N, CHW := x.Dim()
+	l.size = CHW / l.Channel
+
+	out := matrix.Zero(N, l.Channel)
+	for i := 0; i < N; i++ {
+		for c := 0; c < l.Channel; c++ {
+			var sum float64
+			for _, v := range x[i][c*l.size : (c+1)*l.size] {
+				sum += v
+			}
+
+			out[i][c] = sum / float64(l.size)
+		}
+	}
+
+	return out // (N, C)
+}
+
+func (l *GlobalAvgPooling) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+	N := len(dout)
+
+	dx := matrix.Zero(N, l.Channel*l.size)
+	for i := 0; i < N; i++ {
+		for j := range dx[i] {
+			dx[i][j] = dout[i][j/l.size] / float64(l.size)
+		}
+	}
+
+	return dx, nil // (N, C*H*W)
+}
@@ -0,0 +1,42 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+func ExampleGlobalAvgPooling() {
+	// N, C, H, W := 2, 2, 2, 2
+	pool := &layer.GlobalAvgPooling{Channel: 2}
+	fmt.Println(pool)
+
+	// forward
+	x := matrix.New(
+		[]float64{1, 2, 3, 4, 5, 6, 7, 8},
+		[]float64{8, 7, 6, 5, 4, 3, 2, 1},
+	)
+	fmt.Println(pool.Forward(x, nil))
+
+	// backward
+	dout := matrix.New([]float64{4, 8}, []float64{-4, -8})
+	fmt.Println(pool.Backward(dout))
+
+	// Output:
+	// *layer.GlobalAvgPooling: Channel(2)
+	// [[2.5 6.5] [6.5 2.5]]
+	// [[1 1 1 1 2 2 2 2] [-1 -1 -1 -1 -2 -2 -2 -2]] []
+}
+
+func ExampleGlobalAvgPooling_Params() {
+	pool := &layer.GlobalAvgPooling{}
+
+	pool.SetParams(make([]matrix.Matrix, 0)...)
+	fmt.Println(pool.Params())
+	fmt.Println(pool.Grads())
+
+	// Output:
+	// []
+	// []
+}
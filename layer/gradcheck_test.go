@@ -0,0 +1,314 @@
+package layer_test
+
+import (
+	"testing"
+
+	"github.com/itsubaki/neu/gradcheck"
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+)
+
+// tol is the tolerance of the maximum relative error.
+const tol = 1e-4
+
+// negativeSamplingLoss resets the random source before each Forward, so that the same negatives are sampled.
+type negativeSamplingLoss struct {
+	*layer.NegativeSamplingLoss
+}
+
+func (l negativeSamplingLoss) Forward(h, target matrix.Matrix, opts ...layer.Opts) matrix.Matrix {
+	l.SetSource(rand.Const(1))
+	return l.NegativeSamplingLoss.Forward(h, target, opts...)
+}
+
+// lstm has the fixed cell state c, and its output is the sum of the next hidden and cell states.
+type lstm struct {
+	*layer.LSTM
+	c matrix.Matrix
+}
+
+func (l lstm) Forward(x, h matrix.Matrix, opts ...layer.Opts) matrix.Matrix {
+	hNext, cNext := l.LSTM.Forward(x, h, l.c, opts...)
+	return hNext.Add(cNext)
+}
+
+func (l lstm) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+	dx, dh, _ := l.LSTM.Backward(dout, dout)
+	return dx, dh
+}
+
+// attention attends to the fixed hs, and its input is h, e.g. the decoder hidden state or the attention weight.
+type attention struct {
+	model.AttentionLayer
+	hs []matrix.Matrix
+}
+
+func (l attention) Forward(h, _ matrix.Matrix, _ ...layer.Opts) matrix.Matrix {
+	return l.AttentionLayer.Forward(l.hs, h)
+}
+
+func (l attention) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+	_, dh := l.AttentionLayer.Backward(dout)
+	return dh, nil
+}
+
+// attentionHS attends with the fixed h, and its input is hs.
+type attentionHS struct {
+	model.AttentionLayer
+	h matrix.Matrix
+}
+
+func (l attentionHS) SetState(_ ...matrix.Matrix) {}
+func (l attentionHS) ResetState()                 {}
+
+func (l attentionHS) Forward(hs, _ []matrix.Matrix, _ ...layer.Opts) []matrix.Matrix {
+	return []matrix.Matrix{l.AttentionLayer.Forward(hs, l.h)}
+}
+
+func (l attentionHS) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	dhs, _ := l.AttentionLayer.Backward(dout[0])
+	return dhs
+}
+
+// crossAttention attends to the fixed keys and values ys.
+type crossAttention struct {
+	*layer.MultiHeadAttention
+	ys []matrix.Matrix
+}
+
+func (l crossAttention) Forward(xs, _ []matrix.Matrix, opts ...layer.Opts) []matrix.Matrix {
+	return l.MultiHeadAttention.Forward(xs, l.ys, opts...)
+}
+
+// memoryAttention attends with the fixed queries qs, and its input is the keys and values.
+type memoryAttention struct {
+	*layer.MultiHeadAttention
+	qs []matrix.Matrix
+}
+
+func (l memoryAttention) Forward(ys, _ []matrix.Matrix, opts ...layer.Opts) []matrix.Matrix {
+	return l.MultiHeadAttention.Forward(l.qs, ys, opts...)
+}
+
+func (l memoryAttention) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	_, dys := l.BackwardKV(dout)
+	return dys
+}
+
+// timeAttention attends to the fixed encoder outputs hs, and its input is the decoder hidden states.
+type timeAttention struct {
+	*layer.TimeAttention
+	hs []matrix.Matrix
+}
+
+func (l timeAttention) Forward(xs, _ []matrix.Matrix, _ ...layer.Opts) []matrix.Matrix {
+	return l.TimeAttention.Forward(l.hs, xs)
+}
+
+func (l timeAttention) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	_, dxs := l.TimeAttention.Backward(dout)
+	return dxs
+}
+
+// encoderAttention attends with the fixed decoder hidden states hs, and its input is the encoder outputs.
+type encoderAttention struct {
+	*layer.TimeAttention
+	hs []matrix.Matrix
+}
+
+func (l encoderAttention) Forward(xs, _ []matrix.Matrix, _ ...layer.Opts) []matrix.Matrix {
+	return l.TimeAttention.Forward(xs, l.hs)
+}
+
+func (l encoderAttention) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	dxs, _ := l.TimeAttention.Backward(dout)
+	return dxs
+}
+
+// decoderMemory decodes the fixed inputs xs, and its input is the encoder outputs.
+type decoderMemory struct {
+	*layer.TransformerDecoderBlock
+	xs []matrix.Matrix
+}
+
+func (l decoderMemory) Forward(hs, _ []matrix.Matrix, opts ...layer.Opts) []matrix.Matrix {
+	return l.TransformerDecoderBlock.Forward(l.xs, hs, opts...)
+}
+
+func (l decoderMemory) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	l.TransformerDecoderBlock.Backward(dout)
+	return l.DHS()
+}
+
+// tiedEmbedding is TimeEmbedding followed by TimeTiedAffine, and its params are the tied weight and the bias.
+type tiedEmbedding struct {
+	*layer.TimeTiedAffine
+}
+
+func (l tiedEmbedding) Params() []matrix.Matrix {
+	return []matrix.Matrix{l.Embedding.W, l.B}
+}
+
+func (l tiedEmbedding) Grads() []matrix.Matrix {
+	return []matrix.Matrix{l.Embedding.DW, l.DB}
+}
+
+func (l tiedEmbedding) SetParams(p ...matrix.Matrix) {
+	l.Embedding.W, l.B = p[0], p[1]
+}
+
+func (l tiedEmbedding) Forward(xs, _ []matrix.Matrix, opts ...layer.Opts) []matrix.Matrix {
+	return l.TimeTiedAffine.Forward(l.Embedding.Forward(xs, nil, opts...), nil, opts...)
+}
+
+func (l tiedEmbedding) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	return l.Embedding.Backward(l.TimeTiedAffine.Backward(dout))
+}
+
+func TestGradcheck_Layer(t *testing.T) {
+	s := rand.Const(1)
+	randn := func(m, n int) matrix.Matrix { return matrix.Randn(m, n, s) }
+	time := func(T, m, n int) []matrix.Matrix {
+		out := make([]matrix.Matrix, T)
+		for i := range out {
+			out[i] = randn(m, n)
+		}
+
+		return out
+	}
+
+	cases := []struct {
+		name string
+		l    model.Layer
+		x, t matrix.Matrix
+		opts gradcheck.Opts
+	}{
+		{"Add", &layer.Add{}, randn(3, 4), randn(3, 4), gradcheck.Opts{}},
+		{"Attention", attention{&layer.Attention{AttentionWeight: &layer.AttentionWeight{Softmax: &layer.Softmax{}, Score: &layer.GeneralScore{W: randn(3, 3)}}, WeightSum: &layer.WeightSum{}}, time(4, 2, 3)}, randn(2, 3), nil, gradcheck.Opts{}},
+		{"AttentionWeight", attention{&layer.AttentionWeight{Softmax: &layer.Softmax{}}, time(4, 2, 3)}, randn(2, 3), nil, gradcheck.Opts{}},
+		{"Affine", &layer.Affine{W: randn(4, 5), B: randn(1, 5)}, randn(3, 4), nil, gradcheck.Opts{}},
+		{"AvgPooling", &layer.AvgPooling{Channel: 2, Height: 4, Width: 4, PoolHeight: 2, PoolWidth: 2, Stride: 2}, randn(2, 2*4*4), nil, gradcheck.Opts{}},
+		{"BatchNorm", &layer.BatchNorm{Gamma: randn(1, 4), Beta: randn(1, 4), Momentum: 0.9}, randn(3, 4), nil, gradcheck.Opts{Train: true}},
+		{"Convolution", &layer.Convolution{W: randn(2*3*3, 3), B: randn(1, 3), Channel: 2, Height: 4, Width: 4, FilterHeight: 3, FilterWidth: 3, Stride: 1, Pad: 1}, randn(2, 2*4*4), nil, gradcheck.Opts{}},
+		{"Dot", &layer.Dot{W: randn(4, 5)}, randn(3, 4), nil, gradcheck.Opts{}},
+		{"Dropout", &layer.Dropout{Ratio: 0.5}, randn(3, 4), nil, gradcheck.Opts{Train: true, Seed: 1}},
+		{"EmbeddingDot", &layer.EmbeddingDot{Embedding: layer.Embedding{W: randn(6, 4)}}, randn(3, 4), matrix.New([]float64{0}, []float64{5}, []float64{2}), gradcheck.Opts{}},
+		{"Embedding", &layer.Embedding{W: randn(6, 4)}, matrix.New([]float64{0}, []float64{5}, []float64{0}), nil, gradcheck.Opts{}},
+		{"GlobalAvgPooling", &layer.GlobalAvgPooling{Channel: 2}, randn(3, 2*3*3), nil, gradcheck.Opts{}},
+		{"GRU", &layer.GRU{Wx: randn(4, 3*5), Wh: randn(5, 3*5), B: randn(1, 3*5)}, randn(3, 4), randn(3, 5), gradcheck.Opts{}},
+		{"LSTM", lstm{&layer.LSTM{Wx: randn(4, 4*5), Wh: randn(5, 4*5), B: randn(1, 4*5)}, randn(3, 5)}, randn(3, 4), randn(3, 5), gradcheck.Opts{}},
+		{"LayerNorm", &layer.LayerNorm{Gamma: randn(1, 4), Beta: randn(1, 4)}, randn(3, 4), nil, gradcheck.Opts{}},
+		{"MaxPooling", &layer.MaxPooling{Channel: 2, Height: 4, Width: 4, PoolHeight: 2, PoolWidth: 2, Stride: 2}, randn(2, 2*4*4), nil, gradcheck.Opts{}},
+		{"MeanSquaredError", &layer.MeanSquaredError{}, randn(3, 4), randn(3, 4), gradcheck.Opts{}},
+		{"Mul", &layer.Mul{}, randn(3, 4), randn(3, 4), gradcheck.Opts{}},
+		{"NegativeSamplingLoss", negativeSamplingLoss{layer.NewNegativeSamplingLoss(randn(7, 4), []int{0, 1, 2, 3, 4, 5, 6}, 0.75, 2, s)}, randn(3, 4), matrix.New([]float64{0}, []float64{1}, []float64{2}), gradcheck.Opts{}},
+		{"ReLU", &layer.ReLU{}, randn(3, 4), nil, gradcheck.Opts{}},
+		{"RNN", &layer.RNN{Wx: randn(4, 5), Wh: randn(5, 5), B: randn(1, 5)}, randn(3, 4), randn(3, 5), gradcheck.Opts{}},
+		{"Sigmoid", &layer.Sigmoid{}, randn(3, 4), nil, gradcheck.Opts{}},
+		{"SigmoidWithLoss", &layer.SigmoidWithLoss{}, randn(1, 4), matrix.New([]float64{1, 0, 1, 0}), gradcheck.Opts{}},
+		{"Softmax", &layer.Softmax{}, randn(3, 4), nil, gradcheck.Opts{}},
+		{"SoftmaxWithLoss", &layer.SoftmaxWithLoss{}, randn(3, 4), matrix.OneHot([]int{0, 3, 1}, 4), gradcheck.Opts{}},
+		{"WeightSum", attention{&layer.WeightSum{}, time(4, 2, 3)}, randn(4, 2), nil, gradcheck.Opts{}},
+	}
+
+	for _, c := range cases {
+		r := gradcheck.Layer(c.l, c.x, c.t, c.opts)
+		if r.Max() > tol {
+			t.Errorf("%v: %+v", c.name, r)
+		}
+	}
+}
+
+func TestGradcheck_TimeLayer(t *testing.T) {
+	s := rand.Const(1)
+	randn := func(m, n int) matrix.Matrix { return matrix.Randn(m, n, s) }
+	time := func(T, m, n int) []matrix.Matrix {
+		out := make([]matrix.Matrix, T)
+		for i := range out {
+			out[i] = randn(m, n)
+		}
+
+		return out
+	}
+
+	cases := []struct {
+		name   string
+		l      model.TimeLayer
+		xs, ts []matrix.Matrix
+		opts   gradcheck.Opts
+	}{
+		{"Attention_hs", attentionHS{&layer.Attention{AttentionWeight: &layer.AttentionWeight{Softmax: &layer.Softmax{}, Score: &layer.GeneralScore{W: randn(3, 3)}}, WeightSum: &layer.WeightSum{}}, randn(2, 3)}, time(4, 2, 3), nil, gradcheck.Opts{}},
+		{"AttentionWeight_hs", attentionHS{&layer.AttentionWeight{Softmax: &layer.Softmax{}}, randn(2, 3)}, time(4, 2, 3), nil, gradcheck.Opts{}},
+		{"WeightSum_hs", attentionHS{&layer.WeightSum{}, randn(4, 2)}, time(4, 2, 3), nil, gradcheck.Opts{}},
+		{"TimeAffine", &layer.TimeAffine{W: randn(4, 5), B: randn(1, 5)}, time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"TimeBiLSTM", &layer.TimeBiLSTM{
+			F: &layer.TimeLSTM{Wx: randn(4, 4*5), Wh: randn(5, 4*5), B: randn(1, 4*5)},
+			B: &layer.TimeLSTM{Wx: randn(4, 4*5), Wh: randn(5, 4*5), B: randn(1, 4*5)},
+		}, time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"TimeAttention", timeAttention{&layer.TimeAttention{}, time(4, 2, 3)}, time(3, 2, 3), nil, gradcheck.Opts{}},
+		{"TimeAttention_general", timeAttention{&layer.TimeAttention{Score: &layer.GeneralScore{W: randn(3, 3)}}, time(4, 2, 3)}, time(3, 2, 3), nil, gradcheck.Opts{}},
+		{"TimeAttention_additive", timeAttention{&layer.TimeAttention{Score: &layer.AdditiveScore{W1: randn(3, 5), W2: randn(3, 5), V: randn(5, 1)}}, time(4, 2, 3)}, time(3, 2, 3), nil, gradcheck.Opts{}},
+		{"TimeAttention_encoder", encoderAttention{&layer.TimeAttention{Score: &layer.AdditiveScore{W1: randn(3, 5), W2: randn(3, 5), V: randn(5, 1)}}, time(3, 2, 3)}, time(4, 2, 3), nil, gradcheck.Opts{}},
+		{"TimeDropout", &layer.TimeDropout{Ratio: 0.5}, time(3, 2, 4), nil, gradcheck.Opts{Train: true, Seed: 1}},
+		{"TimeEmbedding", &layer.TimeEmbedding{W: randn(6, 4)}, []matrix.Matrix{{{0}, {5}}, {{2}, {0}}, {{1}, {1}}}, nil, gradcheck.Opts{}},
+		{"TimeGRU", &layer.TimeGRU{Wx: randn(4, 3*5), Wh: randn(5, 3*5), B: randn(1, 3*5)}, time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"TimeLayerNorm", &layer.TimeLayerNorm{Gamma: randn(1, 4), Beta: randn(1, 4)}, time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"TimeLSTM", &layer.TimeLSTM{Wx: randn(4, 4*5), Wh: randn(5, 4*5), B: randn(1, 4*5)}, time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"MultiHeadAttention", mha(randn, 2, false, nil), time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"MultiHeadAttention_causal", mha(randn, 1, true, nil), time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"MultiHeadAttention_padding", mha(randn, 2, false, [][]bool{{false, false, true}, {false, true, true}}), time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"MultiHeadAttention_dropout", &layer.MultiHeadAttention{Wq: randn(4, 4), Bq: randn(1, 4), Wk: randn(4, 4), Wv: randn(4, 4), Bv: randn(1, 4), Wo: randn(4, 4), Bo: randn(1, 4), Heads: 2, DropoutRatio: 0.3}, time(3, 2, 4), nil, gradcheck.Opts{Train: true, Seed: 1}},
+		{"MultiHeadAttention_cross", crossAttention{mha(randn, 2, false, nil), time(5, 2, 4)}, time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"MultiHeadAttention_memory", memoryAttention{mha(randn, 2, false, nil), time(3, 2, 4)}, time(5, 2, 4), nil, gradcheck.Opts{}},
+		{"TimePositionalEncoding", &layer.TimePositionalEncoding{W: randn(5, 4), Learned: true}, time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"TransformerEncoderBlock", encoderBlock(randn), time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"TransformerDecoderBlock", decoderBlock(randn), time(3, 2, 4), time(5, 2, 4), gradcheck.Opts{}},
+		{"TransformerDecoderBlock_memory", decoderMemory{decoderBlock(randn), time(3, 2, 4)}, time(5, 2, 4), nil, gradcheck.Opts{}},
+		{"TimeTiedAffine", tiedEmbedding{&layer.TimeTiedAffine{Embedding: &layer.TimeEmbedding{W: randn(6, 4)}, B: randn(1, 6)}}, []matrix.Matrix{{{0}, {5}}, {{2}, {0}}, {{1}, {1}}}, nil, gradcheck.Opts{}},
+		{"TimeRNN", &layer.TimeRNN{Wx: randn(4, 5), Wh: randn(5, 5), B: randn(1, 5)}, time(3, 2, 4), nil, gradcheck.Opts{}},
+		{"TimeSoftmaxWithLoss", &layer.TimeSoftmaxWithLoss{}, time(3, 2, 4), []matrix.Matrix{{{0}, {3}}, {{1}, {1}}, {{2}, {0}}}, gradcheck.Opts{}},
+	}
+
+	for _, c := range cases {
+		r := gradcheck.TimeLayer(c.l, c.xs, c.ts, c.opts)
+		if r.Max() > tol {
+			t.Errorf("%v: %+v", c.name, r)
+		}
+	}
+}
+
+func mha(randn func(m, n int) matrix.Matrix, heads int, causal bool, padding [][]bool) *layer.MultiHeadAttention {
+	return &layer.MultiHeadAttention{
+		Wq: randn(4, 4), Bq: randn(1, 4),
+		Wk: randn(4, 4),
+		Wv: randn(4, 4), Bv: randn(1, 4),
+		Wo: randn(4, 4), Bo: randn(1, 4),
+		Heads:   heads,
+		Causal:  causal,
+		Padding: padding,
+	}
+}
+
+func encoderBlock(randn func(m, n int) matrix.Matrix) *layer.TransformerEncoderBlock {
+	return &layer.TransformerEncoderBlock{
+		Attention: mha(randn, 2, false, nil),
+		Norm1:     &layer.TimeLayerNorm{Gamma: randn(1, 4), Beta: randn(1, 4)},
+		FF1:       &layer.TimeAffine{W: randn(4, 6), B: randn(1, 6)},
+		FF2:       &layer.TimeAffine{W: randn(6, 4), B: randn(1, 4)},
+		Norm2:     &layer.TimeLayerNorm{Gamma: randn(1, 4), Beta: randn(1, 4)},
+	}
+}
+
+func decoderBlock(randn func(m, n int) matrix.Matrix) *layer.TransformerDecoderBlock {
+	return &layer.TransformerDecoderBlock{
+		SelfAttention:  mha(randn, 2, true, nil),
+		Norm1:          &layer.TimeLayerNorm{Gamma: randn(1, 4), Beta: randn(1, 4)},
+		CrossAttention: mha(randn, 2, false, nil),
+		Norm2:          &layer.TimeLayerNorm{Gamma: randn(1, 4), Beta: randn(1, 4)},
+		FF1:            &layer.TimeAffine{W: randn(4, 6), B: randn(1, 6)},
+		FF2:            &layer.TimeAffine{W: randn(6, 4), B: randn(1, 4)},
+		Norm3:          &layer.TimeLayerNorm{Gamma: randn(1, 4), Beta: randn(1, 4)},
+	}
+}
@@ -5,26 +5,31 @@ import (
 
 	"github.com/itsubaki/neu/activation"
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
-type GRU struct {
-	Wx, Wh, B      matrix.Matrix // params
-	DWx, DWh, DB   matrix.Matrix // grads
-	x, hprev, hhat matrix.Matrix // cache
-	z, r           matrix.Matrix // cache
+type GRU = GRUOf[float64]
+
+// GRUOf is GRU for the parameters of E.
+type GRUOf[E vector.Float] struct {
+	Wx, Wh, B      matrix.Dense[E] // params
+	DWx, DWh, DB   matrix.Dense[E] // grads
+	x, hprev, hhat matrix.Dense[E] // cache
+	z, r           matrix.Dense[E] // cache
 }
 
-func (l *GRU) Params() []matrix.Matrix      { return []matrix.Matrix{l.Wx, l.Wh, l.B} }
-func (l *GRU) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DWx, l.DWh, l.DB} }
-func (l *GRU) SetParams(p ...matrix.Matrix) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
-func (l *GRU) String() string {
+func (l *GRUOf[E]) Params() []matrix.Dense[E]      { return []matrix.Dense[E]{l.Wx, l.Wh, l.B} }
+func (l *GRUOf[E]) ParamNames() []string           { return []string{"Wx", "Wh", "B"} }
+func (l *GRUOf[E]) Grads() []matrix.Dense[E]       { return []matrix.Dense[E]{l.DWx, l.DWh, l.DB} }
+func (l *GRUOf[E]) SetParams(p ...matrix.Dense[E]) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
+func (l *GRUOf[E]) String() string {
 	a, b := l.Wx.Dim()
 	c, d := l.Wh.Dim()
 	e, f := l.B.Dim()
-	return fmt.Sprintf("%T: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", l, a, b, c, d, e, f, a*b+c*d+e*f)
+	return fmt.Sprintf("%v: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", typeName(l), a, b, c, d, e, f, a*b+c*d+e*f)
 }
 
-func (l *GRU) Forward(x, h matrix.Matrix, _ ...Opts) matrix.Matrix {
+func (l *GRUOf[E]) Forward(x, h matrix.Dense[E], _ ...Opts) matrix.Dense[E] {
 	H := len(l.Wh)               // (H, 3H)
 	WxH := matrix.Split(l.Wx, H) // (3, D, H)
 	WhH := matrix.Split(l.Wh, H) // (3, H, H)
@@ -43,7 +48,7 @@ func (l *GRU) Forward(x, h matrix.Matrix, _ ...Opts) matrix.Matrix {
 	return hnext
 }
 
-func (l *GRU) Backward(dhnext matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+func (l *GRUOf[E]) Backward(dhnext matrix.Dense[E]) (matrix.Dense[E], matrix.Dense[E]) {
 	H := len(l.Wh)               // (H, 3H)
 	WxH := matrix.Split(l.Wx, H) // (3, D, H)
 	WhH := matrix.Split(l.Wh, H) // (3, H, H)
@@ -57,7 +62,7 @@ func (l *GRU) Backward(dhnext matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
 
 	// tanh
 	dt := dhhat.Mul(matrix.F(l.hhat, dTanh))     // dt = dhhat * (1 - hhat**2)
-	dbh := matrix.New(dt.SumAxis0())             // dbh = sum(dt, axis=0)
+	dbh := matrix.NewOf(dt.SumAxis0())           // dbh = sum(dt, axis=0)
 	dWhh := matrix.Dot(l.r.Mul(l.hprev).T(), dt) // dWhh = (r * hprev).T.dt
 	dhr := matrix.Dot(dt, Whh.T())               // dhr = dt.Whh.T
 	dWxh := matrix.Dot(l.x.T(), dt)              // dWxh = x.T.dt
@@ -67,16 +72,16 @@ func (l *GRU) Backward(dhnext matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
 	// gate(z)
 	dz := dhnext.Mul(l.hhat).Sub(dhnext.Mul(l.hprev)) // dz = dhnext * hhat - dhnext * hprev
 	dtz := dz.Mul(matrix.F(l.z, dSigmoid))            // dtz = dz * z * (1 - z)
-	dbz := matrix.New(dtz.SumAxis0())                 // dbz = sum(dtz, axis=0)
+	dbz := matrix.NewOf(dtz.SumAxis0())               // dbz = sum(dtz, axis=0)
 	dWhz := matrix.Dot(l.hprev.T(), dtz)              // dWhz = hprev.T.dtz
 	dhprev = dhprev.Add(matrix.Dot(dtz, Whz.T()))     // dhprev = dhprev + dtz.Whz.T
 	dWxz := matrix.Dot(l.x.T(), dtz)                  // dWxz = x.T.dtz
-	dx = dx.Add(matrix.Dot(dt, Wxz.T()))              // dx = dx + dtz.Wxz.T
+	dx = dx.Add(matrix.Dot(dtz, Wxz.T()))             // dx = dx + dtz.Wxz.T
 
 	// gate(r)
 	dr := dhr.Mul(l.hprev)                        // dr = dhr * hprev
 	dtr := dr.Mul(matrix.F(l.r, dSigmoid))        // dtr = dr * r * (1 - r)
-	dbr := matrix.New(dtr.SumAxis0())             // dbr = sum(dtr, axis=0)
+	dbr := matrix.NewOf(dtr.SumAxis0())           // dbr = sum(dtr, axis=0)
 	dWhr := matrix.Dot(l.hprev.T(), dtr)          // dWhr = hprev.T.dtr
 	dhprev = dhprev.Add(matrix.Dot(dtr, Whr.T())) // dhprev = dhprev + dtr.Whr.T
 	dWxr := matrix.Dot(l.x.T(), dtr)              // dWzr = x.T.dtr
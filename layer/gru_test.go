@@ -52,7 +52,7 @@ func ExampleGRU() {
 	// Output:
 	// *layer.GRU: Wx(3, 3), Wh(1, 3), B(1, 3): 15
 	// [[0.6435151783703015] [0.7197594876758177]]
-	// [[0.19802226281185067 0.19802226281185067 0.19802226281185067] [0.17355749298151332 0.17355749298151332 0.17355749298151332]]
+	// [[0.13386127022055525 0.13386127022055525 0.13386127022055525] [0.11918853636168007 0.11918853636168007 0.11918853636168007]]
 	// [[0.5336723979435917] [0.5151767843512871]]
 }
 
@@ -0,0 +1,51 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// LayerNorm is a layer that performs layer normalization.
+// Each sample is normalized over its features, so the output does not depend on the batch.
+type LayerNorm struct {
+	Gamma, Beta   matrix.Matrix // params
+	DGamma, DBeta matrix.Matrix // grads
+	xn, std       matrix.Matrix
+}
+
+func (l *LayerNorm) Params() []matrix.Matrix      { return []matrix.Matrix{l.Gamma, l.Beta} }
+func (l *LayerNorm) ParamNames() []string         { return []string{"Gamma", "Beta"} }
+func (l *LayerNorm) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DGamma, l.DBeta} }
+func (l *LayerNorm) SetParams(p ...matrix.Matrix) { l.Gamma, l.Beta = p[0], p[1] }
+func (l *LayerNorm) String() string {
+	a, b := l.Gamma.Dim()
+	c, d := l.Beta.Dim()
+	return fmt.Sprintf("%T: G(%v, %v), B(%v, %v): %v", l, a, b, c, d, a*b+c*d)
+}
+
+func (l *LayerNorm) Forward(x, _ matrix.Matrix, _ ...Opts) matrix.Matrix {
+	mu := meanAxis1(x)                      // mean(x, axis=1)
+	xc := x.Sub(mu)                         // x - mu
+	l.std = meanAxis1(xc.Pow2()).Sqrt(1e-7) // sqrt(mean(xc**2, axis=1) + 1e-7)
+	l.xn = xc.Div(l.std)                    // (x - mu) / std
+
+	return l.xn.Mul(l.Gamma).Add(l.Beta) // xn * gamma + beta
+}
+
+func (l *LayerNorm) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+	// DBeta, DGamma
+	l.DGamma = matrix.New(l.xn.Mul(dout).SumAxis0()) // sum(xn * dout, axis=0)
+	l.DBeta = matrix.New(dout.SumAxis0())            // sum(dout, axis=0)
+
+	// dx = (dxn - mean(dxn) - xn * mean(dxn * xn)) / std
+	dxn := dout.Mul(l.Gamma)
+	dx := dxn.Sub(meanAxis1(dxn)).Sub(l.xn.Mul(meanAxis1(dxn.Mul(l.xn))))
+	return dx.Div(l.std), nil
+}
+
+// meanAxis1 returns the mean of each row as a column vector.
+func meanAxis1(x matrix.Matrix) matrix.Matrix {
+	_, d := x.Dim()
+	return matrix.New(x.SumAxis1()).T().MulC(1.0 / float64(d))
+}
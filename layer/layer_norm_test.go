@@ -0,0 +1,51 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+func ExampleLayerNorm() {
+	x := matrix.New([]float64{1, 2, 3}, []float64{1, 2, 6})
+
+	n := &layer.LayerNorm{
+		Gamma: matrix.One(1, 3),
+		Beta:  matrix.Zero(1, 3),
+	}
+
+	fmt.Println(n)
+	for _, r := range n.Forward(x, nil) {
+		fmt.Printf("%.4f\n", r)
+	}
+
+	dx, _ := n.Backward(matrix.New([]float64{1, 0, 0}, []float64{0, 1, 0}))
+	for _, r := range dx {
+		fmt.Printf("%.4f\n", r)
+	}
+
+	fmt.Printf("%.4f\n", n.DGamma)
+	fmt.Printf("%.4f\n", n.DBeta)
+
+	// Output:
+	// *layer.LayerNorm: G(1, 3), B(1, 3): 6
+	// [-1.2247 0.0000 1.2247]
+	// [-0.9258 -0.4629 1.3887]
+	// [0.2041 -0.4082 0.2041]
+	// [-0.2204 0.2755 -0.0551]
+	// [[-1.2247 -0.4629 0.0000]]
+	// [[1.0000 1.0000 0.0000]]
+}
+
+func ExampleLayerNorm_Params() {
+	n := &layer.LayerNorm{}
+
+	n.SetParams(make([]matrix.Matrix, 2)...)
+	fmt.Println(n.Params())
+	fmt.Println(n.Grads())
+
+	// Output:
+	// [[] []]
+	// [[] []]
+}
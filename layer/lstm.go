@@ -5,27 +5,32 @@ import (
 
 	"github.com/itsubaki/neu/activation"
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
-type LSTM struct {
-	Wx, Wh, B    matrix.Matrix // params
-	DWx, DWh, DB matrix.Matrix // grads
-	x, h, c      matrix.Matrix // cache
-	f, g, i, o   matrix.Matrix // cache
-	cNext        matrix.Matrix // cache
+type LSTM = LSTMOf[float64]
+
+// LSTMOf is LSTM for the parameters of E.
+type LSTMOf[E vector.Float] struct {
+	Wx, Wh, B    matrix.Dense[E] // params
+	DWx, DWh, DB matrix.Dense[E] // grads
+	x, h, c      matrix.Dense[E] // cache
+	f, g, i, o   matrix.Dense[E] // cache
+	cNext        matrix.Dense[E] // cache
 }
 
-func (l *LSTM) Params() []matrix.Matrix      { return []matrix.Matrix{l.Wx, l.Wh, l.B} }
-func (l *LSTM) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DWx, l.DWh, l.DB} }
-func (l *LSTM) SetParams(p ...matrix.Matrix) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
-func (l *LSTM) String() string {
+func (l *LSTMOf[E]) Params() []matrix.Dense[E]      { return []matrix.Dense[E]{l.Wx, l.Wh, l.B} }
+func (l *LSTMOf[E]) ParamNames() []string           { return []string{"Wx", "Wh", "B"} }
+func (l *LSTMOf[E]) Grads() []matrix.Dense[E]       { return []matrix.Dense[E]{l.DWx, l.DWh, l.DB} }
+func (l *LSTMOf[E]) SetParams(p ...matrix.Dense[E]) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
+func (l *LSTMOf[E]) String() string {
 	a, b := l.Wx.Dim()
 	c, d := l.Wh.Dim()
 	e, f := l.B.Dim()
-	return fmt.Sprintf("%T: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", l, a, b, c, d, e, f, a*b+c*d+e*f)
+	return fmt.Sprintf("%v: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", typeName(l), a, b, c, d, e, f, a*b+c*d+e*f)
 }
 
-func (l *LSTM) Forward(x, h, c matrix.Matrix, _ ...Opts) (matrix.Matrix, matrix.Matrix) {
+func (l *LSTMOf[E]) Forward(x, h, c matrix.Dense[E], _ ...Opts) (matrix.Dense[E], matrix.Dense[E]) {
 	A := matrix.Dot(x, l.Wx).Add(matrix.Dot(h, l.Wh)).Add(l.B) // (N, 4H) = x(N, D).Wx(D, 4H) + h(N, H).Wh(H, 4H) + b(1, 4H)
 	AH := matrix.Split(A, len(h[0]))                           // (4, N, H)
 
@@ -46,7 +51,7 @@ func (l *LSTM) Forward(x, h, c matrix.Matrix, _ ...Opts) (matrix.Matrix, matrix.
 	return hNext, cNext
 }
 
-func (l *LSTM) Backward(dhNext, dcNext matrix.Matrix) (matrix.Matrix, matrix.Matrix, matrix.Matrix) {
+func (l *LSTMOf[E]) Backward(dhNext, dcNext matrix.Dense[E]) (matrix.Dense[E], matrix.Dense[E], matrix.Dense[E]) {
 	tanh := matrix.F(l.cNext, activation.Tanh) // tanh(cNext)
 	dt := matrix.F(tanh, dTanh)                // 1 - tanh(cNext)**2
 	ds := dcNext.Add(dhNext.Mul(l.o).Mul(dt))  // dcNext + (dhNext * o) * (1 - tanh(cNext)**2)
@@ -66,7 +71,7 @@ func (l *LSTM) Backward(dhNext, dcNext matrix.Matrix) (matrix.Matrix, matrix.Mat
 	// grads
 	l.DWx = matrix.Dot(l.x.T(), dA)
 	l.DWh = matrix.Dot(l.h.T(), dA)
-	l.DB = matrix.New(dA.SumAxis0())
+	l.DB = matrix.NewOf(dA.SumAxis0())
 
 	// prev
 	dx := matrix.Dot(dA, l.Wx.T())     // (N, D)
@@ -0,0 +1,97 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/tensor"
+)
+
+// MaxPooling is a layer that performs a max pooling.
+// The input is (N, C*H*W) and the output is (N, C*OH*OW).
+type MaxPooling struct {
+	Channel, Height, Width int // input shape
+	PoolHeight, PoolWidth  int // pool shape
+	Stride, Pad            int
+	argmax                 [][][]int
+}
+
+func (l *MaxPooling) Params() []matrix.Matrix      { return make([]matrix.Matrix, 0) }
+func (l *MaxPooling) Grads() []matrix.Matrix       { return make([]matrix.Matrix, 0) }
+func (l *MaxPooling) SetParams(p ...matrix.Matrix) {}
+func (l *MaxPooling) String() string {
+	return fmt.Sprintf("%T: Pool(%v, %v), Stride(%v), Pad(%v)", l, l.PoolHeight, l.PoolWidth, l.Stride, l.Pad)
+}
+
+// OutputSize returns the shape of the output image.
+func (l *MaxPooling) OutputSize() (int, int, int) {
+	oh, ow := outhw(l.Height, l.Width, l.PoolHeight, l.PoolWidth, l.Pad, l.Stride)
+	return l.Channel, oh, ow
+}
+
+// Forward returns the max of each pool.
+// It panics if Pad is not smaller than the pool size, since a pool of only the padding has no max.
+func (l *MaxPooling) Forward(x, _ matrix.Matrix, _ ...Opts) matrix.Matrix {
+	if l.Pad >= l.PoolHeight || l.Pad >= l.PoolWidth {
+		panic(fmt.Sprintf("invalid pad=%v for pool=(%v, %v)", l.Pad, l.PoolHeight, l.PoolWidth))
+	}
+
+	N := len(x)
+	l.argmax = make([][][]int, N)
+	out := make(matrix.Matrix, N)
+
+	matrix.Parallel(N, func(begin, end int) {
+		for i := begin; i < end; i++ {
+			img := image(x[i], l.Channel, l.Height, l.Width) // (C, H, W)
+			l.argmax[i] = make([][]int, l.Channel)
+
+			for c := 0; c < l.Channel; c++ {
+				col := im2col(padInf(img[c], l.Pad), l.PoolHeight, l.PoolWidth, 0, l.Stride) // (OH*OW, PH*PW)
+				l.argmax[i][c] = col.Argmax()                                                // (OH*OW)
+				out[i] = append(out[i], col.MaxAxis1()...)                                   // (C*OH*OW)
+			}
+		}
+	})
+
+	return out // (N, C*OH*OW)
+}
+
+func (l *MaxPooling) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+	N := len(dout)
+	dx := make(matrix.Matrix, N)
+
+	matrix.Parallel(N, func(begin, end int) {
+		for i := begin; i < end; i++ {
+			dimg := make([]matrix.Matrix, l.Channel)
+			for c := 0; c < l.Channel; c++ {
+				size := len(l.argmax[i][c]) // OH*OW
+				dcol := matrix.Zero(size, l.PoolHeight*l.PoolWidth)
+				for j, arg := range l.argmax[i][c] {
+					dcol[j][arg] = dout[i][c*size+j] // the gradient flows only to the max element
+				}
+
+				dimg[c] = col2im(dcol, l.Height, l.Width, l.PoolHeight, l.PoolWidth, l.Pad, l.Stride) // (H, W)
+			}
+
+			dx[i] = tensor.Flatten(dimg) // (C*H*W)
+		}
+	})
+
+	return dx, nil // (N, C*H*W)
+}
+
+// padInf returns the image padded with -Inf, which is never the max of the pool.
+func padInf(im matrix.Matrix, pad int) matrix.Matrix {
+	h, w := im.Dim()
+	out := matrix.Padding(im, pad)
+	for i := range out {
+		for j := range out[i] {
+			if i < pad || i >= pad+h || j < pad || j >= pad+w {
+				out[i][j] = math.Inf(-1)
+			}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,110 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+func ExampleMaxPooling() {
+	// N, C, H, W := 1, 2, 4, 4
+	pool := &layer.MaxPooling{
+		Channel:    2,
+		Height:     4,
+		Width:      4,
+		PoolHeight: 2,
+		PoolWidth:  2,
+		Stride:     2,
+	}
+	fmt.Println(pool)
+	fmt.Println(pool.OutputSize())
+
+	// forward
+	x := matrix.New([]float64{
+		1, 2, 0, 1,
+		3, 4, 1, 0,
+		0, 0, 5, 6,
+		0, 9, 8, 7,
+		//
+		-1, -2, -3, -4,
+		-5, -6, -7, -8,
+		-9, -8, -7, -6,
+		-5, -4, -3, -2,
+	})
+	fmt.Println(pool.Forward(x, nil))
+
+	// backward
+	dout := matrix.New([]float64{1, 2, 3, 4, 5, 6, 7, 8})
+	dx, _ := pool.Backward(dout)
+	fmt.Println(matrix.Reshape(dx, 8, 4))
+
+	// Output:
+	// *layer.MaxPooling: Pool(2, 2), Stride(2), Pad(0)
+	// 2 2 2
+	// [[4 1 9 8 -1 -3 -4 -2]]
+	// [[0 0 0 2] [0 1 0 0] [0 0 0 0] [0 3 4 0] [5 0 6 0] [0 0 0 0] [0 0 0 0] [0 7 0 8]]
+}
+
+func ExampleMaxPooling_pad() {
+	// N, C, H, W := 3, 1, 2, 2
+	pool := &layer.MaxPooling{
+		Channel:    1,
+		Height:     2,
+		Width:      2,
+		PoolHeight: 2,
+		PoolWidth:  2,
+		Stride:     1,
+		Pad:        1,
+	}
+	fmt.Println(pool.OutputSize())
+
+	// forward
+	x := matrix.New(
+		[]float64{1, 2, 3, 4},
+		[]float64{4, 3, 2, 1},
+		[]float64{-4, -3, -2, -1}, // the padding is not the max
+	)
+	fmt.Println(pool.Forward(x, nil))
+
+	// backward
+	dout := matrix.One(3, 9)
+	fmt.Println(pool.Backward(dout))
+
+	// Output:
+	// 1 3 3
+	// [[1 2 2 3 4 4 3 4 4] [4 4 3 4 4 3 2 2 1] [-4 -3 -3 -2 -1 -1 -2 -1 -1]]
+	// [[1 2 2 4] [4 2 2 1] [1 2 2 4]] []
+}
+
+func ExampleMaxPooling_invalidPad() {
+	pool := &layer.MaxPooling{
+		Channel:    1,
+		Height:     2,
+		Width:      2,
+		PoolHeight: 2,
+		PoolWidth:  2,
+		Stride:     1,
+		Pad:        2,
+	}
+
+	defer func() {
+		fmt.Println(recover())
+	}()
+	pool.Forward(matrix.New([]float64{1, 2, 3, 4}), nil)
+
+	// Output:
+	// invalid pad=2 for pool=(2, 2)
+}
+
+func ExampleMaxPooling_Params() {
+	pool := &layer.MaxPooling{}
+
+	pool.SetParams(make([]matrix.Matrix, 0)...)
+	fmt.Println(pool.Params())
+	fmt.Println(pool.Grads())
+
+	// Output:
+	// []
+	// []
+}
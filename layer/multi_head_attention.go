@@ -0,0 +1,210 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/tensor"
+)
+
+// MultiHeadAttention is a layer that performs multi-head scaled dot-product attention.
+// The inputs and outputs are (T, N, H), and H must be a multiple of Heads.
+// The keys have no bias, since it does not change the attention weights.
+type MultiHeadAttention struct {
+	Wq, Wk, Wv, Wo     matrix.Matrix // params
+	Bq, Bv, Bo         matrix.Matrix // params
+	DWq, DWk, DWv, DWo matrix.Matrix // grads
+	DBq, DBv, DBo      matrix.Matrix // grads
+	Heads              int
+	Causal             bool     // the query t attends to the keys up to t
+	Padding            [][]bool // Padding[n][t] is true if the key t of the sample n is not attended
+	DropoutRatio       float64  // the dropout ratio of the attention weights
+	self               bool
+	cache              []mha
+}
+
+// mha is the cache of a sample.
+type mha struct {
+	x, y, q, k, v, o matrix.Matrix
+	a, mask          []matrix.Matrix // for each head
+}
+
+func (l *MultiHeadAttention) Params() []matrix.Matrix {
+	return []matrix.Matrix{l.Wq, l.Bq, l.Wk, l.Wv, l.Bv, l.Wo, l.Bo}
+}
+
+func (l *MultiHeadAttention) ParamNames() []string {
+	return []string{"Wq", "Bq", "Wk", "Wv", "Bv", "Wo", "Bo"}
+}
+
+func (l *MultiHeadAttention) Grads() []matrix.Matrix {
+	return []matrix.Matrix{l.DWq, l.DBq, l.DWk, l.DWv, l.DBv, l.DWo, l.DBo}
+}
+
+func (l *MultiHeadAttention) SetParams(p ...matrix.Matrix) {
+	l.Wq, l.Bq, l.Wk, l.Wv, l.Bv, l.Wo, l.Bo = p[0], p[1], p[2], p[3], p[4], p[5], p[6]
+}
+
+func (l *MultiHeadAttention) SetState(_ ...matrix.Matrix) {}
+func (l *MultiHeadAttention) ResetState()                 {}
+
+func (l *MultiHeadAttention) String() string {
+	a, b := l.Wq.Dim()
+	return fmt.Sprintf("%T: Wq(%v, %v), Wk(%v, %v), Wv(%v, %v), Wo(%v, %v), Heads(%v): %v", l, a, b, a, b, a, b, a, b, l.Heads, 4*a*b+3*b)
+}
+
+// Weights returns the attention weights of the last Forward, which are (Tq, Tk) for each sample and head.
+// The dropout is not applied to them.
+func (l *MultiHeadAttention) Weights() [][]matrix.Matrix {
+	out := make([][]matrix.Matrix, len(l.cache))
+	for n := range l.cache {
+		out[n] = l.cache[n].a
+	}
+
+	return out
+}
+
+// Forward returns the attention of the queries xs over the keys and values ys.
+// If ys is nil, ys is xs, i.e. the self-attention.
+func (l *MultiHeadAttention) Forward(xs, ys []matrix.Matrix, opts ...Opts) []matrix.Matrix {
+	l.self = ys == nil
+	if l.self {
+		ys = xs
+	}
+
+	T, N, H := len(xs), len(xs[0]), len(xs[0][0])
+	dk := H / l.Heads
+	scale := 1.0 / math.Sqrt(float64(dk))
+	train := len(opts) > 0 && opts[0].Train && l.DropoutRatio > 0
+
+	l.cache = make([]mha, N)
+	out := tensor.Zero(T, N, H)
+	for n := 0; n < N; n++ {
+		c := mha{x: sample(xs, n), y: sample(ys, n)}
+		c.q = matrix.Dot(c.x, l.Wq).Add(l.Bq) // (Tq, H)
+		c.k = matrix.Dot(c.y, l.Wk)           // (Tk, H)
+		c.v = matrix.Dot(c.y, l.Wv).Add(l.Bv) // (Tk, H)
+		qs, ks, vs := matrix.Split(c.q, dk), matrix.Split(c.k, dk), matrix.Split(c.v, dk)
+
+		c.a, c.mask = make([]matrix.Matrix, l.Heads), make([]matrix.Matrix, l.Heads)
+		heads := make([]matrix.Matrix, l.Heads)
+		for h := 0; h < l.Heads; h++ {
+			s := matrix.Dot(qs[h], ks[h].T()).MulC(scale) // q * k^T / sqrt(dk)
+			l.masked(s, n)
+
+			c.a[h] = softmax(s)
+			a := c.a[h]
+			if train {
+				rnd := matrix.Rand(len(a), len(a[0]), opts[0].Source)
+				msk := matrix.Mask(rnd, func(x float64) bool { return x > l.DropoutRatio })
+				c.mask[h] = msk.MulC(1.0 / (1.0 - l.DropoutRatio))
+				a = a.Mul(c.mask[h])
+			}
+
+			heads[h] = matrix.Dot(a, vs[h]) // (Tq, dk)
+		}
+
+		c.o = matrix.HStack(heads...)        // (Tq, H)
+		y := matrix.Dot(c.o, l.Wo).Add(l.Bo) // (Tq, H)
+		for t := 0; t < T; t++ {
+			out[t][n] = y[t]
+		}
+
+		l.cache[n] = c
+	}
+
+	return out
+}
+
+// Backward returns the gradient of xs.
+// For the self-attention, it includes the gradient through the keys and values.
+func (l *MultiHeadAttention) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	dxs, dys := l.BackwardKV(dout)
+	if l.self {
+		return tensor.Add(dxs, dys)
+	}
+
+	return dxs
+}
+
+// BackwardKV returns the gradients of the queries xs and of the keys and values ys.
+func (l *MultiHeadAttention) BackwardKV(dout []matrix.Matrix) ([]matrix.Matrix, []matrix.Matrix) {
+	T, N, H := len(dout), len(dout[0]), len(dout[0][0])
+	Tk := len(l.cache[0].y)
+	dk := H / l.Heads
+	scale := 1.0 / math.Sqrt(float64(dk))
+
+	l.DWq, l.DWk, l.DWv, l.DWo = matrix.ZeroLike(l.Wq), matrix.ZeroLike(l.Wk), matrix.ZeroLike(l.Wv), matrix.ZeroLike(l.Wo)
+	l.DBq, l.DBv, l.DBo = matrix.ZeroLike(l.Bq), matrix.ZeroLike(l.Bv), matrix.ZeroLike(l.Bo)
+
+	dxs, dys := tensor.Zero(T, N, H), tensor.Zero(Tk, N, H)
+	for n := 0; n < N; n++ {
+		c := l.cache[n]
+		d := sample(dout, n) // (Tq, H)
+
+		// output projection
+		l.DWo = l.DWo.Add(matrix.Dot(c.o.T(), d))
+		l.DBo = l.DBo.Add(matrix.New(d.SumAxis0()))
+		do := matrix.Split(matrix.Dot(d, l.Wo.T()), dk)
+
+		qs, ks, vs := matrix.Split(c.q, dk), matrix.Split(c.k, dk), matrix.Split(c.v, dk)
+		dq, dkh, dv := make([]matrix.Matrix, l.Heads), make([]matrix.Matrix, l.Heads), make([]matrix.Matrix, l.Heads)
+		for h := 0; h < l.Heads; h++ {
+			a, da := c.a[h], matrix.Dot(do[h], vs[h].T())
+			if c.mask[h] != nil {
+				a, da = a.Mul(c.mask[h]), da.Mul(c.mask[h])
+			}
+			dv[h] = matrix.Dot(a.T(), do[h])
+
+			// softmax
+			ds := c.a[h].Mul(da)
+			sum := matrix.New(ds.SumAxis1()).T()
+			ds = ds.Sub(c.a[h].Mul(sum)).MulC(scale)
+
+			dq[h] = matrix.Dot(ds, ks[h])
+			dkh[h] = matrix.Dot(ds.T(), qs[h])
+		}
+
+		// input projections
+		dQ, dK, dV := matrix.HStack(dq...), matrix.HStack(dkh...), matrix.HStack(dv...)
+		l.DWq = l.DWq.Add(matrix.Dot(c.x.T(), dQ))
+		l.DWk = l.DWk.Add(matrix.Dot(c.y.T(), dK))
+		l.DWv = l.DWv.Add(matrix.Dot(c.y.T(), dV))
+		l.DBq = l.DBq.Add(matrix.New(dQ.SumAxis0()))
+		l.DBv = l.DBv.Add(matrix.New(dV.SumAxis0()))
+
+		dx := matrix.Dot(dQ, l.Wq.T())
+		dy := matrix.Dot(dK, l.Wk.T()).Add(matrix.Dot(dV, l.Wv.T()))
+		for t := range dx {
+			dxs[t][n] = dx[t]
+		}
+
+		for t := range dy {
+			dys[t][n] = dy[t]
+		}
+	}
+
+	return dxs, dys
+}
+
+// masked sets the scores of the keys that are not attended to a large negative value.
+func (l *MultiHeadAttention) masked(s matrix.Matrix, n int) {
+	for i := range s {
+		for j := range s[i] {
+			if (l.Causal && j > i) || (l.Padding != nil && l.Padding[n][j]) {
+				s[i][j] = -1e9
+			}
+		}
+	}
+}
+
+// sample returns (T, H) of the sample n of xs (T, N, H).
+func sample(xs []matrix.Matrix, n int) matrix.Matrix {
+	out := make(matrix.Matrix, len(xs))
+	for t := range xs {
+		out[t] = xs[t][n]
+	}
+
+	return out
+}
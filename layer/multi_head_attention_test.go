@@ -0,0 +1,134 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+)
+
+func newMultiHeadAttention(H, heads int) *layer.MultiHeadAttention {
+	s := rand.Const(1)
+	return &layer.MultiHeadAttention{
+		Wq: matrix.Randn(H, H, s), Bq: matrix.Zero(1, H),
+		Wk: matrix.Randn(H, H, s),
+		Wv: matrix.Randn(H, H, s), Bv: matrix.Zero(1, H),
+		Wo: matrix.Randn(H, H, s), Bo: matrix.Zero(1, H),
+		Heads: heads,
+	}
+}
+
+func ExampleMultiHeadAttention() {
+	l := newMultiHeadAttention(4, 2)
+	fmt.Println(l)
+
+	// (T, N, H) = (3, 1, 4)
+	xs := []matrix.Matrix{
+		{{1, 0, 0, 0}},
+		{{0, 1, 0, 0}},
+		{{0, 0, 1, 1}},
+	}
+
+	ys := l.Forward(xs, nil)
+	fmt.Println(len(ys), len(ys[0]), len(ys[0][0]))
+
+	dxs := l.Backward(ys)
+	fmt.Println(len(dxs), len(dxs[0]), len(dxs[0][0]))
+
+	// weights of the sample 0 and the head 0
+	for _, r := range l.Weights()[0][0] {
+		fmt.Printf("%.4f\n", r)
+	}
+
+	// Output:
+	// *layer.MultiHeadAttention: Wq(4, 4), Wk(4, 4), Wv(4, 4), Wo(4, 4), Heads(2): 76
+	// 3 1 4
+	// 3 1 4
+	// [0.5887 0.1985 0.2128]
+	// [0.1899 0.4578 0.3523]
+	// [0.3477 0.3403 0.3120]
+}
+
+func ExampleMultiHeadAttention_causal() {
+	l := newMultiHeadAttention(4, 2)
+	l.Causal = true
+
+	xs := []matrix.Matrix{
+		{{1, 0, 0, 0}},
+		{{0, 1, 0, 0}},
+		{{0, 0, 1, 1}},
+	}
+
+	l.Forward(xs, nil)
+	for _, r := range l.Weights()[0][1] {
+		fmt.Printf("%.4f\n", r)
+	}
+
+	// Output:
+	// [1.0000 0.0000 0.0000]
+	// [0.7809 0.2191 0.0000]
+	// [0.0001 0.0005 0.9994]
+}
+
+func ExampleMultiHeadAttention_padding() {
+	l := newMultiHeadAttention(4, 1)
+	l.Padding = [][]bool{{false, false, true}}
+
+	// cross-attention. the queries are (1, 1, 4), and the keys and values are (3, 1, 4).
+	qs := []matrix.Matrix{{{1, 0, 0, 0}}}
+	ys := []matrix.Matrix{{{1, 0, 0, 0}}, {{0, 1, 0, 0}}, {{0, 0, 1, 1}}}
+
+	out := l.Forward(qs, ys)
+	fmt.Println(len(out), len(out[0]), len(out[0][0]))
+	fmt.Printf("%.4f\n", l.Weights()[0][0])
+
+	dqs, dys := l.BackwardKV(out)
+	fmt.Println(len(dqs), len(dys))
+	fmt.Printf("%.4f\n", dys[2])
+
+	// Output:
+	// 1 1 4
+	// [[0.6985 0.3015 0.0000]]
+	// 1 3
+	// [[0.0000 0.0000 0.0000 0.0000]]
+}
+
+func ExampleMultiHeadAttention_dropout() {
+	l := newMultiHeadAttention(4, 2)
+	l.DropoutRatio = 0.5
+
+	xs := []matrix.Matrix{
+		{{1, 0, 0, 0}},
+		{{0, 1, 0, 0}},
+	}
+
+	// inference
+	y0 := l.Forward(xs, nil)
+	y1 := l.Forward(xs, nil)
+	fmt.Println(y0[1].Sub(y1[1]).Abs().Sum())
+
+	// training
+	y2 := l.Forward(xs, nil, layer.Opts{Train: true, Source: rand.Const(1)})
+	fmt.Println(y0[1].Sub(y2[1]).Abs().Sum() > 0)
+
+	// Output:
+	// 0
+	// true
+}
+
+func ExampleMultiHeadAttention_Params() {
+	l := &layer.MultiHeadAttention{}
+	l.SetParams(make([]matrix.Matrix, 7)...)
+	l.SetState()
+	l.ResetState()
+
+	fmt.Println(l.Params())
+	fmt.Println(l.Grads())
+	fmt.Println(l.ParamNames())
+
+	// Output:
+	// [[] [] [] [] [] [] []]
+	// [[] [] [] [] [] [] []]
+	// [Wq Bq Wk Wv Bv Wo Bo]
+}
@@ -44,6 +44,15 @@ func (l *NegativeSamplingLoss) Params() []matrix.Matrix {
 	return params
 }
 
+func (l *NegativeSamplingLoss) ParamNames() []string {
+	names := make([]string, 0)
+	for _, e := range l.embeddingDot {
+		names = append(names, e.ParamNames()...)
+	}
+
+	return names
+}
+
 func (l *NegativeSamplingLoss) Grads() []matrix.Matrix {
 	grads := make([]matrix.Matrix, 0)
 	for _, e := range l.embeddingDot {
@@ -53,6 +62,15 @@ func (l *NegativeSamplingLoss) Grads() []matrix.Matrix {
 	return grads
 }
 
+func (l *NegativeSamplingLoss) SparseGrads() []*matrix.Sparse {
+	grads := make([]*matrix.Sparse, 0)
+	for i := range l.embeddingDot {
+		grads = append(grads, l.embeddingDot[i].SparseGrads()...)
+	}
+
+	return grads
+}
+
 func (l *NegativeSamplingLoss) SetParams(p ...matrix.Matrix) {
 	for i, pp := range p {
 		l.embeddingDot[i].SetParams(pp)
@@ -1,8 +1,18 @@
 package layer
 
-import randv2 "math/rand/v2"
+import (
+	"fmt"
+	randv2 "math/rand/v2"
+	"strings"
+)
 
 type Opts struct {
 	Train  bool
 	Source randv2.Source
 }
+
+// typeName returns the type name of the layer without the float64 type argument,
+// e.g. *layer.Affine for *layer.AffineOf[float64].
+func typeName(l any) string {
+	return strings.TrimSuffix(fmt.Sprintf("%T", l), "Of[float64]")
+}
@@ -5,25 +5,30 @@ import (
 
 	"github.com/itsubaki/neu/activation"
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
-type RNN struct {
-	Wx, Wh, B    matrix.Matrix // params
-	DWx, DWh, DB matrix.Matrix // grads
-	x, h, hNext  matrix.Matrix // cache
+type RNN = RNNOf[float64]
+
+// RNNOf is RNN for the parameters of E.
+type RNNOf[E vector.Float] struct {
+	Wx, Wh, B    matrix.Dense[E] // params
+	DWx, DWh, DB matrix.Dense[E] // grads
+	x, h, hNext  matrix.Dense[E] // cache
 }
 
-func (l *RNN) Params() []matrix.Matrix      { return []matrix.Matrix{l.Wx, l.Wh, l.B} }
-func (l *RNN) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DWx, l.DWh, l.DB} }
-func (l *RNN) SetParams(p ...matrix.Matrix) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
-func (l *RNN) String() string {
+func (l *RNNOf[E]) Params() []matrix.Dense[E]      { return []matrix.Dense[E]{l.Wx, l.Wh, l.B} }
+func (l *RNNOf[E]) ParamNames() []string           { return []string{"Wx", "Wh", "B"} }
+func (l *RNNOf[E]) Grads() []matrix.Dense[E]       { return []matrix.Dense[E]{l.DWx, l.DWh, l.DB} }
+func (l *RNNOf[E]) SetParams(p ...matrix.Dense[E]) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
+func (l *RNNOf[E]) String() string {
 	a, b := l.Wx.Dim()
 	c, d := l.Wh.Dim()
 	e, f := l.B.Dim()
-	return fmt.Sprintf("%T: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", l, a, b, c, d, e, f, a*b+c*d+e*f)
+	return fmt.Sprintf("%v: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", typeName(l), a, b, c, d, e, f, a*b+c*d+e*f)
 }
 
-func (l *RNN) Forward(x, h matrix.Matrix, _ ...Opts) matrix.Matrix {
+func (l *RNNOf[E]) Forward(x, h matrix.Dense[E], _ ...Opts) matrix.Dense[E] {
 	t := matrix.Dot(h, l.Wh).Add(matrix.Dot(x, l.Wx)).Add(l.B) // h(N, H).Wh(H, H) + x(N, D).Wx(D, H) + b(1, H)
 	hNext := matrix.F(t, activation.Tanh)
 
@@ -31,16 +36,16 @@ func (l *RNN) Forward(x, h matrix.Matrix, _ ...Opts) matrix.Matrix {
 	return l.hNext
 }
 
-func (l *RNN) Backward(dhNext matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+func (l *RNNOf[E]) Backward(dhNext matrix.Dense[E]) (matrix.Dense[E], matrix.Dense[E]) {
 	dt := dhNext.Mul(matrix.F(l.hNext, dTanh)) // dt = dhNext * (1 - hNext**2)
 	dx := matrix.Dot(dt, l.Wx.T())             // dot(dt(N, H), Wx.T(H, D)) -> dx(N, D)
 	dh := matrix.Dot(dt, l.Wh.T())             // dot(dt(N, H), Wh.T(H, H)) -> dh(N, H)
 
-	l.DWx = matrix.Dot(l.x.T(), dt)  // dot(x.T(D, N), dt(N, H)) -> (D, H)
-	l.DWh = matrix.Dot(l.h.T(), dt)  // dot(hPrev.T(H, N), dt(N, H)) -> (H, H)
-	l.DB = matrix.New(dt.SumAxis0()) // sum(dt(N, H), axis=0) -> (1, H)
+	l.DWx = matrix.Dot(l.x.T(), dt)    // dot(x.T(D, N), dt(N, H)) -> (D, H)
+	l.DWh = matrix.Dot(l.h.T(), dt)    // dot(hPrev.T(H, N), dt(N, H)) -> (H, H)
+	l.DB = matrix.NewOf(dt.SumAxis0()) // sum(dt(N, H), axis=0) -> (1, H)
 	return dx, dh
 }
 
 // dtanh returns 1 - a**2
-func dTanh(y float64) float64 { return 1 - y*y }
+func dTanh[T vector.Float](y T) T { return 1 - y*y }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/itsubaki/neu/activation"
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
 // Sigmoid is a layer that performs an element-wise sigmoid.
@@ -28,4 +29,4 @@ func (l *Sigmoid) Backward(dout matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
 }
 
 // dSigmoid returns a * (1.0 - a)
-func dSigmoid(a float64) float64 { return a * (1.0 - a) }
+func dSigmoid[T vector.Float](a T) T { return a * (1.0 - a) }
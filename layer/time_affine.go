@@ -4,43 +4,48 @@ import (
 	"fmt"
 
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
-type TimeAffine struct {
-	W, B   matrix.Matrix // params
-	DW, DB matrix.Matrix // grads
-	layer  []Affine
+type TimeAffine = TimeAffineOf[float64]
+
+// TimeAffineOf is TimeAffine for the parameters of E.
+type TimeAffineOf[E vector.Float] struct {
+	W, B   matrix.Dense[E] // params
+	DW, DB matrix.Dense[E] // grads
+	layer  []AffineOf[E]
 }
 
-func (l *TimeAffine) Params() []matrix.Matrix      { return []matrix.Matrix{l.W, l.B} }
-func (l *TimeAffine) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DW, l.DB} }
-func (l *TimeAffine) SetParams(p ...matrix.Matrix) { l.W, l.B = p[0], p[1] }
-func (l *TimeAffine) SetState(_ ...matrix.Matrix)  {}
-func (l *TimeAffine) ResetState()                  {}
-func (l *TimeAffine) String() string {
+func (l *TimeAffineOf[E]) Params() []matrix.Dense[E]      { return []matrix.Dense[E]{l.W, l.B} }
+func (l *TimeAffineOf[E]) ParamNames() []string           { return []string{"W", "B"} }
+func (l *TimeAffineOf[E]) Grads() []matrix.Dense[E]       { return []matrix.Dense[E]{l.DW, l.DB} }
+func (l *TimeAffineOf[E]) SetParams(p ...matrix.Dense[E]) { l.W, l.B = p[0], p[1] }
+func (l *TimeAffineOf[E]) SetState(_ ...matrix.Dense[E])  {}
+func (l *TimeAffineOf[E]) ResetState()                    {}
+func (l *TimeAffineOf[E]) String() string {
 	a, b := l.W.Dim()
 	c, d := l.B.Dim()
-	return fmt.Sprintf("%T: W(%v, %v), B(%v, %v): %v", l, a, b, c, d, a*b+c*d)
+	return fmt.Sprintf("%v: W(%v, %v), B(%v, %v): %v", typeName(l), a, b, c, d, a*b+c*d)
 }
 
-func (l *TimeAffine) Forward(xs, _ []matrix.Matrix, _ ...Opts) []matrix.Matrix {
+func (l *TimeAffineOf[E]) Forward(xs, _ []matrix.Dense[E], _ ...Opts) []matrix.Dense[E] {
 	T := len(xs)
-	l.layer = make([]Affine, T)
-	out := make([]matrix.Matrix, T)
+	l.layer = make([]AffineOf[E], T)
+	out := make([]matrix.Dense[E], T)
 
 	for t := 0; t < T; t++ {
-		l.layer[t] = Affine{W: l.W, B: l.B}
+		l.layer[t] = AffineOf[E]{W: l.W, B: l.B}
 		out[t] = l.layer[t].Forward(xs[t], nil)
 	}
 
 	return out
 }
 
-func (l *TimeAffine) Backward(dout []matrix.Matrix) []matrix.Matrix {
+func (l *TimeAffineOf[E]) Backward(dout []matrix.Dense[E]) []matrix.Dense[E] {
 	T := len(dout)
-	dxs := make([]matrix.Matrix, T)
-	l.DW = matrix.Zero(1, 1)
-	l.DB = matrix.Zero(1, 1)
+	dxs := make([]matrix.Dense[E], T)
+	l.DW = matrix.ZeroOf[E](1, 1)
+	l.DB = matrix.ZeroOf[E](1, 1)
 
 	for t := 0; t < T; t++ {
 		dxs[t], _ = l.layer[t].Backward(dout[t])
@@ -8,15 +8,35 @@ import (
 )
 
 type TimeAttention struct {
+	Score Scorer // DotScore if nil. The params are shared by the time steps.
 	layer []Attention
+	grads []matrix.Matrix
 }
 
-func (l *TimeAttention) Params() []matrix.Matrix      { return make([]matrix.Matrix, 0) }
-func (l *TimeAttention) Grads() []matrix.Matrix       { return make([]matrix.Matrix, 0) }
-func (l *TimeAttention) SetParams(p ...matrix.Matrix) {}
-func (l *TimeAttention) SetState(_ ...matrix.Matrix)  {}
-func (l *TimeAttention) ResetState()                  {}
-func (l *TimeAttention) String() string               { return fmt.Sprintf("%T", l) }
+func (l *TimeAttention) Params() []matrix.Matrix     { return l.score().Params() }
+func (l *TimeAttention) ParamNames() []string        { return l.score().ParamNames() }
+func (l *TimeAttention) SetState(_ ...matrix.Matrix) {}
+func (l *TimeAttention) ResetState()                 {}
+
+func (l *TimeAttention) Grads() []matrix.Matrix {
+	if l.grads == nil {
+		return l.score().Grads()
+	}
+
+	return l.grads
+}
+
+func (l *TimeAttention) SetParams(p ...matrix.Matrix) {
+	l.score().SetParams(p...)
+}
+
+func (l *TimeAttention) String() string {
+	if _, ok := l.score().(*DotScore); ok {
+		return fmt.Sprintf("%T", l)
+	}
+
+	return fmt.Sprintf("%T: %v", l, l.Score)
+}
 
 func (l *TimeAttention) Forward(hsenc, hsdec []matrix.Matrix) []matrix.Matrix {
 	T := len(hsdec)
@@ -25,7 +45,7 @@ func (l *TimeAttention) Forward(hsenc, hsdec []matrix.Matrix) []matrix.Matrix {
 
 	for t := 0; t < T; t++ {
 		l.layer[t] = Attention{
-			AttentionWeight: &AttentionWeight{Softmax: &Softmax{}},
+			AttentionWeight: &AttentionWeight{Softmax: &Softmax{}, Score: l.score().Clone()},
 			WeightSum:       &WeightSum{},
 		}
 
@@ -37,14 +57,36 @@ func (l *TimeAttention) Forward(hsenc, hsdec []matrix.Matrix) []matrix.Matrix {
 
 func (l *TimeAttention) Backward(dout []matrix.Matrix) ([]matrix.Matrix, []matrix.Matrix) {
 	T := len(dout)
-	dhsenc := tensor.ZeroLike(dout)
+	var dhsenc []matrix.Matrix // the encoder length may differ from T
 	dhsdec := make([]matrix.Matrix, T)
+	l.grads = make([]matrix.Matrix, len(l.Params()))
 
 	for t := 0; t < T; t++ {
 		dhs, dh := l.layer[t].Backward(dout[t])
-		dhsenc = tensor.Add(dhsenc, dhs)
 		dhsdec[t] = dh
+		if t == 0 {
+			dhsenc = dhs
+		} else {
+			dhsenc = tensor.Add(dhsenc, dhs)
+		}
+
+		for i, g := range l.layer[t].Grads() {
+			if l.grads[i] == nil {
+				l.grads[i] = g
+				continue
+			}
+
+			l.grads[i] = l.grads[i].Add(g)
+		}
 	}
 
 	return dhsenc, dhsdec
 }
+
+func (l *TimeAttention) score() Scorer {
+	if l.Score == nil {
+		l.Score = &DotScore{}
+	}
+
+	return l.Score
+}
@@ -55,9 +55,9 @@ func ExampleTimeAttention() {
 
 	// Output:
 	// *layer.TimeAttention
-	// [[[1.1450074365793674e-19 1.4312592989939168e-19 1.7175111614084662e-19] [8 10 12]] [[2 2.5 3] [6 7.5 9]]]
-	// [[[-5.038032728796514e-18 -6.2975409312678295e-18 -7.557049133739145e-18] [8 10 12]] [[2 2.5 3] [6 7.5 9]]]
-	// [[[-5.152533472454451e-18 -6.4406668611672205e-18 -7.728800249879992e-18] [0 0 0]] [[-1.288133361247227e-18 -2.576266722494454e-18 -3.864400083741681e-18] [0 0 0]]]
+	// [[[3.9999999543100615 4.999999954310062 5.999999954310062] [4 5 6]] [[4 5 6] [4 5 6]]]
+	// [[[-2.589096475468231e-07 -5.178192950898677e-07 -7.767289426329121e-07] [4 5 6]] [[5.0000002589096475 7.000000517819294 9.00000077672894] [4 5 6]]]
+	// [[[8.22418879164935e-07 8.224188786652598e-07 8.224188781655846e-07] [0 0 0]] [[-1.288133361247227e-18 -2.576266722494454e-18 -3.864400083741681e-18] [0 0 0]]]
 }
 
 func ExampleTimeAttention_Params() {
@@ -79,3 +79,36 @@ func ExampleTimeAttention_SetState() {
 
 	// Output:
 }
+
+func ExampleTimeAttention_score() {
+	at := &layer.TimeAttention{Score: &layer.GeneralScore{W: matrix.Identity(3)}}
+	fmt.Println(at)
+	fmt.Println(at.ParamNames())
+
+	// (T, N, H) = (3, 1, 3)
+	hsenc := []matrix.Matrix{{{1, 0, 0}}, {{0, 1, 0}}, {{0, 0, 1}}}
+
+	// (T, N, H) = (2, 1, 3)
+	hsdec := []matrix.Matrix{{{1, 0, 0}}, {{0, 1, 0}}}
+	for _, c := range at.Forward(hsenc, hsdec) {
+		fmt.Printf("%.4f\n", c)
+	}
+
+	dhsenc, dhsdec := at.Backward([]matrix.Matrix{{{1, 0, 0}}, {{0, 1, 0}}})
+	fmt.Println(len(dhsenc), len(dhsdec))
+
+	// the sum of the grads of the time steps
+	for _, r := range at.Grads()[0] {
+		fmt.Printf("%.4f\n", r)
+	}
+
+	// Output:
+	// *layer.TimeAttention: *layer.GeneralScore: W(3, 3): 9
+	// [W]
+	// [[0.5761 0.2119 0.2119]]
+	// [[0.2119 0.5761 0.2119]]
+	// 3 2
+	// [0.2442 -0.1221 -0.1221]
+	// [-0.1221 0.2442 -0.1221]
+	// [0.0000 0.0000 0.0000]
+}
@@ -16,6 +16,10 @@ func (l *TimeBiLSTM) Params() []matrix.Matrix {
 	return []matrix.Matrix{l.F.Wx, l.F.Wh, l.F.B, l.B.Wx, l.B.Wh, l.B.B}
 }
 
+func (l *TimeBiLSTM) ParamNames() []string {
+	return []string{"F.Wx", "F.Wh", "F.B", "B.Wx", "B.Wh", "B.B"}
+}
+
 func (l *TimeBiLSTM) Grads() []matrix.Matrix {
 	return []matrix.Matrix{l.F.DWx, l.F.DWh, l.F.DB, l.B.DWx, l.B.DWh, l.B.DB}
 }
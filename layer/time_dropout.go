@@ -6,6 +6,9 @@ import (
 	"github.com/itsubaki/neu/math/matrix"
 )
 
+// TimeDropout is a dropout layer for the time series.
+// The mask is shared across the time steps, like the variational dropout.
+// It drops the inputs or the outputs of a recurrent layer, not the recurrent connections.
 type TimeDropout struct {
 	Ratio float64
 	mask  matrix.Matrix
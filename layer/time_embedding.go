@@ -4,46 +4,61 @@ import (
 	"fmt"
 
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
-type TimeEmbedding struct {
-	W     matrix.Matrix // params
-	DW    matrix.Matrix // grads
-	layer []Embedding
+type TimeEmbedding = TimeEmbeddingOf[float64]
+
+// TimeEmbeddingOf is TimeEmbedding for the parameters of E.
+type TimeEmbeddingOf[E vector.Float] struct {
+	W     matrix.Dense[E] // params
+	DW    matrix.Dense[E] // grads
+	dW    *matrix.SparseOf[E]
+	tied  matrix.Dense[E] // the gradient of the tied weight, e.g. TimeTiedAffine
+	layer []EmbeddingOf[E]
 }
 
-func (l *TimeEmbedding) Params() []matrix.Matrix      { return []matrix.Matrix{l.W} }
-func (l *TimeEmbedding) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DW} }
-func (l *TimeEmbedding) SetParams(p ...matrix.Matrix) { l.W = p[0] }
-func (l *TimeEmbedding) SetState(_ ...matrix.Matrix)  {}
-func (l *TimeEmbedding) ResetState()                  {}
-func (l *TimeEmbedding) String() string {
+func (l *TimeEmbeddingOf[E]) Params() []matrix.Dense[E]          { return []matrix.Dense[E]{l.W} }
+func (l *TimeEmbeddingOf[E]) ParamNames() []string               { return []string{"W"} }
+func (l *TimeEmbeddingOf[E]) Grads() []matrix.Dense[E]           { return []matrix.Dense[E]{l.DW} }
+func (l *TimeEmbeddingOf[E]) SparseGrads() []*matrix.SparseOf[E] { return []*matrix.SparseOf[E]{l.dW} }
+func (l *TimeEmbeddingOf[E]) SetParams(p ...matrix.Dense[E])     { l.W = p[0] }
+func (l *TimeEmbeddingOf[E]) SetState(_ ...matrix.Dense[E])      {}
+func (l *TimeEmbeddingOf[E]) ResetState()                        {}
+func (l *TimeEmbeddingOf[E]) String() string {
 	a, b := l.W.Dim()
-	return fmt.Sprintf("%T: W(%v, %v): %v", l, a, b, a*b)
+	return fmt.Sprintf("%v: W(%v, %v): %v", typeName(l), a, b, a*b)
 }
 
-func (l *TimeEmbedding) Forward(xs, _ []matrix.Matrix, _ ...Opts) []matrix.Matrix {
-	T := len(xs)                    // (7, 128, 1)
-	l.layer = make([]Embedding, T)  //
-	out := make([]matrix.Matrix, T) // (7, 128, 16)
+func (l *TimeEmbeddingOf[E]) Forward(xs, _ []matrix.Dense[E], _ ...Opts) []matrix.Dense[E] {
+	T := len(xs)                        // (7, 128, 1)
+	l.layer = make([]EmbeddingOf[E], T) //
+	out := make([]matrix.Dense[E], T)   // (7, 128, 16)
 
 	for t := 0; t < T; t++ {
-		l.layer[t] = Embedding{W: l.W}
+		l.layer[t] = EmbeddingOf[E]{W: l.W}
 		out[t] = l.layer[t].Forward(xs[t], nil)
 	}
 
 	return out
 }
 
-func (l *TimeEmbedding) Backward(dout []matrix.Matrix) []matrix.Matrix {
+func (l *TimeEmbeddingOf[E]) Backward(dout []matrix.Dense[E]) []matrix.Dense[E] {
 	T := len(dout) // (Time, N, H)
 
-	grad := matrix.Zero(1, 1)
+	grad := matrix.NewSparse[E](len(l.W), len(l.W[0]), nil, nil)
 	for t := 0; t < T; t++ {
 		l.layer[t].Backward(dout[t])
-		grad = l.layer[t].DW.Add(grad) // Broadcast
+		grad = grad.Add(l.layer[t].dW)
+	}
+
+	l.dW = grad
+	l.DW = grad.View()
+
+	if l.tied != nil {
+		// the gradient is dense if the weight is tied
+		l.dW, l.DW, l.tied = nil, l.DW.Add(l.tied), nil
 	}
 
-	l.DW = grad
 	return nil
 }
@@ -74,3 +74,30 @@ func ExampleTimeEmbedding_SetState() {
 
 	// Output:
 }
+
+func ExampleTimeEmbeddingOf() {
+	embedding := &layer.TimeEmbeddingOf[float32]{
+		W: matrix.NewOf(
+			[]float32{0.1, 0.2},
+			[]float32{0.3, 0.4},
+			[]float32{0.5, 0.6},
+		),
+	}
+	fmt.Println(embedding)
+
+	// forward
+	xs := []matrix.Dense[float32]{{{0}, {2}}}
+	ys := embedding.Forward(xs, nil)
+	fmt.Printf("%T %v\n", ys[0][0][0], ys)
+
+	// backward
+	embedding.Backward([]matrix.Dense[float32]{{{1, 1}, {2, 2}}})
+	fmt.Println(embedding.Grads())
+	fmt.Println(embedding.SparseGrads()[0].Index)
+
+	// Output:
+	// *layer.TimeEmbeddingOf[float32]: W(3, 2): 6
+	// float32 [[[0.1 0.2] [0.5 0.6]]]
+	// [[[1 1] [0 0] [2 2]]]
+	// [0 2]
+}
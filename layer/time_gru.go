@@ -4,40 +4,46 @@ import (
 	"fmt"
 
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
-type TimeGRU struct {
-	Wx, Wh, B    matrix.Matrix // params
-	DWx, DWh, DB matrix.Matrix // grads
-	h, dh        matrix.Matrix // hidden state
-	layer        []GRU
+type TimeGRU = TimeGRUOf[float64]
+
+// TimeGRUOf is TimeGRU for the parameters of E.
+type TimeGRUOf[E vector.Float] struct {
+	Wx, Wh, B    matrix.Dense[E] // params
+	DWx, DWh, DB matrix.Dense[E] // grads
+	h, dh        matrix.Dense[E] // hidden state
+	layer        []GRUOf[E]
 	Stateful     bool
 }
 
-func (l *TimeGRU) DH() matrix.Matrix            { return l.dh }
-func (l *TimeGRU) Params() []matrix.Matrix      { return []matrix.Matrix{l.Wx, l.Wh, l.B} }
-func (l *TimeGRU) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DWx, l.DWh, l.DB} }
-func (l *TimeGRU) SetParams(p ...matrix.Matrix) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
-func (l *TimeGRU) SetState(h ...matrix.Matrix)  { l.h = h[0] }
-func (l *TimeGRU) ResetState()                  { l.h = matrix.New() }
-func (l *TimeGRU) String() string {
+func (l *TimeGRUOf[E]) DH() matrix.Dense[E]            { return l.dh }
+func (l *TimeGRUOf[E]) Params() []matrix.Dense[E]      { return []matrix.Dense[E]{l.Wx, l.Wh, l.B} }
+func (l *TimeGRUOf[E]) ParamNames() []string           { return []string{"Wx", "Wh", "B"} }
+func (l *TimeGRUOf[E]) Grads() []matrix.Dense[E]       { return []matrix.Dense[E]{l.DWx, l.DWh, l.DB} }
+func (l *TimeGRUOf[E]) SetParams(p ...matrix.Dense[E]) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
+func (l *TimeGRUOf[E]) SetState(h ...matrix.Dense[E])  { l.h = h[0] }
+func (l *TimeGRUOf[E]) State() []matrix.Dense[E]       { return []matrix.Dense[E]{l.h} }
+func (l *TimeGRUOf[E]) ResetState()                    { l.h = matrix.NewOf[E]() }
+func (l *TimeGRUOf[E]) String() string {
 	a, b := l.Wx.Dim()
 	c, d := l.Wh.Dim()
 	e, f := l.B.Dim()
-	return fmt.Sprintf("%T: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", l, a, b, c, d, e, f, a*b+c*d+e*f)
+	return fmt.Sprintf("%v: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", typeName(l), a, b, c, d, e, f, a*b+c*d+e*f)
 }
 
-func (l *TimeGRU) Forward(xs, _ []matrix.Matrix, _ ...Opts) []matrix.Matrix {
+func (l *TimeGRUOf[E]) Forward(xs, _ []matrix.Dense[E], _ ...Opts) []matrix.Dense[E] {
 	T, N, H := len(xs), len(xs[0]), len(l.Wh)
-	l.layer = make([]GRU, T)
-	hs := make([]matrix.Matrix, T)
+	l.layer = make([]GRUOf[E], T)
+	hs := make([]matrix.Dense[E], T)
 
 	if !l.Stateful || len(l.h) == 0 {
-		l.h = matrix.Zero(N, H)
+		l.h = matrix.ZeroOf[E](N, H)
 	}
 
 	for t := 0; t < T; t++ {
-		l.layer[t] = GRU{Wx: l.Wx, Wh: l.Wh, B: l.B}
+		l.layer[t] = GRUOf[E]{Wx: l.Wx, Wh: l.Wh, B: l.B}
 		l.h = l.layer[t].Forward(xs[t], l.h)
 		hs[t] = l.h
 	}
@@ -45,15 +51,15 @@ func (l *TimeGRU) Forward(xs, _ []matrix.Matrix, _ ...Opts) []matrix.Matrix {
 	return hs
 }
 
-func (l *TimeGRU) Backward(dhs []matrix.Matrix) []matrix.Matrix {
+func (l *TimeGRUOf[E]) Backward(dhs []matrix.Dense[E]) []matrix.Dense[E] {
 	T, N, H := len(dhs), len(dhs[0]), len(dhs[0][0])
-	dxs := make([]matrix.Matrix, T)
-	dh := matrix.Zero(N, H)
+	dxs := make([]matrix.Dense[E], T)
+	dh := matrix.ZeroOf[E](N, H)
 
-	grads := []matrix.Matrix{
-		matrix.Zero(1, 1), // DWx(D, 3H)
-		matrix.Zero(1, 1), // DWh(H, 3H)
-		matrix.Zero(1, 1), // DB(1, 3H)
+	grads := []matrix.Dense[E]{
+		matrix.ZeroOf[E](1, 1), // DWx(D, 3H)
+		matrix.ZeroOf[E](1, 1), // DWh(H, 3H)
+		matrix.ZeroOf[E](1, 1), // DB(1, 3H)
 	}
 
 	for t := T - 1; t > -1; t-- {
@@ -62,7 +62,7 @@ func ExampleTimeGRU() {
 	// Output:
 	// *layer.TimeGRU: Wx(3, 3), Wh(1, 3), B(1, 3): 15
 	// 2 1: [[0.09171084600490446] [0.18295102825645382]]
-	// 2 3: [[0.019946542181883273 0.019946542181883273 0.019946542181883273] [0.05601681471011035 0.05601681471011035 0.05601681471011035]]
+	// 2 3: [[0.015404708365039137 0.015404708365039137 0.015404708365039137] [0.04459241783399783 0.04459241783399783 0.04459241783399783]]
 	// [[0.056873464301738536] [0.16710562310833357]]
 }
 
@@ -0,0 +1,54 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// TimeLayerNorm is a layer that performs layer normalization at each time step.
+type TimeLayerNorm struct {
+	Gamma, Beta   matrix.Matrix // params
+	DGamma, DBeta matrix.Matrix // grads
+	layer         []LayerNorm
+}
+
+func (l *TimeLayerNorm) Params() []matrix.Matrix      { return []matrix.Matrix{l.Gamma, l.Beta} }
+func (l *TimeLayerNorm) ParamNames() []string         { return []string{"Gamma", "Beta"} }
+func (l *TimeLayerNorm) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DGamma, l.DBeta} }
+func (l *TimeLayerNorm) SetParams(p ...matrix.Matrix) { l.Gamma, l.Beta = p[0], p[1] }
+func (l *TimeLayerNorm) SetState(_ ...matrix.Matrix)  {}
+func (l *TimeLayerNorm) ResetState()                  {}
+func (l *TimeLayerNorm) String() string {
+	a, b := l.Gamma.Dim()
+	c, d := l.Beta.Dim()
+	return fmt.Sprintf("%T: G(%v, %v), B(%v, %v): %v", l, a, b, c, d, a*b+c*d)
+}
+
+func (l *TimeLayerNorm) Forward(xs, _ []matrix.Matrix, _ ...Opts) []matrix.Matrix {
+	T := len(xs)
+	l.layer = make([]LayerNorm, T)
+	out := make([]matrix.Matrix, T)
+
+	for t := 0; t < T; t++ {
+		l.layer[t] = LayerNorm{Gamma: l.Gamma, Beta: l.Beta}
+		out[t] = l.layer[t].Forward(xs[t], nil)
+	}
+
+	return out
+}
+
+func (l *TimeLayerNorm) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	T := len(dout)
+	dxs := make([]matrix.Matrix, T)
+	l.DGamma = matrix.Zero(1, 1)
+	l.DBeta = matrix.Zero(1, 1)
+
+	for t := 0; t < T; t++ {
+		dxs[t], _ = l.layer[t].Backward(dout[t])
+		l.DGamma = l.layer[t].DGamma.Add(l.DGamma) // Broadcast
+		l.DBeta = l.layer[t].DBeta.Add(l.DBeta)    // Broadcast
+	}
+
+	return dxs
+}
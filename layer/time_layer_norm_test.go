@@ -0,0 +1,55 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+func ExampleTimeLayerNorm() {
+	n := &layer.TimeLayerNorm{
+		Gamma: matrix.New([]float64{1, 2, 1}),
+		Beta:  matrix.New([]float64{0, 1, 0}),
+	}
+	fmt.Println(n)
+
+	// forward
+	xs := []matrix.Matrix{{{1, 3, 2}}, {{4, 2, 1}}}
+	for _, y := range n.Forward(xs, nil) {
+		fmt.Printf("%.4f\n", y)
+	}
+
+	// backward
+	for _, dx := range n.Backward([]matrix.Matrix{{{1, 0, 0}}, {{0, 1, 0}}}) {
+		fmt.Printf("%.4f\n", dx)
+	}
+
+	// grads
+	for _, g := range n.Grads() {
+		fmt.Printf("%.4f\n", g)
+	}
+
+	// Output:
+	// *layer.TimeLayerNorm: G(1, 3), B(1, 3): 6
+	// [[-1.2247 3.4495 0.0000]]
+	// [[1.3363 0.4655 -1.0690]]
+	// [[0.2041 0.2041 -0.4082]]
+	// [[-0.3436 1.0309 -0.6872]]
+	// [[-1.2247 -0.2673 0.0000]]
+	// [[1.0000 1.0000 0.0000]]
+}
+
+func ExampleTimeLayerNorm_Params() {
+	n := &layer.TimeLayerNorm{}
+	n.SetParams(make([]matrix.Matrix, 2)...)
+	n.SetState()
+	n.ResetState()
+
+	fmt.Println(n.Params())
+	fmt.Println(n.Grads())
+
+	// Output:
+	// [[] []]
+	// [[] []]
+}
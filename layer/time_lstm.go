@@ -4,66 +4,72 @@ import (
 	"fmt"
 
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
-type TimeLSTM struct {
-	Wx, Wh, B    matrix.Matrix // params
-	DWx, DWh, DB matrix.Matrix // grads
-	h, dh        matrix.Matrix // hidden state
-	c            matrix.Matrix // cell state
-	layer        []LSTM
+type TimeLSTM = TimeLSTMOf[float64]
+
+// TimeLSTMOf is TimeLSTM for the parameters of E.
+type TimeLSTMOf[E vector.Float] struct {
+	Wx, Wh, B    matrix.Dense[E] // params
+	DWx, DWh, DB matrix.Dense[E] // grads
+	h, dh        matrix.Dense[E] // hidden state
+	c            matrix.Dense[E] // cell state
+	layer        []LSTMOf[E]
 	Stateful     bool
 }
 
-func (l *TimeLSTM) DH() matrix.Matrix            { return l.dh }
-func (l *TimeLSTM) Params() []matrix.Matrix      { return []matrix.Matrix{l.Wx, l.Wh, l.B} }
-func (l *TimeLSTM) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DWx, l.DWh, l.DB} }
-func (l *TimeLSTM) SetParams(p ...matrix.Matrix) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
-func (l *TimeLSTM) SetState(s ...matrix.Matrix) {
+func (l *TimeLSTMOf[E]) DH() matrix.Dense[E]            { return l.dh }
+func (l *TimeLSTMOf[E]) Params() []matrix.Dense[E]      { return []matrix.Dense[E]{l.Wx, l.Wh, l.B} }
+func (l *TimeLSTMOf[E]) ParamNames() []string           { return []string{"Wx", "Wh", "B"} }
+func (l *TimeLSTMOf[E]) Grads() []matrix.Dense[E]       { return []matrix.Dense[E]{l.DWx, l.DWh, l.DB} }
+func (l *TimeLSTMOf[E]) SetParams(p ...matrix.Dense[E]) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
+func (l *TimeLSTMOf[E]) SetState(s ...matrix.Dense[E]) {
 	if len(s) == 1 {
-		s = append(s, matrix.New())
+		s = append(s, matrix.NewOf[E]())
 	}
 	l.h, l.c = s[0], s[1]
 }
-func (l *TimeLSTM) ResetState() { l.h, l.c = matrix.New(), matrix.New() }
-func (l *TimeLSTM) String() string {
+func (l *TimeLSTMOf[E]) State() []matrix.Dense[E] { return []matrix.Dense[E]{l.h, l.c} }
+func (l *TimeLSTMOf[E]) ResetState()              { l.h, l.c = matrix.NewOf[E](), matrix.NewOf[E]() }
+func (l *TimeLSTMOf[E]) String() string {
 	a, b := l.Wx.Dim()
 	c, d := l.Wh.Dim()
 	e, f := l.B.Dim()
-	return fmt.Sprintf("%T: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", l, a, b, c, d, e, f, a*b+c*d+e*f)
+	return fmt.Sprintf("%v: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", typeName(l), a, b, c, d, e, f, a*b+c*d+e*f)
 }
 
-func (l *TimeLSTM) Forward(xs, _ []matrix.Matrix, _ ...Opts) []matrix.Matrix {
+func (l *TimeLSTMOf[E]) Forward(xs, _ []matrix.Dense[E], _ ...Opts) []matrix.Dense[E] {
 	T, N, H := len(xs), len(xs[0]), len(l.Wh) // 7, 128, 128
-	l.layer = make([]LSTM, T)                 //
-	hs := make([]matrix.Matrix, T)            // (7, 128, 128)
+	l.layer = make([]LSTMOf[E], T)            //
+	hs := make([]matrix.Dense[E], T)          // (7, 128, 128)
 
 	if !l.Stateful || len(l.h) == 0 {
-		l.h = matrix.Zero(N, H) // (128, 128)
+		l.h = matrix.ZeroOf[E](N, H) // (128, 128)
 	}
 	if !l.Stateful || len(l.c) == 0 {
-		l.c = matrix.Zero(N, H) // (128, 128)
+		l.c = matrix.ZeroOf[E](N, H) // (128, 128)
 	}
 
 	for t := 0; t < T; t++ {
-		l.layer[t] = LSTM{Wx: l.Wx, Wh: l.Wh, B: l.B}  // Wx(D, 4H), Wh(H, 4H), B(1, 4H)
-		l.h, l.c = l.layer[t].Forward(xs[t], l.h, l.c) // h(128, 128), c(128, 128)
+		l.layer[t] = LSTMOf[E]{Wx: l.Wx, Wh: l.Wh, B: l.B} // Wx(D, 4H), Wh(H, 4H), B(1, 4H)
+		l.h, l.c = l.layer[t].Forward(xs[t], l.h, l.c)     // h(128, 128), c(128, 128)
 		hs[t] = l.h
 	}
 
 	return hs
 }
 
-func (l *TimeLSTM) Backward(dhs []matrix.Matrix) []matrix.Matrix {
+func (l *TimeLSTMOf[E]) Backward(dhs []matrix.Dense[E]) []matrix.Dense[E] {
 	T, N, H := len(dhs), len(dhs[0]), len(dhs[0][0]) // dhs(Time, N, H)
-	dxs := make([]matrix.Matrix, T)                  // dxs(Time, N, D)
-	dh := matrix.Zero(N, H)                          // dh(N, H)
-	dc := matrix.Zero(N, H)                          // dc(N, H)
+	dxs := make([]matrix.Dense[E], T)                // dxs(Time, N, D)
+	dh := matrix.ZeroOf[E](N, H)                     // dh(N, H)
+	dc := matrix.ZeroOf[E](N, H)                     // dc(N, H)
 
-	grads := []matrix.Matrix{
-		matrix.Zero(1, 1), // DWx(D, 4H)
-		matrix.Zero(1, 1), // DWh(H, 4H)
-		matrix.Zero(1, 1), // DB(1, 4H)
+	grads := []matrix.Dense[E]{
+		matrix.ZeroOf[E](1, 1), // DWx(D, 4H)
+		matrix.ZeroOf[E](1, 1), // DWh(H, 4H)
+		matrix.ZeroOf[E](1, 1), // DB(1, 4H)
 	}
 
 	for t := T - 1; t > -1; t-- {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/itsubaki/neu/layer"
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
 )
 
 func ExampleTimeLSTM() {
@@ -86,3 +87,44 @@ func ExampleTimeLSTM_SetState() {
 
 	// Output:
 }
+
+func ExampleTimeLSTMOf() {
+	s := rand.Const(1)
+	D, H, V := 2, 3, 4
+
+	// float32
+	embedding := &layer.TimeEmbeddingOf[float32]{W: matrix.To[float32](matrix.Randn(V, D, s))}
+	lstm := &layer.TimeLSTMOf[float32]{
+		Wx: matrix.To[float32](matrix.Randn(D, 4*H, s)),
+		Wh: matrix.To[float32](matrix.Randn(H, 4*H, s)),
+		B:  matrix.ZeroOf[float32](1, 4*H),
+	}
+	affine := &layer.TimeAffineOf[float32]{
+		W: matrix.To[float32](matrix.Randn(H, V, s)),
+		B: matrix.ZeroOf[float32](1, V),
+	}
+	fmt.Println(lstm)
+	fmt.Println(affine)
+
+	// forward
+	xs := []matrix.Dense[float32]{{{0}, {1}}, {{2}, {3}}}
+	ys := affine.Forward(lstm.Forward(embedding.Forward(xs, nil), nil), nil)
+	fmt.Println(len(ys))
+	fmt.Println(ys[0].Dim())
+
+	// backward
+	dout := []matrix.Dense[float32]{matrix.To[float32](matrix.One(2, V)), matrix.To[float32](matrix.One(2, V))}
+	embedding.Backward(lstm.Backward(affine.Backward(dout)))
+	for _, g := range lstm.Grads() {
+		fmt.Printf("%T %.4f\n", g[0][0], g.Sum())
+	}
+
+	// Output:
+	// *layer.TimeLSTMOf[float32]: Wx(2, 12), Wh(3, 12), B(1, 12): 72
+	// *layer.TimeAffineOf[float32]: W(3, 4), B(1, 4): 16
+	// 2
+	// 2 4
+	// float32 2.4993
+	// float32 -0.4938
+	// float32 -3.4104
+}
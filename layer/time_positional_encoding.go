@@ -0,0 +1,93 @@
+package layer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// TimePositionalEncoding is a layer that adds W[t] to the input at the time t.
+// W is (max length, H). It is learned if Learned is true, or fixed, e.g. Sinusoidal.
+type TimePositionalEncoding struct {
+	W       matrix.Matrix // params if Learned
+	DW      matrix.Matrix // grads if Learned
+	Learned bool
+}
+
+func (l *TimePositionalEncoding) Params() []matrix.Matrix {
+	if !l.Learned {
+		return make([]matrix.Matrix, 0)
+	}
+
+	return []matrix.Matrix{l.W}
+}
+
+func (l *TimePositionalEncoding) ParamNames() []string {
+	if !l.Learned {
+		return make([]string, 0)
+	}
+
+	return []string{"W"}
+}
+
+func (l *TimePositionalEncoding) Grads() []matrix.Matrix {
+	if !l.Learned {
+		return make([]matrix.Matrix, 0)
+	}
+
+	return []matrix.Matrix{l.DW}
+}
+
+func (l *TimePositionalEncoding) SetParams(p ...matrix.Matrix) {
+	if !l.Learned {
+		return
+	}
+
+	l.W = p[0]
+}
+
+func (l *TimePositionalEncoding) SetState(_ ...matrix.Matrix) {}
+func (l *TimePositionalEncoding) ResetState()                 {}
+
+func (l *TimePositionalEncoding) String() string {
+	a, b := l.W.Dim()
+	return fmt.Sprintf("%T: W(%v, %v), Learned(%v)", l, a, b, l.Learned)
+}
+
+func (l *TimePositionalEncoding) Forward(xs, _ []matrix.Matrix, _ ...Opts) []matrix.Matrix {
+	out := make([]matrix.Matrix, len(xs))
+	for t := range xs {
+		out[t] = xs[t].Add(l.W[t : t+1]) // Broadcast
+	}
+
+	return out
+}
+
+func (l *TimePositionalEncoding) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	if l.Learned {
+		l.DW = matrix.ZeroLike(l.W)
+		for t := range dout {
+			l.DW[t] = dout[t].SumAxis0()
+		}
+	}
+
+	return dout
+}
+
+// Sinusoidal returns the sinusoidal positional encoding (T, H).
+// PE(t, 2i) = sin(t / 10000^(2i/H)), PE(t, 2i+1) = cos(t / 10000^(2i/H)).
+func Sinusoidal(T, H int) matrix.Matrix {
+	out := matrix.Zero(T, H)
+	for t := 0; t < T; t++ {
+		for i := 0; i < H; i += 2 {
+			a := float64(t) / math.Pow(10000, float64(i)/float64(H))
+			out[t][i] = math.Sin(a)
+			if i+1 < H {
+				out[t][i+1] = math.Cos(a)
+			}
+		}
+	}
+
+	return out
+}
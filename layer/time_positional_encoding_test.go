@@ -0,0 +1,70 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+func ExampleSinusoidal() {
+	for _, r := range layer.Sinusoidal(3, 4) {
+		fmt.Printf("%.4f\n", r)
+	}
+
+	// Output:
+	// [0.0000 1.0000 0.0000 1.0000]
+	// [0.8415 0.5403 0.0100 1.0000]
+	// [0.9093 -0.4161 0.0200 0.9998]
+}
+
+func ExampleTimePositionalEncoding() {
+	l := &layer.TimePositionalEncoding{W: layer.Sinusoidal(3, 4)}
+	fmt.Println(l)
+
+	xs := []matrix.Matrix{{{1, 1, 1, 1}, {0, 0, 0, 0}}, {{1, 1, 1, 1}, {0, 0, 0, 0}}}
+	for _, y := range l.Forward(xs, nil) {
+		fmt.Printf("%.4f\n", y)
+	}
+
+	fmt.Println(l.Backward([]matrix.Matrix{{{1, 2, 3, 4}}}))
+	fmt.Println(l.Params(), l.Grads(), l.ParamNames())
+
+	// Output:
+	// *layer.TimePositionalEncoding: W(3, 4), Learned(false)
+	// [[1.0000 2.0000 1.0000 2.0000] [0.0000 1.0000 0.0000 1.0000]]
+	// [[1.8415 1.5403 1.0100 2.0000] [0.8415 0.5403 0.0100 1.0000]]
+	// [[[1 2 3 4]]]
+	// [] [] []
+}
+
+func ExampleTimePositionalEncoding_learned() {
+	l := &layer.TimePositionalEncoding{W: matrix.Zero(3, 2), Learned: true}
+	fmt.Println(l)
+
+	xs := []matrix.Matrix{{{1, 2}, {3, 4}}, {{5, 6}, {7, 8}}}
+	l.Forward(xs, nil)
+	l.Backward(xs)
+
+	fmt.Println(l.ParamNames())
+	fmt.Println(l.Grads())
+
+	// Output:
+	// *layer.TimePositionalEncoding: W(3, 2), Learned(true)
+	// [W]
+	// [[[4 6] [12 14] [0 0]]]
+}
+
+func ExampleTimePositionalEncoding_Params() {
+	l := &layer.TimePositionalEncoding{Learned: true}
+	l.SetParams(make([]matrix.Matrix, 1)...)
+	l.SetState()
+	l.ResetState()
+
+	fmt.Println(l.Params())
+	fmt.Println(l.Grads())
+
+	// Output:
+	// [[]]
+	// [[]]
+}
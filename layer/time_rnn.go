@@ -4,39 +4,45 @@ import (
 	"fmt"
 
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
-type TimeRNN struct {
-	Wx, Wh, B    matrix.Matrix // params
-	DWx, DWh, DB matrix.Matrix // grads
-	h, dh        matrix.Matrix // hidden state
-	layer        []RNN
+type TimeRNN = TimeRNNOf[float64]
+
+// TimeRNNOf is TimeRNN for the parameters of E.
+type TimeRNNOf[E vector.Float] struct {
+	Wx, Wh, B    matrix.Dense[E] // params
+	DWx, DWh, DB matrix.Dense[E] // grads
+	h, dh        matrix.Dense[E] // hidden state
+	layer        []RNNOf[E]
 	Stateful     bool
 }
 
-func (l *TimeRNN) Params() []matrix.Matrix      { return []matrix.Matrix{l.Wx, l.Wh, l.B} }
-func (l *TimeRNN) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DWx, l.DWh, l.DB} }
-func (l *TimeRNN) SetParams(p ...matrix.Matrix) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
-func (l *TimeRNN) SetState(h ...matrix.Matrix)  { l.h = h[0] }
-func (l *TimeRNN) ResetState()                  { l.h = matrix.New() }
-func (l *TimeRNN) String() string {
+func (l *TimeRNNOf[E]) Params() []matrix.Dense[E]      { return []matrix.Dense[E]{l.Wx, l.Wh, l.B} }
+func (l *TimeRNNOf[E]) ParamNames() []string           { return []string{"Wx", "Wh", "B"} }
+func (l *TimeRNNOf[E]) Grads() []matrix.Dense[E]       { return []matrix.Dense[E]{l.DWx, l.DWh, l.DB} }
+func (l *TimeRNNOf[E]) SetParams(p ...matrix.Dense[E]) { l.Wx, l.Wh, l.B = p[0], p[1], p[2] }
+func (l *TimeRNNOf[E]) SetState(h ...matrix.Dense[E])  { l.h = h[0] }
+func (l *TimeRNNOf[E]) State() []matrix.Dense[E]       { return []matrix.Dense[E]{l.h} }
+func (l *TimeRNNOf[E]) ResetState()                    { l.h = matrix.NewOf[E]() }
+func (l *TimeRNNOf[E]) String() string {
 	a, b := l.Wx.Dim()
 	c, d := l.Wh.Dim()
 	e, f := l.B.Dim()
-	return fmt.Sprintf("%T: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", l, a, b, c, d, e, f, a*b+c*d+e*f)
+	return fmt.Sprintf("%v: Wx(%v, %v), Wh(%v, %v), B(%v, %v): %v", typeName(l), a, b, c, d, e, f, a*b+c*d+e*f)
 }
 
-func (l *TimeRNN) Forward(xs, _ []matrix.Matrix, _ ...Opts) []matrix.Matrix {
+func (l *TimeRNNOf[E]) Forward(xs, _ []matrix.Dense[E], _ ...Opts) []matrix.Dense[E] {
 	T, N, H := len(xs), len(xs[0]), len(l.Wx[0]) // xs(Time, N, D), Wx(D, H)
-	l.layer = make([]RNN, T)
-	hs := make([]matrix.Matrix, T)
+	l.layer = make([]RNNOf[E], T)
+	hs := make([]matrix.Dense[E], T)
 
 	if !l.Stateful || len(l.h) == 0 {
-		l.h = matrix.Zero(N, H)
+		l.h = matrix.ZeroOf[E](N, H)
 	}
 
 	for t := 0; t < T; t++ {
-		l.layer[t] = RNN{Wx: l.Wx, Wh: l.Wh, B: l.B}
+		l.layer[t] = RNNOf[E]{Wx: l.Wx, Wh: l.Wh, B: l.B}
 		l.h = l.layer[t].Forward(xs[t], l.h)
 		hs[t] = l.h
 	}
@@ -44,15 +50,15 @@ func (l *TimeRNN) Forward(xs, _ []matrix.Matrix, _ ...Opts) []matrix.Matrix {
 	return hs
 }
 
-func (l *TimeRNN) Backward(dhs []matrix.Matrix) []matrix.Matrix {
+func (l *TimeRNNOf[E]) Backward(dhs []matrix.Dense[E]) []matrix.Dense[E] {
 	T, N, H := len(dhs), len(dhs[0]), len(dhs[0][0]) // dhs(Time, N, H)
-	dxs := make([]matrix.Matrix, T)                  // dxs(Time, N, D)
-	dh := matrix.Zero(N, H)                          // dh(N, H)
+	dxs := make([]matrix.Dense[E], T)                // dxs(Time, N, D)
+	dh := matrix.ZeroOf[E](N, H)                     // dh(N, H)
 
-	grads := []matrix.Matrix{
-		matrix.Zero(1, 1), // DWx(D, H)
-		matrix.Zero(1, 1), // DWh(H, H)
-		matrix.Zero(1, 1), // DB(1, H)
+	grads := []matrix.Dense[E]{
+		matrix.ZeroOf[E](1, 1), // DWx(D, H)
+		matrix.ZeroOf[E](1, 1), // DWh(H, H)
+		matrix.ZeroOf[E](1, 1), // DB(1, H)
 	}
 
 	for t := T - 1; t > -1; t-- {
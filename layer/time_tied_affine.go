@@ -0,0 +1,40 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// TimeTiedAffine is a TimeAffine whose weight is the transpose of the weight of Embedding.
+// The gradient of the weight is added to the gradient of Embedding in its Backward, which follows the Backward of this layer.
+type TimeTiedAffine struct {
+	Embedding *TimeEmbedding
+	B         matrix.Matrix // params
+	DB        matrix.Matrix // grads
+	affine    TimeAffine
+}
+
+func (l *TimeTiedAffine) Params() []matrix.Matrix      { return []matrix.Matrix{l.B} }
+func (l *TimeTiedAffine) ParamNames() []string         { return []string{"B"} }
+func (l *TimeTiedAffine) Grads() []matrix.Matrix       { return []matrix.Matrix{l.DB} }
+func (l *TimeTiedAffine) SetParams(p ...matrix.Matrix) { l.B = p[0] }
+func (l *TimeTiedAffine) SetState(_ ...matrix.Matrix)  {}
+func (l *TimeTiedAffine) ResetState()                  {}
+func (l *TimeTiedAffine) String() string {
+	a, b := l.Embedding.W.Dim()
+	c, d := l.B.Dim()
+	return fmt.Sprintf("%T: W(%v, %v), B(%v, %v): %v", l, b, a, c, d, c*d)
+}
+
+func (l *TimeTiedAffine) Forward(xs, _ []matrix.Matrix, _ ...Opts) []matrix.Matrix {
+	l.affine = TimeAffine{W: l.Embedding.W.T(), B: l.B} // (V, D) -> (D, V)
+	return l.affine.Forward(xs, nil)
+}
+
+func (l *TimeTiedAffine) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	dxs := l.affine.Backward(dout)
+	l.DB = l.affine.DB
+	l.Embedding.tied = l.affine.DW.T() // (D, V) -> (V, D)
+	return dxs
+}
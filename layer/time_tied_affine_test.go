@@ -0,0 +1,43 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+func ExampleTimeTiedAffine() {
+	embedding := &layer.TimeEmbedding{
+		W: matrix.New(
+			[]float64{0.1, 0.2},
+			[]float64{0.3, 0.4},
+			[]float64{0.5, 0.6},
+		),
+	}
+
+	affine := &layer.TimeTiedAffine{
+		Embedding: embedding,
+		B:         matrix.New([]float64{0.1, 0.2, 0.3}),
+	}
+	fmt.Println(affine)
+
+	// forward
+	xs := []matrix.Matrix{{{1}}}
+	hs := embedding.Forward(xs, nil)
+	fmt.Println(affine.Forward(hs, nil))
+
+	// backward
+	dout := []matrix.Matrix{{{1.0, 0.5, 0.0}}}
+	embedding.Backward(affine.Backward(dout))
+
+	// grads
+	fmt.Println(affine.Grads())
+	fmt.Println(embedding.Grads())
+
+	// Output:
+	// *layer.TimeTiedAffine: W(2, 3), B(1, 3): 3
+	// [[[0.21000000000000002 0.45 0.69]]]
+	// [[[1 0.5 0]]]
+	// [[[0.3 0.4] [0.4 0.6000000000000001] [0 0]]]
+}
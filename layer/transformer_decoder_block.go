@@ -0,0 +1,79 @@
+package layer
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/tensor"
+)
+
+// TransformerDecoderBlock is a layer of the Transformer decoder.
+// Causal self-attention -> Add & LayerNorm -> Cross-attention over the encoder outputs -> Add & LayerNorm
+// -> Position-wise feed-forward -> Add & LayerNorm.
+type TransformerDecoderBlock struct {
+	SelfAttention  *MultiHeadAttention
+	Norm1          *TimeLayerNorm
+	CrossAttention *MultiHeadAttention
+	Norm2          *TimeLayerNorm
+	FF1, FF2       *TimeAffine
+	Norm3          *TimeLayerNorm
+	ff             positionwise
+	dhs            []matrix.Matrix
+}
+
+func (l *TransformerDecoderBlock) layers() []timeParams {
+	return []timeParams{l.SelfAttention, l.Norm1, l.CrossAttention, l.Norm2, l.FF1, l.FF2, l.Norm3}
+}
+
+func (l *TransformerDecoderBlock) Params() []matrix.Matrix { return params(l.layers()) }
+func (l *TransformerDecoderBlock) Grads() []matrix.Matrix  { return grads(l.layers()) }
+func (l *TransformerDecoderBlock) SetParams(p ...matrix.Matrix) {
+	setParams(l.layers(), p)
+}
+
+func (l *TransformerDecoderBlock) ParamNames() []string {
+	return slices.Concat(
+		prefixed("SelfAttention", l.SelfAttention.ParamNames()),
+		prefixed("Norm1", l.Norm1.ParamNames()),
+		prefixed("CrossAttention", l.CrossAttention.ParamNames()),
+		prefixed("Norm2", l.Norm2.ParamNames()),
+		prefixed("FF1", l.FF1.ParamNames()),
+		prefixed("FF2", l.FF2.ParamNames()),
+		prefixed("Norm3", l.Norm3.ParamNames()),
+	)
+}
+
+// DHS returns the gradient of the encoder outputs of the last Backward.
+func (l *TransformerDecoderBlock) DHS() []matrix.Matrix { return l.dhs }
+
+func (l *TransformerDecoderBlock) SetState(_ ...matrix.Matrix) {}
+func (l *TransformerDecoderBlock) ResetState()                 {}
+
+func (l *TransformerDecoderBlock) String() string {
+	a, b := l.FF1.W.Dim()
+	return fmt.Sprintf("%T: H(%v), FF(%v), Heads(%v): %v", l, a, b, l.SelfAttention.Heads, size(l.Params()))
+}
+
+// Forward returns the outputs of the decoder inputs xs, which attend to the encoder outputs hs.
+func (l *TransformerDecoderBlock) Forward(xs, hs []matrix.Matrix, opts ...Opts) []matrix.Matrix {
+	a := l.SelfAttention.Forward(xs, nil, opts...)
+	h1 := l.Norm1.Forward(tensor.Add(xs, a), nil)
+	c := l.CrossAttention.Forward(h1, hs, opts...)
+	h2 := l.Norm2.Forward(tensor.Add(h1, c), nil)
+	f := l.ff.forward(l.FF1, l.FF2, h2)
+	return l.Norm3.Forward(tensor.Add(h2, f), nil)
+}
+
+// Backward returns the gradient of xs. The gradient of the encoder outputs is DHS.
+func (l *TransformerDecoderBlock) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	d3 := l.Norm3.Backward(dout)
+	dh2 := tensor.Add(d3, l.ff.backward(l.FF1, l.FF2, d3))
+	d2 := l.Norm2.Backward(dh2)
+
+	dq, dhs := l.CrossAttention.BackwardKV(d2)
+	d1 := l.Norm1.Backward(tensor.Add(d2, dq))
+	l.dhs = dhs
+
+	return tensor.Add(d1, l.SelfAttention.Backward(d1))
+}
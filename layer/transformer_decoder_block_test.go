@@ -0,0 +1,73 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+)
+
+func newTransformerDecoderBlock(H, F, heads int) *layer.TransformerDecoderBlock {
+	s := rand.Const(1)
+	self := newMultiHeadAttention(H, heads)
+	self.Causal = true
+
+	return &layer.TransformerDecoderBlock{
+		SelfAttention:  self,
+		Norm1:          &layer.TimeLayerNorm{Gamma: matrix.One(1, H), Beta: matrix.Zero(1, H)},
+		CrossAttention: newMultiHeadAttention(H, heads),
+		Norm2:          &layer.TimeLayerNorm{Gamma: matrix.One(1, H), Beta: matrix.Zero(1, H)},
+		FF1:            &layer.TimeAffine{W: matrix.Randn(H, F, s), B: matrix.Zero(1, F)},
+		FF2:            &layer.TimeAffine{W: matrix.Randn(F, H, s), B: matrix.Zero(1, H)},
+		Norm3:          &layer.TimeLayerNorm{Gamma: matrix.One(1, H), Beta: matrix.Zero(1, H)},
+	}
+}
+
+func ExampleTransformerDecoderBlock() {
+	l := newTransformerDecoderBlock(4, 6, 2)
+	fmt.Println(l)
+
+	// (T, N, H) = (2, 1, 4)
+	xs := []matrix.Matrix{
+		{{1, 0, 0, 0}},
+		{{0, 1, 0, 0}},
+	}
+
+	// the encoder outputs (T, N, H) = (3, 1, 4)
+	hs := []matrix.Matrix{
+		{{1, 0, 0, 0}},
+		{{0, 1, 0, 0}},
+		{{0, 0, 1, 1}},
+	}
+
+	ys := l.Forward(xs, hs)
+	for _, y := range ys {
+		fmt.Printf("%.4f\n", y)
+	}
+
+	dxs := l.Backward(ys)
+	fmt.Println(len(dxs), len(dxs[0]), len(dxs[0][0]))
+	fmt.Println(len(l.DHS()), len(l.DHS()[0]), len(l.DHS()[0][0]))
+
+	// Output:
+	// *layer.TransformerDecoderBlock: H(4), FF(6), Heads(2): 234
+	// [[1.6327 -0.3135 -0.2319 -1.0873]]
+	// [[1.3894 0.3491 -0.4022 -1.3363]]
+	// 2 1 4
+	// 3 1 4
+}
+
+func ExampleTransformerDecoderBlock_ParamNames() {
+	l := newTransformerDecoderBlock(4, 6, 2)
+	fmt.Println(l.ParamNames())
+	fmt.Println(len(l.Params()), len(l.Grads()))
+
+	l.SetParams(l.Params()...)
+	l.SetState()
+	l.ResetState()
+
+	// Output:
+	// [SelfAttention.Wq SelfAttention.Bq SelfAttention.Wk SelfAttention.Wv SelfAttention.Bv SelfAttention.Wo SelfAttention.Bo Norm1.Gamma Norm1.Beta CrossAttention.Wq CrossAttention.Bq CrossAttention.Wk CrossAttention.Wv CrossAttention.Bv CrossAttention.Wo CrossAttention.Bo Norm2.Gamma Norm2.Beta FF1.W FF1.B FF2.W FF2.B Norm3.Gamma Norm3.Beta]
+	// 24 24
+}
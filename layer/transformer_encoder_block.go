@@ -0,0 +1,136 @@
+package layer
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/tensor"
+)
+
+// TransformerEncoderBlock is a layer of the Transformer encoder.
+// Self-attention -> Add & LayerNorm -> Position-wise feed-forward -> Add & LayerNorm.
+type TransformerEncoderBlock struct {
+	Attention *MultiHeadAttention
+	Norm1     *TimeLayerNorm
+	FF1, FF2  *TimeAffine
+	Norm2     *TimeLayerNorm
+	ff        positionwise
+}
+
+func (l *TransformerEncoderBlock) layers() []timeParams {
+	return []timeParams{l.Attention, l.Norm1, l.FF1, l.FF2, l.Norm2}
+}
+
+func (l *TransformerEncoderBlock) Params() []matrix.Matrix { return params(l.layers()) }
+func (l *TransformerEncoderBlock) Grads() []matrix.Matrix  { return grads(l.layers()) }
+func (l *TransformerEncoderBlock) SetParams(p ...matrix.Matrix) {
+	setParams(l.layers(), p)
+}
+
+func (l *TransformerEncoderBlock) ParamNames() []string {
+	return slices.Concat(
+		prefixed("Attention", l.Attention.ParamNames()),
+		prefixed("Norm1", l.Norm1.ParamNames()),
+		prefixed("FF1", l.FF1.ParamNames()),
+		prefixed("FF2", l.FF2.ParamNames()),
+		prefixed("Norm2", l.Norm2.ParamNames()),
+	)
+}
+
+func (l *TransformerEncoderBlock) SetState(_ ...matrix.Matrix) {}
+func (l *TransformerEncoderBlock) ResetState()                 {}
+
+func (l *TransformerEncoderBlock) String() string {
+	a, b := l.FF1.W.Dim()
+	return fmt.Sprintf("%T: H(%v), FF(%v), Heads(%v): %v", l, a, b, l.Attention.Heads, size(l.Params()))
+}
+
+func (l *TransformerEncoderBlock) Forward(xs, _ []matrix.Matrix, opts ...Opts) []matrix.Matrix {
+	a := l.Attention.Forward(xs, nil, opts...)
+	h := l.Norm1.Forward(tensor.Add(xs, a), nil)
+	f := l.ff.forward(l.FF1, l.FF2, h)
+	return l.Norm2.Forward(tensor.Add(h, f), nil)
+}
+
+func (l *TransformerEncoderBlock) Backward(dout []matrix.Matrix) []matrix.Matrix {
+	d2 := l.Norm2.Backward(dout)
+	dh := tensor.Add(d2, l.ff.backward(l.FF1, l.FF2, d2))
+	d1 := l.Norm1.Backward(dh)
+	return tensor.Add(d1, l.Attention.Backward(d1))
+}
+
+// positionwise is the position-wise feed-forward network, Affine -> ReLU -> Affine at each time step.
+type positionwise struct {
+	relu []ReLU
+}
+
+func (p *positionwise) forward(ff1, ff2 *TimeAffine, xs []matrix.Matrix) []matrix.Matrix {
+	h := ff1.Forward(xs, nil)
+	p.relu = make([]ReLU, len(h))
+	for t := range h {
+		h[t] = p.relu[t].Forward(h[t], nil)
+	}
+
+	return ff2.Forward(h, nil)
+}
+
+func (p *positionwise) backward(ff1, ff2 *TimeAffine, dout []matrix.Matrix) []matrix.Matrix {
+	dh := ff2.Backward(dout)
+	for t := range dh {
+		dh[t], _ = p.relu[t].Backward(dh[t])
+	}
+
+	return ff1.Backward(dh)
+}
+
+type timeParams interface {
+	Params() []matrix.Matrix
+	Grads() []matrix.Matrix
+	SetParams(p ...matrix.Matrix)
+}
+
+func params(layers []timeParams) []matrix.Matrix {
+	out := make([]matrix.Matrix, 0)
+	for _, l := range layers {
+		out = append(out, l.Params()...)
+	}
+
+	return out
+}
+
+func grads(layers []timeParams) []matrix.Matrix {
+	out := make([]matrix.Matrix, 0)
+	for _, l := range layers {
+		out = append(out, l.Grads()...)
+	}
+
+	return out
+}
+
+// setParams sets p to the layers in the order of params.
+func setParams(layers []timeParams, p []matrix.Matrix) {
+	for _, l := range layers {
+		n := len(l.Params())
+		l.SetParams(p[:n]...)
+		p = p[n:]
+	}
+}
+
+func prefixed(prefix string, names []string) []string {
+	out := make([]string, len(names))
+	for i, n := range names {
+		out[i] = prefix + "." + n
+	}
+
+	return out
+}
+
+func size(p []matrix.Matrix) int {
+	var out int
+	for _, m := range p {
+		out += m.Size()
+	}
+
+	return out
+}
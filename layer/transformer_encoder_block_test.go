@@ -0,0 +1,61 @@
+package layer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+)
+
+func newTransformerEncoderBlock(H, F, heads int) *layer.TransformerEncoderBlock {
+	s := rand.Const(1)
+	return &layer.TransformerEncoderBlock{
+		Attention: newMultiHeadAttention(H, heads),
+		Norm1:     &layer.TimeLayerNorm{Gamma: matrix.One(1, H), Beta: matrix.Zero(1, H)},
+		FF1:       &layer.TimeAffine{W: matrix.Randn(H, F, s), B: matrix.Zero(1, F)},
+		FF2:       &layer.TimeAffine{W: matrix.Randn(F, H, s), B: matrix.Zero(1, H)},
+		Norm2:     &layer.TimeLayerNorm{Gamma: matrix.One(1, H), Beta: matrix.Zero(1, H)},
+	}
+}
+
+func ExampleTransformerEncoderBlock() {
+	l := newTransformerEncoderBlock(4, 6, 2)
+	fmt.Println(l)
+
+	// (T, N, H) = (3, 1, 4)
+	xs := []matrix.Matrix{
+		{{1, 0, 0, 0}},
+		{{0, 1, 0, 0}},
+		{{0, 0, 1, 1}},
+	}
+
+	ys := l.Forward(xs, nil)
+	for _, y := range ys {
+		fmt.Printf("%.4f\n", y)
+	}
+
+	dxs := l.Backward(ys)
+	fmt.Println(len(dxs), len(dxs[0]), len(dxs[0][0]))
+
+	// Output:
+	// *layer.TransformerEncoderBlock: H(4), FF(6), Heads(2): 150
+	// [[1.2211 -0.3705 0.5771 -1.4278]]
+	// [[1.0335 -0.3001 0.7675 -1.5009]]
+	// [[0.8521 -0.5299 1.0516 -1.3738]]
+	// 3 1 4
+}
+
+func ExampleTransformerEncoderBlock_ParamNames() {
+	l := newTransformerEncoderBlock(4, 6, 2)
+	fmt.Println(l.ParamNames())
+	fmt.Println(len(l.Params()), len(l.Grads()))
+
+	l.SetParams(l.Params()...)
+	l.SetState()
+	l.ResetState()
+
+	// Output:
+	// [Attention.Wq Attention.Bq Attention.Wk Attention.Wv Attention.Bv Attention.Wo Attention.Bo Norm1.Gamma Norm1.Beta FF1.W FF1.B FF2.W FF2.B Norm2.Gamma Norm2.Beta]
+	// 15 15
+}
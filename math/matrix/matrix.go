@@ -5,35 +5,86 @@ import (
 	randv2 "math/rand/v2"
 
 	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/math/vector"
 )
 
-type Matrix [][]float64
+// Dense is a matrix of floating-point numbers.
+// The rows of a new matrix are views of one contiguous backing array in row-major order,
+// except New and NewOf, which keep the given rows.
+// Batch, Broadcast, Split and Unpadding return the views of the rows of the given matrix.
+type Dense[T vector.Float] [][]T
 
+// Matrix is a matrix of float64.
+type Matrix = Dense[float64]
+
+// New returns a matrix of the rows. The rows are not copied.
 func New(v ...[]float64) Matrix {
-	out := make(Matrix, len(v))
+	return NewOf(v...)
+}
+
+// NewOf returns a matrix of T of the rows. The rows are not copied.
+func NewOf[T vector.Float](v ...[]T) Dense[T] {
+	out := make(Dense[T], len(v))
 	copy(out, v)
 	return out
 }
 
+// Contiguous returns a copy of m, whose rows are views of one contiguous backing array.
+func Contiguous[T vector.Float](m Dense[T]) Dense[T] {
+	var size int
+	for i := range m {
+		size = size + len(m[i])
+	}
+
+	data := make([]T, 0, size)
+	out := make(Dense[T], len(m))
+	for i := range m {
+		begin := len(data)
+		data = append(data, m[i]...)
+		out[i] = data[begin:len(data):len(data)]
+	}
+
+	return out
+}
+
 // Zero returns a matrix with all elements 0.
 func Zero(m, n int) Matrix {
-	out := make(Matrix, m)
+	return ZeroOf[float64](m, n)
+}
+
+// ZeroOf returns a matrix of T with all elements 0.
+// The rows are views of one contiguous backing array in row-major order.
+func ZeroOf[T vector.Float](m, n int) Dense[T] {
+	data := make([]T, m*n)
+
+	out := make(Dense[T], m)
 	for i := 0; i < m; i++ {
-		out[i] = make([]float64, n)
+		out[i] = data[i*n : (i+1)*n : (i+1)*n]
 	}
 
 	return out
 }
 
-func ZeroLike(m Matrix) Matrix {
-	return Zero(m.Dim())
+func ZeroLike[T vector.Float](m Dense[T]) Dense[T] {
+	return ZeroOf[T](m.Dim())
+}
+
+// To returns a matrix with the elements converted to T.
+func To[T, S vector.Float](m Dense[S]) Dense[T] {
+	out := ZeroOf[T](m.Dim())
+	for i := range m {
+		for j := range m[i] {
+			out[i][j] = T(m[i][j])
+		}
+	}
+
+	return out
 }
 
 // One returns a matrix with all elements 1.
 func One(m, n int) Matrix {
-	out := make(Matrix, m)
+	out := Zero(m, n)
 	for i := 0; i < m; i++ {
-		out[i] = make([]float64, n)
 		for j := 0; j < n; j++ {
 			out[i][j] = 1
 		}
@@ -55,18 +106,30 @@ func rnd(s ...randv2.Source) *randv2.Rand {
 // m, n is the dimension of the matrix.
 // s is the source of the pseudo-random number generator.
 func Rand(m, n int, s ...randv2.Source) Matrix {
-	return F(Zero(m, n), func(_ float64) float64 { return rnd(s...).Float64() })
+	return fill(Zero(m, n), func() float64 { return rnd(s...).Float64() })
 }
 
 // Randn returns a matrix with elements that normally distributed float64 in the range [-math.MaxFloat64, +math.MaxFloat64] with standard normal distribution.
 // m, n is the dimension of the matrix.
 // s is the source of the pseudo-random number generator.
 func Randn(m, n int, s ...randv2.Source) Matrix {
-	return F(Zero(m, n), func(_ float64) float64 { return rnd(s...).NormFloat64() })
+	return fill(Zero(m, n), func() float64 { return rnd(s...).NormFloat64() })
+}
+
+// fill sets each element of the matrix to f() in row-major order.
+// It always runs on the calling goroutine, because f may draw from a pseudo-random number generator.
+func fill(m Matrix, f func() float64) Matrix {
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] = f()
+		}
+	}
+
+	return m
 }
 
 // Mask returns a matrix with elements that 1 if f() is true and 0 otherwise.
-func Mask(m Matrix, f func(x float64) bool) Matrix {
+func Mask[T vector.Float](m Dense[T], f func(x T) bool) Dense[T] {
 	mask := ZeroLike(m)
 	for i := range m {
 		for j := range m[i] {
@@ -80,8 +143,8 @@ func Mask(m Matrix, f func(x float64) bool) Matrix {
 }
 
 // Batch returns a matrix with rows of the specified index.
-func Batch(m Matrix, index []int) Matrix {
-	out := make(Matrix, len(index))
+func Batch[T vector.Float](m Dense[T], index []int) Dense[T] {
+	out := make(Dense[T], len(index))
 	for i, idx := range index {
 		out[i] = m[idx]
 	}
@@ -90,10 +153,10 @@ func Batch(m Matrix, index []int) Matrix {
 }
 
 // Column returns a matrix with the specified column.
-func Column(m Matrix, j int) Matrix {
-	out := make(Matrix, len(m))
+func Column[T vector.Float](m Dense[T], j int) Dense[T] {
+	out := ZeroOf[T](len(m), 1)
 	for i, r := range m {
-		out[i] = []float64{r[j]}
+		out[i][0] = r[j]
 	}
 
 	return out
@@ -111,7 +174,7 @@ func From(x [][]int) Matrix {
 	return out
 }
 
-func Int(m Matrix) [][]int {
+func Int[T vector.Float](m Dense[T]) [][]int {
 	out := make([][]int, len(m))
 	for i, r := range m {
 		out[i] = make([]int, len(r))
@@ -141,7 +204,7 @@ func OneHot(x []int, size int) Matrix {
 	return out
 }
 
-func (m Matrix) Dim() (int, int) {
+func (m Dense[T]) Dim() (int, int) {
 	if len(m) == 0 {
 		return 0, 0
 	}
@@ -149,63 +212,70 @@ func (m Matrix) Dim() (int, int) {
 	return len(m), len(m[0])
 }
 
-func (m Matrix) Size() int {
+func (m Dense[T]) Size() int {
 	a, b := m.Dim()
 	return a * b
 }
 
-func (m Matrix) T() Matrix {
+func (m Dense[T]) T() Dense[T] {
 	p, q := m.Dim()
 
-	out := Zero(q, p)
-	for i := 0; i < q; i++ {
-		for j := 0; j < p; j++ {
-			out[i][j] = m[j][i]
+	out := ZeroOf[T](q, p)
+	for ii := 0; ii < p; ii += block {
+		imax := min(ii+block, p)
+		for jj := 0; jj < q; jj += block {
+			jmax := min(jj+block, q)
+			for i := ii; i < imax; i++ {
+				mi := m[i]
+				for j := jj; j < jmax; j++ {
+					out[j][i] = mi[j]
+				}
+			}
 		}
 	}
 
 	return out
 }
 
-func (m Matrix) Add(n Matrix) Matrix {
-	return F2(m, n.Broadcast(m.Dim()), func(a, b float64) float64 { return a + b })
+func (m Dense[T]) Add(n Dense[T]) Dense[T] {
+	return F2(m, n.Broadcast(m.Dim()), func(a, b T) T { return a + b })
 }
 
-func (m Matrix) Sub(n Matrix) Matrix {
-	return F2(m, n.Broadcast(m.Dim()), func(a, b float64) float64 { return a - b })
+func (m Dense[T]) Sub(n Dense[T]) Dense[T] {
+	return F2(m, n.Broadcast(m.Dim()), func(a, b T) T { return a - b })
 }
 
-func (m Matrix) Mul(n Matrix) Matrix {
-	return F2(m, n.Broadcast(m.Dim()), func(a, b float64) float64 { return a * b })
+func (m Dense[T]) Mul(n Dense[T]) Dense[T] {
+	return F2(m, n.Broadcast(m.Dim()), func(a, b T) T { return a * b })
 }
 
-func (m Matrix) Div(n Matrix) Matrix {
-	return F2(m, n.Broadcast(m.Dim()), func(a, b float64) float64 { return a / b })
+func (m Dense[T]) Div(n Dense[T]) Dense[T] {
+	return F2(m, n.Broadcast(m.Dim()), func(a, b T) T { return a / b })
 }
 
-func (m Matrix) AddC(c float64) Matrix {
-	return F(m, func(v float64) float64 { return c + v })
+func (m Dense[T]) AddC(c T) Dense[T] {
+	return F(m, func(v T) T { return c + v })
 }
 
-func (m Matrix) MulC(c float64) Matrix {
-	return F(m, func(v float64) float64 { return c * v })
+func (m Dense[T]) MulC(c T) Dense[T] {
+	return F(m, func(v T) T { return c * v })
 }
 
-func (m Matrix) Sqrt(eps float64) Matrix {
-	return F(m, func(v float64) float64 { return math.Sqrt(v + eps) })
+func (m Dense[T]) Sqrt(eps T) Dense[T] {
+	return F(m, func(v T) T { return T(math.Sqrt(float64(v + eps))) })
 }
 
-func (m Matrix) Pow2() Matrix {
-	return F(m, func(v float64) float64 { return v * v })
+func (m Dense[T]) Pow2() Dense[T] {
+	return F(m, func(v T) T { return v * v })
 }
 
-func (m Matrix) Abs() Matrix {
-	return F(m, func(v float64) float64 { return math.Abs(v) })
+func (m Dense[T]) Abs() Dense[T] {
+	return F(m, func(v T) T { return T(math.Abs(float64(v))) })
 }
 
 // Sum returns the sum of all elements.
-func (m Matrix) Sum() float64 {
-	var sum float64
+func (m Dense[T]) Sum() T {
+	var sum T
 	for i := range m {
 		for j := range m[i] {
 			sum = sum + m[i][j]
@@ -216,13 +286,13 @@ func (m Matrix) Sum() float64 {
 }
 
 // Mean returns the average of all elements.
-func (m Matrix) Mean() float64 {
+func (m Dense[T]) Mean() T {
 	a, b := m.Dim()
-	return m.Sum() / float64(a*b)
+	return m.Sum() / T(a*b)
 }
 
 // Argmax returns the index of the maximum value of each row.
-func (m Matrix) Argmax() []int {
+func (m Dense[T]) Argmax() []int {
 	out := make([]int, len(m))
 	for i := range m {
 		max := m[i][0]
@@ -238,29 +308,30 @@ func (m Matrix) Argmax() []int {
 }
 
 // SumAxis0 returns the sum of each column.
-func (m Matrix) SumAxis0() []float64 {
+func (m Dense[T]) SumAxis0() []T {
 	p, q := m.Dim()
 
-	v := make([]float64, 0, q)
-	for j := 0; j < q; j++ {
-		var sum float64
+	// columns are split across the workers, so that each column is added in the order of rows.
+	v := make([]T, q)
+	parallel(q, p, func(begin, end int) {
 		for i := 0; i < p; i++ {
-			sum = sum + m[i][j]
+			mi, vi := m[i][begin:end], v[begin:end]
+			for j := range vi {
+				vi[j] = vi[j] + mi[j]
+			}
 		}
-
-		v = append(v, sum)
-	}
+	})
 
 	return v
 }
 
 // SumAxis1 returns the sum of each row.
-func (m Matrix) SumAxis1() []float64 {
+func (m Dense[T]) SumAxis1() []T {
 	p, q := m.Dim()
 
-	v := make([]float64, 0, p)
+	v := make([]T, 0, p)
 	for i := 0; i < p; i++ {
-		var sum float64
+		var sum T
 		for j := 0; j < q; j++ {
 			sum = sum + m[i][j]
 		}
@@ -272,18 +343,18 @@ func (m Matrix) SumAxis1() []float64 {
 }
 
 // MeanAxis0 returns the mean of each column.
-func (m Matrix) MeanAxis0() []float64 {
-	out := make([]float64, 0)
+func (m Dense[T]) MeanAxis0() []T {
+	out := make([]T, 0)
 	for _, v := range m.SumAxis0() {
-		out = append(out, v/float64(len(m)))
+		out = append(out, v/T(len(m)))
 	}
 
 	return out
 }
 
 // MaxAxis1 returns the maximum value of each row.
-func (m Matrix) MaxAxis1() []float64 {
-	out := make([]float64, len(m))
+func (m Dense[T]) MaxAxis1() []T {
+	out := make([]T, len(m))
 	for i := range m {
 		out[i] = m[i][0]
 		for j := range m[i] {
@@ -297,9 +368,9 @@ func (m Matrix) MaxAxis1() []float64 {
 }
 
 // Broadcast returns the broadcasted matrix.
-func (m Matrix) Broadcast(a, b int) Matrix {
+func (m Dense[T]) Broadcast(a, b int) Dense[T] {
 	if len(m) == 1 && len(m[0]) == 1 {
-		out := Zero(a, b)
+		out := ZeroOf[T](a, b)
 		for i := 0; i < a; i++ {
 			for j := 0; j < b; j++ {
 				out[i][j] = m[0][0]
@@ -311,7 +382,7 @@ func (m Matrix) Broadcast(a, b int) Matrix {
 
 	if len(m) == 1 {
 		// b is ignored
-		out := make(Matrix, a)
+		out := make(Dense[T], a)
 		for i := 0; i < a; i++ {
 			out[i] = m[0]
 		}
@@ -321,7 +392,7 @@ func (m Matrix) Broadcast(a, b int) Matrix {
 
 	if len(m[0]) == 1 {
 		// a is ignored
-		out := Zero(len(m), b)
+		out := ZeroOf[T](len(m), b)
 		for i := 0; i < len(m); i++ {
 			for j := 0; j < b; j++ {
 				out[i][j] = m[i][0]
@@ -335,100 +406,124 @@ func (m Matrix) Broadcast(a, b int) Matrix {
 }
 
 // SubC returns c - m
-func SubC(c float64, m Matrix) Matrix {
-	return F(m, func(v float64) float64 { return c - v })
+func SubC[T vector.Float](c T, m Dense[T]) Dense[T] {
+	return F(m, func(v T) T { return c - v })
 }
 
+// block is the tile size of the blocked matrix operations.
+const block = 64
+
 // Dot returns the dot product of m and n.
-func Dot(m, n Matrix) Matrix {
+// It is a blocked (tiled) matrix multiplication in i-k-j order.
+// For each element, the products are added in the order of k, so the result is the same as the naive triple loop.
+func Dot[T vector.Float](m, n Dense[T]) Dense[T] {
 	a, b := m.Dim()
 	_, p := n.Dim()
 
-	out := Zero(a, p)
-	for i := 0; i < a; i++ {
-		for j := 0; j < p; j++ {
-			for k := 0; k < b; k++ {
-				out[i][j] = out[i][j] + m[i][k]*n[k][j]
+	out := ZeroOf[T](a, p)
+	parallel(a, b*p, func(begin, end int) {
+		dot(m, n, out, begin, end)
+	})
+
+	return out
+}
+
+// dot computes the rows [begin, end) of the dot product of m and n into out.
+func dot[T vector.Float](m, n, out Dense[T], begin, end int) {
+	_, b := m.Dim()
+	_, p := n.Dim()
+	for ii := begin; ii < end; ii += block {
+		imax := min(ii+block, end)
+		for kk := 0; kk < b; kk += block {
+			kmax := min(kk+block, b)
+			for jj := 0; jj < p; jj += block {
+				jmax := min(jj+block, p)
+				for i := ii; i < imax; i++ {
+					mi, oi := m[i], out[i][jj:jmax]
+					for k := kk; k < kmax; k++ {
+						mik, nk := mi[k], n[k][jj:jmax]
+						for j := range oi {
+							oi[j] = oi[j] + mik*nk[j]
+						}
+					}
+				}
 			}
 		}
 	}
-
-	return out
 }
 
 // F applies a function to each element of the matrix.
-func F(m Matrix, f func(a float64) float64) Matrix {
+// f may be called concurrently if SetWorkers is greater than 1, so f must not have side effects, e.g. a counter or a shared pseudo-random number generator.
+func F[T vector.Float](m Dense[T], f func(a T) T) Dense[T] {
 	p, q := m.Dim()
 
-	out := Zero(p, q)
-	for i := 0; i < p; i++ {
-		for j := 0; j < q; j++ {
-			out[i][j] = f(m[i][j])
+	out := ZeroOf[T](p, q)
+	parallel(p, q, func(begin, end int) {
+		for i := begin; i < end; i++ {
+			oi, mi := out[i], m[i]
+			for j := 0; j < q; j++ {
+				oi[j] = f(mi[j])
+			}
 		}
-	}
+	})
 
 	return out
 }
 
 // F2 applies a function to each element of the matrix.
-func F2(m, n Matrix, f func(a, b float64) float64) Matrix {
+// f may be called concurrently if SetWorkers is greater than 1, so f must not have side effects, e.g. a counter or a shared pseudo-random number generator.
+func F2[T vector.Float](m, n Dense[T], f func(a, b T) T) Dense[T] {
 	p, q := m.Dim()
 
-	out := Zero(p, q)
-	for i := 0; i < p; i++ {
-		for j := 0; j < q; j++ {
-			out[i][j] = f(m[i][j], n[i][j])
+	out := ZeroOf[T](p, q)
+	parallel(p, q, func(begin, end int) {
+		for i := begin; i < end; i++ {
+			oi, mi, ni := out[i], m[i], n[i]
+			for j := 0; j < q; j++ {
+				oi[j] = f(mi[j], ni[j])
+			}
 		}
-	}
+	})
 
 	return out
 }
 
 // F3 applies a function to each element of the matrix.
-func F3(m, n, o Matrix, f func(a, b, c float64) float64) Matrix {
+// f may be called concurrently if SetWorkers is greater than 1, so f must not have side effects, e.g. a counter or a shared pseudo-random number generator.
+func F3[T vector.Float](m, n, o Dense[T], f func(a, b, c T) T) Dense[T] {
 	p, q := m.Dim()
 
-	out := Zero(p, q)
-	for i := 0; i < p; i++ {
-		for j := 0; j < q; j++ {
-			out[i][j] = f(m[i][j], n[i][j], o[i][j])
+	out := ZeroOf[T](p, q)
+	parallel(p, q, func(begin, end int) {
+		for i := begin; i < end; i++ {
+			oi, mi, ni, li := out[i], m[i], n[i], o[i]
+			for j := 0; j < q; j++ {
+				oi[j] = f(mi[j], ni[j], li[j])
+			}
 		}
-	}
+	})
 
 	return out
 }
 
 // Padding returns the padded matrix.
-func Padding(x Matrix, pad int) Matrix {
-	_, q := x.Dim()
-	pw := pad + q + pad // right + row + left
-
-	// top
-	out := New()
-	for i := 0; i < pad; i++ {
-		out = append(out, make([]float64, pw))
-	}
+func Padding[T vector.Float](x Dense[T], pad int) Dense[T] {
+	p, q := x.Dim()
 
-	// right, left
+	// top + rows + bottom, right + row + left
+	out := ZeroOf[T](pad+p+pad, pad+q+pad)
 	for i := range x {
-		v := append(make([]float64, pad), x[i]...) // right + row
-		v = append(v, make([]float64, pad)...)     // right + row + left
-		out = append(out, v)
-	}
-
-	// bottom
-	for i := 0; i < pad; i++ {
-		out = append(out, make([]float64, pw))
+		copy(out[pad+i][pad:], x[i])
 	}
 
 	return out
 }
 
 // Unpadding returns the unpadded matrix.
-func Unpadding(x Matrix, pad int) Matrix {
+func Unpadding[T vector.Float](x Dense[T], pad int) Dense[T] {
 	m, n := x.Dim()
 
-	out := New()
+	out := make(Dense[T], 0)
 	for _, r := range x[pad : m-pad] {
 		out = append(out, r[pad:n-pad])
 	}
@@ -436,8 +531,8 @@ func Unpadding(x Matrix, pad int) Matrix {
 	return out
 }
 
-func Flatten(x Matrix) []float64 {
-	out := make([]float64, 0)
+func Flatten[T vector.Float](x Dense[T]) []T {
+	out := make([]T, 0, x.Size())
 	for _, r := range x {
 		out = append(out, r...)
 	}
@@ -446,7 +541,7 @@ func Flatten(x Matrix) []float64 {
 }
 
 // Reshape returns the matrix with the given shape.
-func Reshape(x Matrix, m, n int) Matrix {
+func Reshape[T vector.Float](x Dense[T], m, n int) Dense[T] {
 	v := Flatten(x)
 
 	if m < 1 {
@@ -459,20 +554,20 @@ func Reshape(x Matrix, m, n int) Matrix {
 		n = p * q / m
 	}
 
-	out := New()
+	out := make(Dense[T], 0)
 	for i := 0; i < m; i++ {
 		begin, end := i*n, (i+1)*n
-		out = append(out, v[begin:end])
+		out = append(out, v[begin:end:end])
 	}
 
 	return out
 }
 
 // Split returns the matrix split into H parts.
-func Split(x Matrix, H int) []Matrix {
-	out := make([]Matrix, len(x[0])/H)
+func Split[T vector.Float](x Dense[T], H int) []Dense[T] {
+	out := make([]Dense[T], len(x[0])/H)
 	for i := 0; i < len(out); i++ {
-		out[i] = New()
+		out[i] = make(Dense[T], 0)
 		for _, r := range x {
 			out[i] = append(out[i], r[i*H:(i+1)*H])
 		}
@@ -482,11 +577,18 @@ func Split(x Matrix, H int) []Matrix {
 }
 
 // HStack returns the matrix horizontally stacked.
-func HStack(x ...Matrix) Matrix {
-	out := Zero(len(x[0]), 0)
+func HStack[T vector.Float](x ...Dense[T]) Dense[T] {
+	var q int
 	for _, m := range x {
-		for i, r := range m {
-			out[i] = append(out[i], r...)
+		_, n := m.Dim()
+		q = q + n
+	}
+
+	out := ZeroOf[T](len(x[0]), q)
+	for i := range out {
+		var j int
+		for _, m := range x {
+			j = j + copy(out[i][j:], m[i])
 		}
 	}
 
@@ -0,0 +1,93 @@
+package matrix_test
+
+import (
+	"testing"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+)
+
+// dot is the naive triple loop for comparison.
+func dot(m, n matrix.Matrix) matrix.Matrix {
+	a, b := m.Dim()
+	_, p := n.Dim()
+
+	out := make(matrix.Matrix, a)
+	for i := 0; i < a; i++ {
+		out[i] = make([]float64, p)
+		for j := 0; j < p; j++ {
+			for k := 0; k < b; k++ {
+				out[i][j] = out[i][j] + m[i][k]*n[k][j]
+			}
+		}
+	}
+
+	return out
+}
+
+func TestDot(t *testing.T) {
+	s := rand.Const(1)
+	for _, c := range []struct {
+		a, b, p int
+	}{
+		{1, 1, 1},
+		{3, 5, 7},
+		{65, 130, 67},
+		{100, 784, 50},
+	} {
+		x := matrix.Randn(c.a, c.b, s)
+		y := matrix.Randn(c.b, c.p, s)
+
+		got, want := matrix.Dot(x, y), dot(x, y)
+		for i := range want {
+			for j := range want[i] {
+				if got[i][j] != want[i][j] {
+					t.Fatalf("%v: got=%v, want=%v", c, got[i][j], want[i][j])
+				}
+			}
+		}
+	}
+}
+
+func benchmarkDot(b *testing.B, f func(m, n matrix.Matrix) matrix.Matrix, x, y, z int) {
+	s := rand.Const(1)
+	m := matrix.Randn(x, y, s)
+	n := matrix.Randn(y, z, s)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f(m, n)
+	}
+}
+
+// MNIST MLP. x(100, 784).W(784, 50)
+func BenchmarkDot_MLP(b *testing.B)       { benchmarkDot(b, matrix.Dot, 100, 784, 50) }
+func BenchmarkDot_MLP_naive(b *testing.B) { benchmarkDot(b, dot, 100, 784, 50) }
+
+// RNNLM. h(20, 650).W(650, 4*650)
+func BenchmarkDot_RNNLM(b *testing.B)       { benchmarkDot(b, matrix.Dot, 20, 650, 4*650) }
+func BenchmarkDot_RNNLM_naive(b *testing.B) { benchmarkDot(b, dot, 20, 650, 4*650) }
+
+// RNNLM backward. x.T(650, 20).dA(20, 4*650)
+func BenchmarkDot_RNNLMGrads(b *testing.B)       { benchmarkDot(b, matrix.Dot, 650, 20, 4*650) }
+func BenchmarkDot_RNNLMGrads_naive(b *testing.B) { benchmarkDot(b, dot, 650, 20, 4*650) }
+
+func BenchmarkT(b *testing.B) {
+	m := matrix.Randn(650, 4*650, rand.Const(1))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.T()
+	}
+}
+
+func BenchmarkF2(b *testing.B) {
+	s := rand.Const(1)
+	m := matrix.Randn(650, 650, s)
+	n := matrix.Randn(650, 650, s)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Add(n)
+	}
+}
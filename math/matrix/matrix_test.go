@@ -17,6 +17,53 @@ func ExampleZero() {
 	// [0 0 0]
 }
 
+func ExampleNew() {
+	v := []float64{1, 2}
+	m := matrix.New(v, []float64{3, 4})
+
+	// the rows are not copied
+	v[0] = 5
+	fmt.Println(m)
+
+	// Output:
+	// [[5 2] [3 4]]
+}
+
+func ExampleContiguous() {
+	v := []float64{1, 2}
+	m := matrix.Contiguous(matrix.New(v, []float64{3, 4}))
+
+	// the rows are copied into one array
+	v[0] = 5
+	fmt.Println(m)
+
+	// Output:
+	// [[1 2] [3 4]]
+}
+
+func ExampleZeroOf() {
+	z := matrix.ZeroOf[float32](2, 3)
+	fmt.Printf("%T\n", z[0][0])
+	fmt.Println(z)
+
+	// Output:
+	// float32
+	// [[0 0 0] [0 0 0]]
+}
+
+func ExampleTo() {
+	x := matrix.To[float32](matrix.New(
+		[]float64{0.1, 0.2},
+		[]float64{0.3, 0.4},
+	))
+	fmt.Println(x)
+	fmt.Println(matrix.To[float64](x))
+
+	// Output:
+	// [[0.1 0.2] [0.3 0.4]]
+	// [[0.10000000149011612 0.20000000298023224] [0.30000001192092896 0.4000000059604645]]
+}
+
 func ExampleOne() {
 	for _, r := range matrix.One(2, 3) {
 		fmt.Println(r)
@@ -163,6 +210,25 @@ func ExampleDot() {
 	// [43 50]
 }
 
+func ExampleDot_float32() {
+	A := matrix.Dense[float32]{
+		{1, 2},
+		{3, 4},
+	}
+
+	B := matrix.Dense[float32]{
+		{5, 6},
+		{7, 8},
+	}
+
+	fmt.Println(matrix.Dot(A, B))
+	fmt.Println(matrix.Dot(A, B).Add(A).MulC(0.5))
+
+	// Output:
+	// [[19 22] [43 50]]
+	// [[10 12] [23 27]]
+}
+
 func ExampleMatrix_Dim() {
 	fmt.Println(matrix.New().Dim())
 	fmt.Println(matrix.New([]float64{1, 2, 3}).Dim())
@@ -674,3 +740,19 @@ func ExampleHStack() {
 	// [1 2 3 7 8 9]
 	// [4 5 6 10 11 12]
 }
+
+func ExampleNewSparse() {
+	s := matrix.NewSparse(4, 2, []int{1, 3, 1}, matrix.New([]float64{1, 2}, []float64{3, 4}, []float64{5, 6}))
+	fmt.Println(s.Index, s.Value)
+	fmt.Println(s.Dense())
+	fmt.Println(s.View())
+
+	t := matrix.NewSparse(4, 2, []int{0, 3}, matrix.New([]float64{1, 1}, []float64{1, 1}))
+	fmt.Println(s.Add(t).Dense())
+
+	// Output:
+	// [1 3] [[6 8] [3 4]]
+	// [[0 0] [6 8] [0 0] [3 4]]
+	// [[0 0] [6 8] [0 0] [3 4]]
+	// [[1 1] [6 8] [0 0] [4 5]]
+}
@@ -0,0 +1,89 @@
+package matrix
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// minWork is the minimum number of elements per goroutine.
+// Smaller kernels run on the calling goroutine.
+const minWork = 1 << 14
+
+var (
+	workers atomic.Int64
+	busy    atomic.Int64
+)
+
+// SetWorkers sets the maximum number of goroutines used by the matrix kernels.
+// The default is 1, which runs all kernels on the calling goroutine.
+// The results do not depend on the number of workers, if the functions given to F, F2 and F3 have no side effects.
+func SetWorkers(n int) {
+	workers.Store(int64(n))
+}
+
+// Workers returns the maximum number of goroutines used by the matrix kernels.
+func Workers() int {
+	n := workers.Load()
+	if n < 1 {
+		return 1
+	}
+
+	return int(n)
+}
+
+// Parallel splits [0, n) into contiguous ranges and calls f for each range on the worker goroutines.
+// It returns after all calls have returned.
+// The worker goroutines are shared by all calls, including nested ones such as a kernel called from f.
+// A range runs on the calling goroutine when no worker is free, so at most Workers goroutines run the kernels at a time.
+func Parallel(n int, f func(begin, end int)) {
+	w := min(Workers(), n)
+	if w < 2 {
+		f(0, n)
+		return
+	}
+
+	size := (n + w - 1) / w
+
+	var wg sync.WaitGroup
+	for begin := 0; begin < n; begin += size {
+		end := min(begin+size, n)
+		if end == n || !acquire() {
+			f(begin, end)
+			continue
+		}
+
+		wg.Add(1)
+		go func(begin, end int) {
+			defer wg.Done()
+			defer busy.Add(-1)
+			f(begin, end)
+		}(begin, end)
+	}
+
+	wg.Wait()
+}
+
+// acquire reserves a worker goroutine, if the number of busy workers is less than Workers minus the calling goroutine.
+func acquire() bool {
+	for {
+		b := busy.Load()
+		if b >= int64(Workers()-1) {
+			return false
+		}
+
+		if busy.CompareAndSwap(b, b+1) {
+			return true
+		}
+	}
+}
+
+// parallel calls Parallel if the kernel is large enough, and f(0, n) otherwise.
+// cost is the number of elements processed for each index.
+func parallel(n, cost int, f func(begin, end int)) {
+	if n*cost < minWork {
+		f(0, n)
+		return
+	}
+
+	Parallel(n, f)
+}
@@ -0,0 +1,130 @@
+package matrix_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+)
+
+func ExampleSetWorkers() {
+	defer matrix.SetWorkers(matrix.Workers())
+
+	matrix.SetWorkers(4)
+	fmt.Println(matrix.Workers())
+
+	matrix.SetWorkers(0)
+	fmt.Println(matrix.Workers())
+
+	// Output:
+	// 4
+	// 1
+}
+
+func ExampleParallel() {
+	defer matrix.SetWorkers(matrix.Workers())
+	matrix.SetWorkers(3)
+
+	var count atomic.Int64
+	out := make([]int, 10)
+	matrix.Parallel(len(out), func(begin, end int) {
+		count.Add(1)
+		for i := begin; i < end; i++ {
+			out[i] = i * i
+		}
+	})
+
+	fmt.Println(out)
+	fmt.Println(count.Load())
+
+	// Output:
+	// [0 1 4 9 16 25 36 49 64 81]
+	// 3
+}
+
+func TestParallel(t *testing.T) {
+	defer matrix.SetWorkers(matrix.Workers())
+
+	s := rand.Const(1)
+	x := matrix.Randn(300, 200, s)
+	y := matrix.Randn(200, 100, s)
+	z := matrix.Randn(300, 200, s)
+	f := func(a, b, c float64) float64 { return a*b + c }
+
+	run := func() []matrix.Matrix {
+		return []matrix.Matrix{
+			matrix.Dot(x, y),
+			x.MulC(2),
+			x.Add(z),
+			matrix.F3(x, z, x, f),
+			matrix.New(x.SumAxis0()),
+		}
+	}
+
+	matrix.SetWorkers(1)
+	want := run()
+
+	for _, w := range []int{2, 3, 8, 32} {
+		matrix.SetWorkers(w)
+		got := run()
+
+		for k := range want {
+			for i := range want[k] {
+				for j := range want[k][i] {
+					if got[k][i][j] != want[k][i][j] {
+						t.Fatalf("workers=%v, kernel=%v: got=%v, want=%v", w, k, got[k][i][j], want[k][i][j])
+					}
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkDot_RNNLM_parallel(b *testing.B) {
+	defer matrix.SetWorkers(matrix.Workers())
+	matrix.SetWorkers(4)
+
+	benchmarkDot(b, matrix.Dot, 20, 650, 4*650)
+}
+
+func TestParallel_nested(t *testing.T) {
+	defer matrix.SetWorkers(matrix.Workers())
+	matrix.SetWorkers(4)
+
+	var running, peak atomic.Int64
+	out := make([][]int, 8)
+	matrix.Parallel(len(out), func(begin, end int) {
+		for i := begin; i < end; i++ {
+			out[i] = make([]int, 100)
+			matrix.Parallel(len(out[i]), func(b, e int) {
+				r := running.Add(1)
+				defer running.Add(-1)
+
+				for {
+					p := peak.Load()
+					if r <= p || peak.CompareAndSwap(p, r) {
+						break
+					}
+				}
+
+				for j := b; j < e; j++ {
+					out[i][j] = i * j
+				}
+			})
+		}
+	})
+
+	for i := range out {
+		for j := range out[i] {
+			if out[i][j] != i*j {
+				t.Fatalf("out[%v][%v]=%v, want=%v", i, j, out[i][j], i*j)
+			}
+		}
+	}
+
+	if p := peak.Load(); p > 4 {
+		t.Errorf("peak=%v, want<=4", p)
+	}
+}
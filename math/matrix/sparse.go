@@ -0,0 +1,95 @@
+package matrix
+
+import "github.com/itsubaki/neu/math/vector"
+
+// Sparse is a row-sparse matrix of float64.
+type Sparse = SparseOf[float64]
+
+// SparseOf is a row-sparse matrix of (Rows, Cols).
+// Only the rows in Index have values, and the other rows are zero.
+type SparseOf[T vector.Float] struct {
+	Rows, Cols int
+	Index      []int    // unique row indices
+	Value      Dense[T] // (len(Index), Cols)
+}
+
+// NewSparse returns a row-sparse matrix of (rows, cols) with v[i] added to the row index[i].
+// The rows of the duplicate indices are summed in order.
+func NewSparse[T vector.Float](rows, cols int, index []int, v Dense[T]) *SparseOf[T] {
+	out := &SparseOf[T]{Rows: rows, Cols: cols}
+
+	pos := make(map[int]int)
+	for i, k := range index {
+		p, ok := pos[k]
+		if !ok {
+			p = len(out.Index)
+			pos[k] = p
+			out.Index = append(out.Index, k)
+			out.Value = append(out.Value, make([]T, cols))
+		}
+
+		out.Value[p] = vector.Add(out.Value[p], v[i])
+	}
+
+	return out
+}
+
+// Add returns s + t.
+func (s *SparseOf[T]) Add(t *SparseOf[T]) *SparseOf[T] {
+	out := &SparseOf[T]{
+		Rows:  s.Rows,
+		Cols:  s.Cols,
+		Index: append(make([]int, 0, len(s.Index)+len(t.Index)), s.Index...),
+		Value: append(make(Dense[T], 0, len(s.Index)+len(t.Index)), s.Value...),
+	}
+
+	pos := make(map[int]int)
+	for i, k := range s.Index {
+		pos[k] = i
+	}
+
+	for i, k := range t.Index {
+		p, ok := pos[k]
+		if !ok {
+			out.Index = append(out.Index, k)
+			out.Value = append(out.Value, t.Value[i])
+			continue
+		}
+
+		out.Value[p] = vector.Add(t.Value[i], s.Value[p])
+	}
+
+	return out
+}
+
+// Dim returns the dimension of the matrix.
+func (s *SparseOf[T]) Dim() (int, int) {
+	return s.Rows, s.Cols
+}
+
+// Dense returns the dense matrix.
+func (s *SparseOf[T]) Dense() Dense[T] {
+	out := ZeroOf[T](s.Rows, s.Cols)
+	for i, k := range s.Index {
+		copy(out[k], s.Value[i])
+	}
+
+	return out
+}
+
+// View returns a (Rows, Cols) matrix without copying the values.
+// The rows not in Index share one zero row, so the returned matrix must not be modified in place.
+func (s *SparseOf[T]) View() Dense[T] {
+	zero := make([]T, s.Cols)
+
+	out := make(Dense[T], s.Rows)
+	for i := range out {
+		out[i] = zero
+	}
+
+	for i, k := range s.Index {
+		out[k] = s.Value[i]
+	}
+
+	return out
+}
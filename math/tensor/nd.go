@@ -0,0 +1,472 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// Tensor is an N-dimensional array with flat storage.
+// The element at idx is Data[Offset + sum(idx[i] * Stride[i])].
+type Tensor struct {
+	Data   []float64
+	Shape  []int
+	Stride []int
+	Offset int
+}
+
+// New returns a tensor with the given data and shape.
+func New(data []float64, shape ...int) *Tensor {
+	if size(shape) != len(data) {
+		panic(fmt.Sprintf("invalid shape=%v for size=%v", shape, len(data)))
+	}
+
+	return &Tensor{
+		Data:   data,
+		Shape:  append([]int{}, shape...),
+		Stride: stride(shape),
+	}
+}
+
+// Zeros returns a tensor with all elements 0.
+func Zeros(shape ...int) *Tensor {
+	return New(make([]float64, size(shape)), shape...)
+}
+
+// Ones returns a tensor with all elements 1.
+func Ones(shape ...int) *Tensor {
+	return Zeros(shape...).AddC(1)
+}
+
+// FromMatrix returns a (M, N) tensor from a matrix.
+func FromMatrix(m matrix.Matrix) *Tensor {
+	p, q := m.Dim()
+	return New(matrix.Flatten(m), p, q)
+}
+
+// FromTime returns a (T, N, H) tensor from a time series of matrices.
+func FromTime(xs []matrix.Matrix) *Tensor {
+	T, N, H := len(xs), len(xs[0]), len(xs[0][0])
+	return New(Flatten(xs), T, N, H)
+}
+
+// size returns the number of elements of the shape.
+func size(shape []int) int {
+	s := 1
+	for _, v := range shape {
+		s = s * v
+	}
+
+	return s
+}
+
+// stride returns the row-major strides of the shape.
+func stride(shape []int) []int {
+	out := make([]int, len(shape))
+	s := 1
+	for i := len(shape) - 1; i > -1; i-- {
+		out[i] = s
+		s = s * shape[i]
+	}
+
+	return out
+}
+
+// axis returns the non-negative axis.
+func axis(a, ndim int) int {
+	if a < 0 {
+		a = a + ndim
+	}
+
+	if a < 0 || a >= ndim {
+		panic(fmt.Sprintf("invalid axis=%v for ndim=%v", a, ndim))
+	}
+
+	return a
+}
+
+// each calls f with every index of the shape in row-major order.
+func each(shape []int, f func(idx []int)) {
+	if size(shape) == 0 {
+		return
+	}
+
+	idx := make([]int, len(shape))
+	for {
+		f(idx)
+
+		// next
+		i := len(shape) - 1
+		for ; i > -1; i-- {
+			idx[i]++
+			if idx[i] < shape[i] {
+				break
+			}
+
+			idx[i] = 0
+		}
+
+		if i < 0 {
+			return
+		}
+	}
+}
+
+// NDim returns the number of dimensions.
+func (t *Tensor) NDim() int {
+	return len(t.Shape)
+}
+
+// Size returns the number of elements.
+func (t *Tensor) Size() int {
+	return size(t.Shape)
+}
+
+// index returns the position of idx in Data.
+func (t *Tensor) index(idx []int) int {
+	p := t.Offset
+	for i, v := range idx {
+		p = p + v*t.Stride[i]
+	}
+
+	return p
+}
+
+// At returns the element at idx.
+func (t *Tensor) At(idx ...int) float64 {
+	return t.Data[t.index(idx)]
+}
+
+// Set sets the element at idx to v.
+func (t *Tensor) Set(v float64, idx ...int) {
+	t.Data[t.index(idx)] = v
+}
+
+// IsContiguous returns true if the data is stored in row-major order without gaps.
+func (t *Tensor) IsContiguous() bool {
+	if t.Offset != 0 || len(t.Data) != t.Size() {
+		return false
+	}
+
+	for i, s := range stride(t.Shape) {
+		if t.Shape[i] > 1 && t.Stride[i] != s {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Contiguous returns a tensor with the data copied in row-major order.
+// It returns t itself if t is already contiguous.
+func (t *Tensor) Contiguous() *Tensor {
+	if t.IsContiguous() {
+		return t
+	}
+
+	return t.Clone()
+}
+
+// Clone returns a contiguous copy of t.
+func (t *Tensor) Clone() *Tensor {
+	data := make([]float64, 0, t.Size())
+	each(t.Shape, func(idx []int) {
+		data = append(data, t.At(idx...))
+	})
+
+	return New(data, t.Shape...)
+}
+
+// Flatten returns the elements in row-major order.
+func (t *Tensor) Flatten() []float64 {
+	return t.Clone().Data
+}
+
+// Reshape returns a tensor with the given shape.
+// One of the dimensions may be -1, which is inferred from the size.
+func (t *Tensor) Reshape(shape ...int) *Tensor {
+	shape = append([]int{}, shape...)
+
+	infer, known := -1, 1
+	for i, v := range shape {
+		if v < 0 {
+			infer = i
+			continue
+		}
+
+		known = known * v
+	}
+
+	if infer > -1 && known > 0 {
+		shape[infer] = t.Size() / known
+	}
+
+	c := t.Contiguous()
+	return New(c.Data, shape...)
+}
+
+// Transpose returns a view of t with the axes permuted.
+// If axes is empty, the order of the axes is reversed.
+func (t *Tensor) Transpose(axes ...int) *Tensor {
+	if len(axes) == 0 {
+		for i := t.NDim() - 1; i > -1; i-- {
+			axes = append(axes, i)
+		}
+	}
+
+	if len(axes) != t.NDim() {
+		panic(fmt.Sprintf("invalid axes=%v for ndim=%v", axes, t.NDim()))
+	}
+
+	shape, strides := make([]int, len(axes)), make([]int, len(axes))
+	for i, a := range axes {
+		a = axis(a, t.NDim())
+		shape[i], strides[i] = t.Shape[a], t.Stride[a]
+	}
+
+	return &Tensor{
+		Data:   t.Data,
+		Shape:  shape,
+		Stride: strides,
+		Offset: t.Offset,
+	}
+}
+
+// Slice returns a view of t with the elements in [begin, end) along the axis.
+// It panics if the axis or the range is out of the shape.
+func (t *Tensor) Slice(a, begin, end int) *Tensor {
+	a = axis(a, t.NDim())
+	if begin < 0 || end < begin || end > t.Shape[a] {
+		panic(fmt.Sprintf("invalid range=[%v, %v) for axis=%v of shape=%v", begin, end, a, t.Shape))
+	}
+
+	shape := append([]int{}, t.Shape...)
+	shape[a] = end - begin
+
+	return &Tensor{
+		Data:   t.Data,
+		Shape:  shape,
+		Stride: append([]int{}, t.Stride...),
+		Offset: t.Offset + begin*t.Stride[a],
+	}
+}
+
+// BroadcastTo returns a view of t broadcasted to the shape.
+func (t *Tensor) BroadcastTo(shape ...int) *Tensor {
+	if len(shape) < t.NDim() {
+		panic(fmt.Sprintf("invalid shape=%v for %v", shape, t.Shape))
+	}
+
+	d := len(shape) - t.NDim()
+	strides := make([]int, len(shape))
+	for i := range shape {
+		if i < d {
+			continue // new axis
+		}
+
+		switch t.Shape[i-d] {
+		case shape[i]:
+			strides[i] = t.Stride[i-d]
+		case 1:
+			strides[i] = 0
+		default:
+			panic(fmt.Sprintf("invalid shape=%v for %v", shape, t.Shape))
+		}
+	}
+
+	return &Tensor{
+		Data:   t.Data,
+		Shape:  append([]int{}, shape...),
+		Stride: strides,
+		Offset: t.Offset,
+	}
+}
+
+// Broadcast returns the shape that x and y are broadcasted to.
+func Broadcast(x, y []int) []int {
+	n := max(len(x), len(y))
+
+	out := make([]int, n)
+	for i := 0; i < n; i++ {
+		a, b := 1, 1
+		if j := i - (n - len(x)); j > -1 {
+			a = x[j]
+		}
+
+		if j := i - (n - len(y)); j > -1 {
+			b = y[j]
+		}
+
+		switch {
+		case a == b, b == 1:
+			out[i] = a
+		case a == 1:
+			out[i] = b
+		default:
+			panic(fmt.Sprintf("shapes %v and %v are not broadcastable", x, y))
+		}
+	}
+
+	return out
+}
+
+// F applies a function to each element of the tensor.
+func F(t *Tensor, f func(a float64) float64) *Tensor {
+	data := make([]float64, 0, t.Size())
+	each(t.Shape, func(idx []int) {
+		data = append(data, f(t.At(idx...)))
+	})
+
+	return New(data, t.Shape...)
+}
+
+// F2 applies a function to each element of the broadcasted tensors.
+func F2(x, y *Tensor, f func(a, b float64) float64) *Tensor {
+	shape := Broadcast(x.Shape, y.Shape)
+	bx, by := x.BroadcastTo(shape...), y.BroadcastTo(shape...)
+
+	data := make([]float64, 0, size(shape))
+	each(shape, func(idx []int) {
+		data = append(data, f(bx.At(idx...), by.At(idx...)))
+	})
+
+	return New(data, shape...)
+}
+
+func (t *Tensor) Add(y *Tensor) *Tensor {
+	return F2(t, y, func(a, b float64) float64 { return a + b })
+}
+
+func (t *Tensor) Sub(y *Tensor) *Tensor {
+	return F2(t, y, func(a, b float64) float64 { return a - b })
+}
+
+func (t *Tensor) Mul(y *Tensor) *Tensor {
+	return F2(t, y, func(a, b float64) float64 { return a * b })
+}
+
+func (t *Tensor) Div(y *Tensor) *Tensor {
+	return F2(t, y, func(a, b float64) float64 { return a / b })
+}
+
+func (t *Tensor) AddC(c float64) *Tensor {
+	return F(t, func(v float64) float64 { return c + v })
+}
+
+func (t *Tensor) MulC(c float64) *Tensor {
+	return F(t, func(v float64) float64 { return c * v })
+}
+
+// reduce applies f along the axis and removes the axis.
+func (t *Tensor) reduce(a int, init float64, f func(acc, v float64) float64) *Tensor {
+	a = axis(a, t.NDim())
+
+	shape := make([]int, 0, t.NDim()-1)
+	shape = append(shape, t.Shape[:a]...)
+	shape = append(shape, t.Shape[a+1:]...)
+
+	data := make([]float64, 0, size(shape))
+	full := make([]int, t.NDim())
+	each(shape, func(idx []int) {
+		copy(full[:a], idx[:a])
+		copy(full[a+1:], idx[a:])
+
+		acc := init
+		for i := 0; i < t.Shape[a]; i++ {
+			full[a] = i
+			acc = f(acc, t.At(full...))
+		}
+
+		data = append(data, acc)
+	})
+
+	return New(data, shape...)
+}
+
+// SumAxis returns the sum along the axis.
+func (t *Tensor) SumAxis(a int) *Tensor {
+	return t.reduce(a, 0, func(acc, v float64) float64 { return acc + v })
+}
+
+// MeanAxis returns the mean along the axis.
+func (t *Tensor) MeanAxis(a int) *Tensor {
+	n := float64(t.Shape[axis(a, t.NDim())])
+	return t.SumAxis(a).MulC(1.0 / n)
+}
+
+// MaxAxis returns the maximum value along the axis.
+func (t *Tensor) MaxAxis(a int) *Tensor {
+	return t.reduce(a, math.Inf(-1), math.Max)
+}
+
+// Sum returns the sum of all elements.
+func (t *Tensor) Sum() float64 {
+	var sum float64
+	each(t.Shape, func(idx []int) {
+		sum = sum + t.At(idx...)
+	})
+
+	return sum
+}
+
+// Mean returns the average of all elements.
+func (t *Tensor) Mean() float64 {
+	return t.Sum() / float64(t.Size())
+}
+
+// Max returns the maximum value of all elements.
+func (t *Tensor) Max() float64 {
+	return t.Reshape(-1).MaxAxis(0).Data[0]
+}
+
+// Matrix returns the matrix of a 2D tensor.
+func (t *Tensor) Matrix() matrix.Matrix {
+	if t.NDim() != 2 {
+		panic(fmt.Sprintf("invalid ndim=%v", t.NDim()))
+	}
+
+	return matrix.Reshape(matrix.New(t.Flatten()), t.Shape[0], t.Shape[1])
+}
+
+// Time returns the time series of matrices of a 3D tensor.
+func (t *Tensor) Time() []matrix.Matrix {
+	if t.NDim() != 3 {
+		panic(fmt.Sprintf("invalid ndim=%v", t.NDim()))
+	}
+
+	out := make([]matrix.Matrix, t.Shape[0])
+	for i := range out {
+		out[i] = t.Slice(0, i, i+1).Reshape(t.Shape[1], t.Shape[2]).Matrix()
+	}
+
+	return out
+}
+
+// String returns the nested representation of t.
+func (t *Tensor) String() string {
+	if t.NDim() == 0 {
+		return fmt.Sprint(t.Data[t.Offset])
+	}
+
+	if t.NDim() == 1 {
+		return fmt.Sprint(t.Flatten())
+	}
+
+	s := "["
+	for i := 0; i < t.Shape[0]; i++ {
+		if i > 0 {
+			s = s + " "
+		}
+
+		sub := t.Slice(0, i, i+1)
+		s = s + (&Tensor{
+			Data:   sub.Data,
+			Shape:  sub.Shape[1:],
+			Stride: sub.Stride[1:],
+			Offset: sub.Offset,
+		}).String()
+	}
+
+	return s + "]"
+}
@@ -0,0 +1,267 @@
+package tensor_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/tensor"
+)
+
+func ExampleNew() {
+	x := tensor.New([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	fmt.Println(x)
+	fmt.Println(x.Shape, x.Stride, x.NDim(), x.Size())
+	fmt.Println(x.At(1, 2))
+
+	x.Set(10, 0, 1)
+	fmt.Println(x)
+
+	// Output:
+	// [[1 2 3] [4 5 6]]
+	// [2 3] [3 1] 2 6
+	// 6
+	// [[1 10 3] [4 5 6]]
+}
+
+func ExampleNew_invalid() {
+	defer func() {
+		if rec := recover(); rec != nil {
+			fmt.Println(rec)
+		}
+	}()
+
+	tensor.New([]float64{1, 2, 3}, 2, 2)
+
+	// Output:
+	// invalid shape=[2 2] for size=3
+}
+
+func ExampleZeros() {
+	fmt.Println(tensor.Zeros(2, 2, 2))
+	fmt.Println(tensor.Ones(2, 3))
+
+	// Output:
+	// [[[0 0] [0 0]] [[0 0] [0 0]]]
+	// [[1 1 1] [1 1 1]]
+}
+
+func ExampleTensor_Reshape() {
+	x := tensor.New([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, 2, 3, 2)
+	fmt.Println(x.Reshape(3, 4))
+	fmt.Println(x.Reshape(2, -1))
+	fmt.Println(x.Reshape(-1).Shape)
+
+	// Output:
+	// [[1 2 3 4] [5 6 7 8] [9 10 11 12]]
+	// [[1 2 3 4 5 6] [7 8 9 10 11 12]]
+	// [12]
+}
+
+func ExampleTensor_Transpose() {
+	x := tensor.New([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	fmt.Println(x.Transpose())
+	fmt.Println(x.Transpose().IsContiguous())
+	fmt.Println(x.Transpose().Reshape(-1))
+
+	// (N, T, H) -> (T, N, H)
+	y := tensor.New([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, 2, 3, 2)
+	fmt.Println(y.Transpose(1, 0, 2))
+	fmt.Println(y.Transpose(1, 0, 2).Shape)
+	fmt.Println(y.Transpose(0, -1, 1).Shape)
+
+	// Output:
+	// [[1 4] [2 5] [3 6]]
+	// false
+	// [1 4 2 5 3 6]
+	// [[[1 2] [7 8]] [[3 4] [9 10]] [[5 6] [11 12]]]
+	// [3 2 2]
+	// [2 2 3]
+}
+
+func ExampleTensor_Slice() {
+	x := tensor.New([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, 3, 3)
+	fmt.Println(x.Slice(0, 1, 3))
+	fmt.Println(x.Slice(1, 0, 2))
+	fmt.Println(x.Slice(0, 1, 3).Slice(1, 1, 2))
+
+	// view
+	x.Slice(1, 2, 3).Set(0, 0, 0)
+	fmt.Println(x)
+
+	// Output:
+	// [[4 5 6] [7 8 9]]
+	// [[1 2] [4 5] [7 8]]
+	// [[5] [8]]
+	// [[1 2 0] [4 5 6] [7 8 9]]
+}
+
+func ExampleTensor_Slice_invalid() {
+	x := tensor.New([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	for _, args := range [][]int{{2, 0, 1}, {1, 2, 4}, {0, 1, 0}, {0, -1, 1}} {
+		func() {
+			defer func() {
+				if rec := recover(); rec != nil {
+					fmt.Println(rec)
+				}
+			}()
+
+			x.Slice(args[0], args[1], args[2])
+		}()
+	}
+
+	// Output:
+	// invalid axis=2 for ndim=2
+	// invalid range=[2, 4) for axis=1 of shape=[2 3]
+	// invalid range=[1, 0) for axis=0 of shape=[2 3]
+	// invalid range=[-1, 1) for axis=0 of shape=[2 3]
+}
+
+func ExampleTensor_SumAxis() {
+	x := tensor.New([]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, 2, 3, 2)
+	fmt.Println(x.SumAxis(0))
+	fmt.Println(x.SumAxis(1))
+	fmt.Println(x.SumAxis(-1))
+	fmt.Println(x.SumAxis(0).SumAxis(0).SumAxis(0))
+	fmt.Println(x.Sum(), x.Mean())
+
+	// Output:
+	// [[8 10] [12 14] [16 18]]
+	// [[9 12] [27 30]]
+	// [[3 7 11] [15 19 23]]
+	// 78
+	// 78 6.5
+}
+
+func ExampleTensor_MeanAxis() {
+	x := tensor.New([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	fmt.Println(x.MeanAxis(0))
+	fmt.Println(x.MeanAxis(1))
+
+	// Output:
+	// [2.5 3.5 4.5]
+	// [2 5]
+}
+
+func ExampleTensor_MaxAxis() {
+	x := tensor.New([]float64{1, 9, 3, 4, -5, 6}, 2, 3)
+	fmt.Println(x.MaxAxis(0))
+	fmt.Println(x.MaxAxis(1))
+	fmt.Println(x.Transpose().MaxAxis(0))
+	fmt.Println(x.Max())
+
+	// Output:
+	// [4 9 6]
+	// [9 6]
+	// [9 6]
+	// 9
+}
+
+func ExampleBroadcast() {
+	fmt.Println(tensor.Broadcast([]int{2, 3}, []int{3}))
+	fmt.Println(tensor.Broadcast([]int{2, 1, 4}, []int{3, 1}))
+	fmt.Println(tensor.Broadcast([]int{1}, []int{2, 2}))
+
+	// Output:
+	// [2 3]
+	// [2 3 4]
+	// [2 2]
+}
+
+func ExampleBroadcast_invalid() {
+	defer func() {
+		if rec := recover(); rec != nil {
+			fmt.Println(rec)
+		}
+	}()
+
+	tensor.Broadcast([]int{2, 3}, []int{2})
+
+	// Output:
+	// shapes [2 3] and [2] are not broadcastable
+}
+
+func ExampleTensor_Add() {
+	x := tensor.New([]float64{1, 2, 3, 4, 5, 6}, 2, 3)
+	b := tensor.New([]float64{10, 20, 30}, 3)
+	c := tensor.New([]float64{100, 200}, 2, 1)
+
+	fmt.Println(x.Add(b))
+	fmt.Println(x.Sub(c))
+	fmt.Println(x.Mul(b))
+	fmt.Println(x.Div(c))
+	fmt.Println(b.Add(c))
+	fmt.Println(x.AddC(1).MulC(2))
+
+	// Output:
+	// [[11 22 33] [14 25 36]]
+	// [[-99 -98 -97] [-196 -195 -194]]
+	// [[10 40 90] [40 100 180]]
+	// [[0.01 0.02 0.03] [0.02 0.025 0.03]]
+	// [[110 120 130] [210 220 230]]
+	// [[4 6 8] [10 12 14]]
+}
+
+func ExampleTensor_BroadcastTo() {
+	x := tensor.New([]float64{1, 2}, 2, 1)
+	y := x.BroadcastTo(3, 2, 2)
+	fmt.Println(y)
+	fmt.Println(y.Stride)
+
+	// Output:
+	// [[[1 1] [2 2]] [[1 1] [2 2]] [[1 1] [2 2]]]
+	// [0 1 0]
+}
+
+func ExampleF() {
+	x := tensor.New([]float64{1, 2, 3, 4}, 2, 2)
+	fmt.Println(tensor.F(x.Transpose(), func(v float64) float64 { return v * v }))
+
+	// Output:
+	// [[1 9] [4 16]]
+}
+
+func ExampleFromMatrix() {
+	m := matrix.New([]float64{1, 2, 3}, []float64{4, 5, 6})
+	x := tensor.FromMatrix(m)
+	fmt.Println(x.Shape)
+	fmt.Println(x.Transpose().Matrix())
+
+	// Output:
+	// [2 3]
+	// [[1 4] [2 5] [3 6]]
+}
+
+func ExampleFromTime() {
+	// (T, N, H) = (2, 3, 1)
+	xs := []matrix.Matrix{
+		{{1}, {2}, {3}},
+		{{4}, {5}, {6}},
+	}
+
+	x := tensor.FromTime(xs)
+	fmt.Println(x.Shape)
+	fmt.Println(x.Time())
+
+	// (N, T, H)
+	fmt.Println(x.Transpose(1, 0, 2).Clone().Time())
+
+	// Output:
+	// [2 3 1]
+	// [[[1] [2] [3]] [[4] [5] [6]]]
+	// [[[1] [4]] [[2] [5]] [[3] [6]]]
+}
+
+func ExampleTensor_Clone() {
+	x := tensor.New([]float64{1, 2, 3, 4}, 2, 2)
+	y := x.Clone()
+	y.Set(10, 0, 0)
+
+	fmt.Println(x)
+	fmt.Println(y)
+	fmt.Println(x.Contiguous() == x)
+
+	// Output:
+	// [[1 2] [3 4]]
+	// [[10 2] [3 4]]
+	// true
+}
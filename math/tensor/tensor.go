@@ -1,6 +1,9 @@
 package tensor
 
-import "github.com/itsubaki/neu/math/matrix"
+import (
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
+)
 
 func Zero(m, n, o int) []matrix.Matrix {
 	out := make([]matrix.Matrix, m)
@@ -11,8 +14,8 @@ func Zero(m, n, o int) []matrix.Matrix {
 	return out
 }
 
-func ZeroLike(x []matrix.Matrix) []matrix.Matrix {
-	out := make([]matrix.Matrix, len(x))
+func ZeroLike[T vector.Float](x []matrix.Dense[T]) []matrix.Dense[T] {
+	out := make([]matrix.Dense[T], len(x))
 	for i := 0; i < len(x); i++ {
 		out[i] = matrix.ZeroLike(x[i])
 	}
@@ -36,8 +39,8 @@ func OneHot(ts []matrix.Matrix, size int) []matrix.Matrix {
 	return out
 }
 
-func Add(x, y []matrix.Matrix) []matrix.Matrix {
-	out := make([]matrix.Matrix, len(x))
+func Add[T vector.Float](x, y []matrix.Dense[T]) []matrix.Dense[T] {
+	out := make([]matrix.Dense[T], len(x))
 	for i := 0; i < len(x); i++ {
 		out[i] = x[i].Add(y[i])
 	}
@@ -45,8 +48,8 @@ func Add(x, y []matrix.Matrix) []matrix.Matrix {
 	return out
 }
 
-func Mul(x, y []matrix.Matrix) []matrix.Matrix {
-	out := make([]matrix.Matrix, len(x))
+func Mul[T vector.Float](x, y []matrix.Dense[T]) []matrix.Dense[T] {
+	out := make([]matrix.Dense[T], len(x))
 	for i := 0; i < len(x); i++ {
 		out[i] = x[i].Mul(y[i])
 	}
@@ -71,10 +74,10 @@ func SumAxis0(hr []matrix.Matrix) matrix.Matrix {
 	return out
 }
 
-func Concat(a, b []matrix.Matrix) []matrix.Matrix {
-	out := make([]matrix.Matrix, len(a))
+func Concat[T vector.Float](a, b []matrix.Dense[T]) []matrix.Dense[T] {
+	out := make([]matrix.Dense[T], len(a))
 	for t := 0; t < len(a); t++ {
-		out[t] = make(matrix.Matrix, len(a[t]))
+		out[t] = make(matrix.Dense[T], len(a[t]))
 
 		for i := 0; i < len(a[t]); i++ {
 			out[t][i] = append(out[t][i], a[t][i]...)
@@ -88,10 +91,10 @@ func Concat(a, b []matrix.Matrix) []matrix.Matrix {
 	return out
 }
 
-func Split(dout []matrix.Matrix, H int) ([]matrix.Matrix, []matrix.Matrix) {
-	a, b := make([]matrix.Matrix, len(dout)), make([]matrix.Matrix, len(dout))
+func Split[T vector.Float](dout []matrix.Dense[T], H int) ([]matrix.Dense[T], []matrix.Dense[T]) {
+	a, b := make([]matrix.Dense[T], len(dout)), make([]matrix.Dense[T], len(dout))
 	for t := range dout {
-		a[t], b[t] = matrix.New(), matrix.New()
+		a[t], b[t] = make(matrix.Dense[T], 0), make(matrix.Dense[T], 0)
 		for _, r := range dout[t] {
 			a[t] = append(a[t], r[:H])
 			b[t] = append(b[t], r[H:])
@@ -110,8 +113,8 @@ func Repeat(m matrix.Matrix, T int) []matrix.Matrix {
 	return out
 }
 
-func Flatten(m []matrix.Matrix) []float64 {
-	flatten := make([]float64, 0)
+func Flatten[T vector.Float](m []matrix.Dense[T]) []T {
+	flatten := make([]T, 0)
 	for _, s := range m {
 		flatten = append(flatten, matrix.Flatten(s)...)
 	}
@@ -119,7 +122,7 @@ func Flatten(m []matrix.Matrix) []float64 {
 	return flatten
 }
 
-func Argmax(score []matrix.Matrix) int {
+func Argmax[T vector.Float](score []matrix.Dense[T]) int {
 	flatten := Flatten(score)
 
 	max := flatten[0]
@@ -134,8 +137,8 @@ func Argmax(score []matrix.Matrix) int {
 	return arg
 }
 
-func Reverse(m []matrix.Matrix) []matrix.Matrix {
-	out := make([]matrix.Matrix, len(m))
+func Reverse[T vector.Float](m []matrix.Dense[T]) []matrix.Dense[T] {
+	out := make([]matrix.Dense[T], len(m))
 	for i := 0; i < len(m); i++ {
 		out[i] = m[len(m)-1-i]
 	}
@@ -7,6 +7,11 @@ import (
 	"github.com/itsubaki/neu/math/rand"
 )
 
+// Float is a constraint that permits the floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
 func Zero(n int) []float64 {
 	return make([]float64, n)
 }
@@ -42,7 +47,7 @@ func Randn(n int, s ...randv2.Source) []float64 {
 	return out
 }
 
-func Int(v []float64) []int {
+func Int[T Float](v []T) []int {
 	out := make([]int, len(v))
 	for i, e := range v {
 		out[i] = int(e)
@@ -51,7 +56,7 @@ func Int(v []float64) []int {
 	return out
 }
 
-func Max[T int | float64](v []T) T {
+func Max[T int | Float](v []T) T {
 	max := v[0]
 	for _, e := range v {
 		if e > max {
@@ -62,8 +67,8 @@ func Max[T int | float64](v []T) T {
 	return max
 }
 
-func Argmax(v []float64) int {
-	var max float64
+func Argmax[T Float](v []T) int {
+	var max T
 	var arg int
 	for i, e := range v {
 		if e > max {
@@ -75,8 +80,8 @@ func Argmax(v []float64) int {
 	return arg
 }
 
-func Add(v, w []float64) []float64 {
-	out := make([]float64, len(v))
+func Add[T Float](v, w []T) []T {
+	out := make([]T, len(v))
 	for i := range v {
 		out[i] = v[i] + w[i]
 	}
@@ -84,8 +89,8 @@ func Add(v, w []float64) []float64 {
 	return out
 }
 
-func Mul(v []float64, a float64) []float64 {
-	out := make([]float64, len(v))
+func Mul[T Float](v []T, a T) []T {
+	out := make([]T, len(v))
 	for i := range v {
 		out[i] = v[i] * a
 	}
@@ -93,8 +98,8 @@ func Mul(v []float64, a float64) []float64 {
 	return out
 }
 
-func Div(v []float64, a float64) []float64 {
-	out := make([]float64, len(v))
+func Div[T Float](v []T, a T) []T {
+	out := make([]T, len(v))
 	for i := range v {
 		out[i] = v[i] / a
 	}
@@ -102,17 +107,17 @@ func Div(v []float64, a float64) []float64 {
 	return out
 }
 
-func Abs(v []float64) []float64 {
-	out := make([]float64, len(v))
+func Abs[T Float](v []T) []T {
+	out := make([]T, len(v))
 	for i, e := range v {
-		out[i] = math.Abs(e)
+		out[i] = T(math.Abs(float64(e)))
 	}
 
 	return out
 }
 
-func Sum(v []float64) float64 {
-	var sum float64
+func Sum[T Float](v []T) T {
+	var sum T
 	for _, e := range v {
 		sum += e
 	}
@@ -120,12 +125,12 @@ func Sum(v []float64) float64 {
 	return sum
 }
 
-func Mean(v []float64) float64 {
-	return Sum(v) / float64(len(v))
+func Mean[T Float](v []T) T {
+	return Sum(v) / T(len(v))
 }
 
-func Pow2(v []float64) []float64 {
-	out := make([]float64, len(v))
+func Pow2[T Float](v []T) []T {
+	out := make([]T, len(v))
 	for i, e := range v {
 		out[i] = e * e
 	}
@@ -133,11 +138,11 @@ func Pow2(v []float64) []float64 {
 	return out
 }
 
-func Cos(x, y []float64) float64 {
-	xps := math.Sqrt(Sum(Pow2(x)) + 1e-8)
-	yps := math.Sqrt(Sum(Pow2(y)) + 1e-8)
+func Cos[T Float](x, y []T) T {
+	xps := T(math.Sqrt(float64(Sum(Pow2(x)) + 1e-8)))
+	yps := T(math.Sqrt(float64(Sum(Pow2(y)) + 1e-8)))
 
-	var sum float64
+	var sum T
 	for i := range x {
 		sum += x[i] * y[i]
 	}
@@ -82,6 +82,14 @@ func ExampleSum() {
 	// 6
 }
 
+func ExampleSum_float32() {
+	v := []float32{0.1, 0.2, 0.3}
+	fmt.Println(vector.Sum(v))
+
+	// Output:
+	// 0.6
+}
+
 func ExampleMean() {
 	v := []float64{1, 2, 3}
 	fmt.Println(vector.Mean(v))
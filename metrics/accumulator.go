@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+type Predictor interface {
+	Predict(x matrix.Matrix, opts ...layer.Opts) matrix.Matrix
+}
+
+// Accumulator accumulates the metrics over the batches of a dataset.
+type Accumulator struct {
+	Confusion *Confusion
+	K         int // the k of TopKAccuracy
+	size      int
+	topk      int
+	loss      float64
+	score     []float64 // for ROCAUC
+	label     []bool
+}
+
+func NewAccumulator(classes, k int) *Accumulator {
+	return &Accumulator{
+		Confusion: NewConfusion(classes),
+		K:         k,
+	}
+}
+
+// Evaluate returns the metrics of the model over all of x, which is predicted in batches.
+// The number of the classes is of t. See Classes.
+func Evaluate(m Predictor, x, t matrix.Matrix, batchSize, k int) *Accumulator {
+	a := NewAccumulator(Classes(t), k)
+	for begin := 0; begin < len(x); begin += batchSize {
+		end := min(begin+batchSize, len(x))
+		a.Add(m.Predict(x[begin:end]), t[begin:end])
+	}
+
+	return a
+}
+
+// Add adds the predictions of a batch. See Labels for y and t.
+func (a *Accumulator) Add(y, t matrix.Matrix) {
+	a.Confusion.Add(y, t)
+	a.topk += topK(y, t, a.K)
+	a.loss += LogLoss(y, t) * float64(len(y))
+	a.size += len(y)
+
+	if _, n := y.Dim(); n == 1 {
+		for i := range y {
+			a.score = append(a.score, y[i][0])
+			a.label = append(a.label, t[i][0] > 0.5)
+		}
+	}
+}
+
+// Size returns the number of the samples.
+func (a *Accumulator) Size() int {
+	return a.size
+}
+
+// Accuracy returns the accuracy of the samples.
+func (a *Accumulator) Accuracy() float64 {
+	return a.Confusion.Accuracy()
+}
+
+// TopKAccuracy returns the top-k accuracy of the samples.
+func (a *Accumulator) TopKAccuracy() float64 {
+	return div(a.topk, a.size)
+}
+
+// LogLoss returns the mean log-loss of the samples.
+func (a *Accumulator) LogLoss() float64 {
+	if a.size == 0 {
+		return 0
+	}
+
+	return a.loss / float64(a.size)
+}
+
+// ROCAUC returns the ROC-AUC of the samples. It returns NaN for the outputs with more than one column.
+func (a *Accumulator) ROCAUC() float64 {
+	return auc(a.score, a.label)
+}
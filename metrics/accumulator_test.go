@@ -0,0 +1,65 @@
+package metrics_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/metrics"
+	"github.com/itsubaki/neu/model"
+)
+
+func ExampleAccumulator() {
+	y := matrix.New([]float64{0.1, 0.5, 0.4}, []float64{0.3, 0.3, 0.4}, []float64{0.6, 0.3, 0.1}, []float64{0.2, 0.5, 0.3})
+	t := matrix.New([]float64{0, 0, 1}, []float64{0, 1, 0}, []float64{0, 0, 1}, []float64{0, 1, 0})
+
+	a := metrics.NewAccumulator(3, 2)
+	a.Add(y[:3], t[:3])
+	a.Add(y[3:], t[3:])
+
+	fmt.Printf("%v %.4f %.4f %.4f\n", a.Size(), a.Accuracy(), a.TopKAccuracy(), a.LogLoss())
+	fmt.Printf("%.4f %.4f\n", metrics.TopKAccuracy(y, t, 2), metrics.LogLoss(y, t))
+
+	// Output:
+	// 4 0.2500 0.7500 1.1180
+	// 0.7500 1.1180
+}
+
+func ExampleAccumulator_binary() {
+	y := matrix.New([]float64{0.1}, []float64{0.4}, []float64{0.35}, []float64{0.8})
+	t := matrix.New([]float64{0}, []float64{0}, []float64{1}, []float64{1})
+
+	a := metrics.NewAccumulator(2, 1)
+	a.Add(y[:1], t[:1])
+	a.Add(y[1:], t[1:])
+
+	fmt.Printf("%.4f %.4f\n", a.ROCAUC(), a.Confusion.MacroF1())
+
+	// Output:
+	// 0.7500 0.3333
+}
+
+func ExampleEvaluate() {
+	s := rand.Const(1)
+	m := model.NewSequential([]model.Layer{
+		&layer.Affine{W: matrix.Randn(2, 3, s), B: matrix.Zero(1, 3)},
+		&layer.SoftmaxWithLoss{},
+	}, s)
+
+	x := matrix.New([]float64{0, 0}, []float64{1, 0}, []float64{0, 1}, []float64{1, 1}, []float64{0.5, 0.5})
+	t := matrix.New([]float64{1, 0, 0}, []float64{0, 1, 0}, []float64{0, 0, 1}, []float64{0, 0, 1}, []float64{1, 0, 0})
+
+	// the remainder batch is evaluated too
+	a := metrics.Evaluate(m, x, t, 2, 2)
+	fmt.Println(a.Size())
+	fmt.Println(a.Confusion)
+	fmt.Printf("%.4f %.4f\n", a.Accuracy(), a.TopKAccuracy())
+
+	// Output:
+	// 5
+	//   0:    1    1    0
+	//   1:    0    1    0
+	//   2:    0    2    0
+	// 0.4000 0.8000
+}
@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// Confusion is the confusion matrix.
+// Count[i][j] is the number of the samples of the class i that are predicted as the class j.
+type Confusion struct {
+	Count [][]int
+}
+
+func NewConfusion(classes int) *Confusion {
+	count := make([][]int, classes)
+	for i := range count {
+		count[i] = make([]int, classes)
+	}
+
+	return &Confusion{
+		Count: count,
+	}
+}
+
+// Add adds the predictions of a batch. See Labels for y and t.
+func (c *Confusion) Add(y, t matrix.Matrix) {
+	c.AddLabels(Labels(y, t))
+}
+
+// AddLabels adds the predicted and the true classes.
+func (c *Confusion) AddLabels(pred, label []int) {
+	for i := range pred {
+		c.Count[label[i]][pred[i]]++
+	}
+}
+
+// Total returns the number of the samples.
+func (c *Confusion) Total() int {
+	var sum int
+	for i := range c.Count {
+		for j := range c.Count[i] {
+			sum += c.Count[i][j]
+		}
+	}
+
+	return sum
+}
+
+// Accuracy returns the ratio of the correct predictions.
+func (c *Confusion) Accuracy() float64 {
+	var tp int
+	for i := range c.Count {
+		tp += c.Count[i][i]
+	}
+
+	return div(tp, c.Total())
+}
+
+// Precision returns the precision of each class, tp / (tp + fp).
+// The precision of the class without predictions is 0.
+func (c *Confusion) Precision() []float64 {
+	out := make([]float64, len(c.Count))
+	for j := range c.Count {
+		var predicted int
+		for i := range c.Count {
+			predicted += c.Count[i][j]
+		}
+
+		out[j] = div(c.Count[j][j], predicted)
+	}
+
+	return out
+}
+
+// Recall returns the recall of each class, tp / (tp + fn).
+// The recall of the class without samples is 0.
+func (c *Confusion) Recall() []float64 {
+	out := make([]float64, len(c.Count))
+	for i := range c.Count {
+		var actual int
+		for j := range c.Count[i] {
+			actual += c.Count[i][j]
+		}
+
+		out[i] = div(c.Count[i][i], actual)
+	}
+
+	return out
+}
+
+// F1 returns the F1 score of each class, the harmonic mean of the precision and the recall.
+func (c *Confusion) F1() []float64 {
+	p, r := c.Precision(), c.Recall()
+
+	out := make([]float64, len(c.Count))
+	for i := range out {
+		out[i] = f1(p[i], r[i])
+	}
+
+	return out
+}
+
+// MacroPrecision returns the mean of the precision of the classes.
+func (c *Confusion) MacroPrecision() float64 { return mean(c.Precision()) }
+
+// MacroRecall returns the mean of the recall of the classes.
+func (c *Confusion) MacroRecall() float64 { return mean(c.Recall()) }
+
+// MacroF1 returns the mean of the F1 score of the classes.
+func (c *Confusion) MacroF1() float64 { return mean(c.F1()) }
+
+// MicroPrecision returns the precision of the sum of tp and fp of the classes.
+// It equals the accuracy, since each sample has one predicted class and one true class.
+func (c *Confusion) MicroPrecision() float64 { return c.Accuracy() }
+
+// MicroRecall returns the recall of the sum of tp and fn of the classes.
+// It equals the accuracy, since each sample has one predicted class and one true class.
+func (c *Confusion) MicroRecall() float64 { return c.Accuracy() }
+
+// MicroF1 returns the F1 score of MicroPrecision and MicroRecall.
+func (c *Confusion) MicroF1() float64 { return f1(c.MicroPrecision(), c.MicroRecall()) }
+
+// String returns the confusion matrix with the true classes in rows and the predicted classes in columns.
+func (c *Confusion) String() string {
+	var sb strings.Builder
+	for i := range c.Count {
+		s := make([]string, len(c.Count[i]))
+		for j := range c.Count[i] {
+			s[j] = fmt.Sprintf("%4d", c.Count[i][j])
+		}
+
+		fmt.Fprintf(&sb, "%3d: %s\n", i, strings.Join(s, " "))
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func f1(p, r float64) float64 {
+	if p+r == 0 {
+		return 0
+	}
+
+	return 2 * p * r / (p + r)
+}
+
+func div(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+
+	return float64(a) / float64(b)
+}
+
+func mean(v []float64) float64 {
+	if len(v) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, e := range v {
+		sum += e
+	}
+
+	return sum / float64(len(v))
+}
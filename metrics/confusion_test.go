@@ -0,0 +1,81 @@
+package metrics_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/metrics"
+)
+
+func ExampleConfusion() {
+	c := metrics.NewConfusion(3)
+	c.AddLabels([]int{0, 1, 1, 1, 2, 0}, []int{0, 0, 1, 1, 2, 2})
+
+	fmt.Println(c)
+	fmt.Printf("%.4f\n", c.Precision())
+	fmt.Printf("%.4f\n", c.Recall())
+	fmt.Printf("%.4f\n", c.F1())
+
+	// Output:
+	//   0:    1    1    0
+	//   1:    0    2    0
+	//   2:    1    0    1
+	// [0.5000 0.6667 1.0000]
+	// [0.5000 1.0000 0.5000]
+	// [0.5000 0.8000 0.6667]
+}
+
+func ExampleConfusion_average() {
+	c := metrics.NewConfusion(3)
+	c.AddLabels([]int{0, 1, 1, 1, 2, 0}, []int{0, 0, 1, 1, 2, 2})
+
+	fmt.Printf("%v %.4f\n", c.Total(), c.Accuracy())
+	fmt.Printf("%.4f %.4f %.4f\n", c.MacroPrecision(), c.MacroRecall(), c.MacroF1())
+	fmt.Printf("%.4f %.4f %.4f\n", c.MicroPrecision(), c.MicroRecall(), c.MicroF1())
+
+	// Output:
+	// 6 0.6667
+	// 0.7222 0.6667 0.6556
+	// 0.6667 0.6667 0.6667
+}
+
+func ExampleConfusion_empty() {
+	c := metrics.NewConfusion(0)
+
+	fmt.Printf("%v %.4f\n", c.Total(), c.Accuracy())
+	fmt.Printf("%.4f %.4f %.4f\n", c.MacroPrecision(), c.MacroRecall(), c.MacroF1())
+	fmt.Printf("%.4f %.4f %.4f\n", c.MicroPrecision(), c.MicroRecall(), c.MicroF1())
+
+	// Output:
+	// 0 0.0000
+	// 0.0000 0.0000 0.0000
+	// 0.0000 0.0000 0.0000
+}
+
+func ExampleConfusion_Add() {
+	y := matrix.New([]float64{2, 1, 0}, []float64{0, 3, 1}, []float64{0, 1, 2})
+	t := matrix.New([]float64{1, 0, 0}, []float64{0, 0, 1}, []float64{0, 0, 1})
+
+	c := metrics.NewConfusion(3)
+	c.Add(y, t)
+	fmt.Println(c)
+
+	// Output:
+	//   0:    1    0    0
+	//   1:    0    0    0
+	//   2:    0    1    1
+}
+
+func ExampleConfusion_binary() {
+	// the scores of SigmoidWithLoss
+	y := matrix.New([]float64{-1}, []float64{2}, []float64{0.5}, []float64{-3})
+	t := matrix.New([]float64{0}, []float64{1}, []float64{0}, []float64{1})
+
+	c := metrics.NewConfusion(2)
+	c.Add(y, t)
+	fmt.Println(c)
+
+	// Output:
+	//   0:    1    1
+	//   1:    1    1
+}
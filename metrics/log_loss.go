@@ -0,0 +1,13 @@
+package metrics
+
+import (
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// LogLoss returns the mean cross entropy of the softmax of y, or the sigmoid of y for the binary outputs.
+// It is the same as the loss of SoftmaxWithLoss and SigmoidWithLoss. See Labels for y and t.
+func LogLoss(y, t matrix.Matrix) float64 {
+	y, t = binary(y, t)
+	return (&layer.SoftmaxWithLoss{}).Forward(y, t)[0][0]
+}
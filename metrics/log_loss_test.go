@@ -0,0 +1,33 @@
+package metrics_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/metrics"
+)
+
+func ExampleLogLoss() {
+	y := matrix.New([]float64{0, 0}, []float64{2, -1})
+	t := matrix.New([]float64{1, 0}, []float64{1, 0})
+
+	fmt.Printf("%.4f\n", metrics.LogLoss(y, t))
+	fmt.Printf("%.4f\n", (&layer.SoftmaxWithLoss{}).Forward(y, t))
+
+	// Output:
+	// 0.3709
+	// [[0.3709]]
+}
+
+func ExampleLogLoss_binary() {
+	y := matrix.New([]float64{0}, []float64{2}, []float64{-1})
+	t := matrix.New([]float64{1}, []float64{1}, []float64{0})
+
+	fmt.Printf("%.4f\n", metrics.LogLoss(y, t))
+	fmt.Printf("%.4f\n", (&layer.SigmoidWithLoss{}).Forward(y, t))
+
+	// Output:
+	// 0.3778
+	// [[0.3778]]
+}
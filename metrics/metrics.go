@@ -0,0 +1,33 @@
+package metrics
+
+import "github.com/itsubaki/neu/math/matrix"
+
+// Labels returns the predicted and the true classes of each row.
+// y is the output of Predict, i.e. the scores before the softmax, and t is the one-hot labels.
+// For the binary outputs with one column, like SigmoidWithLoss, the class is 1 if y > 0 and t > 0.5.
+func Labels(y, t matrix.Matrix) ([]int, []int) {
+	y, t = binary(y, t)
+	return y.Argmax(), t.Argmax()
+}
+
+// Classes returns the number of the classes of the labels, which is 2 for the binary labels with one column.
+func Classes(t matrix.Matrix) int {
+	_, n := t.Dim()
+	return max(n, 2)
+}
+
+// binary returns the scores and the labels with two columns, [0, y] and [1 - t, t], if they have one column.
+// softmax([0, y]) equals [1 - sigmoid(y), sigmoid(y)], so the binary outputs are the same as the two classes.
+func binary(y, t matrix.Matrix) (matrix.Matrix, matrix.Matrix) {
+	if _, n := y.Dim(); n != 1 {
+		return y, t
+	}
+
+	y2, t2 := matrix.Zero(len(y), 2), matrix.Zero(len(t), 2)
+	for i := range y {
+		y2[i][1] = y[i][0]
+		t2[i][0], t2[i][1] = 1-t[i][0], t[i][0]
+	}
+
+	return y2, t2
+}
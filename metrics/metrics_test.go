@@ -0,0 +1,30 @@
+package metrics_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/metrics"
+)
+
+func ExampleLabels() {
+	y := matrix.New([]float64{2, 1, 0}, []float64{0, 3, 1})
+	t := matrix.New([]float64{1, 0, 0}, []float64{0, 0, 1})
+	fmt.Println(metrics.Labels(y, t))
+
+	// binary
+	fmt.Println(metrics.Labels(matrix.New([]float64{-1}, []float64{2}), matrix.New([]float64{1}, []float64{1})))
+
+	// Output:
+	// [0 1] [0 2]
+	// [0 1] [1 1]
+}
+
+func ExampleClasses() {
+	fmt.Println(metrics.Classes(matrix.New([]float64{0, 0, 1})))
+	fmt.Println(metrics.Classes(matrix.New([]float64{1})))
+
+	// Output:
+	// 3
+	// 2
+}
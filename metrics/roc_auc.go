@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// ROCAUC returns the area under the ROC curve of the binary outputs with one column.
+// The AUC is the probability that a positive sample has a higher score than a negative one, and the ties count half.
+// It returns NaN if the labels have only one class.
+func ROCAUC(y, t matrix.Matrix) float64 {
+	score, label := make([]float64, len(y)), make([]bool, len(t))
+	for i := range y {
+		score[i], label[i] = y[i][0], t[i][0] > 0.5
+	}
+
+	return auc(score, label)
+}
+
+// auc returns the AUC by the Mann-Whitney U statistic, with the average rank of the ties.
+func auc(score []float64, label []bool) float64 {
+	index := make([]int, len(score))
+	for i := range index {
+		index[i] = i
+	}
+	sort.SliceStable(index, func(a, b int) bool { return score[index[a]] < score[index[b]] })
+
+	var pos, neg int
+	var rank float64
+	for begin := 0; begin < len(index); {
+		end := begin + 1
+		for end < len(index) && score[index[end]] == score[index[begin]] {
+			end++
+		}
+
+		// ranks are 1-origin. the ties have the average rank.
+		r := float64(begin+end+1) / 2
+		for _, i := range index[begin:end] {
+			if label[i] {
+				rank += r
+				pos++
+				continue
+			}
+
+			neg++
+		}
+
+		begin = end
+	}
+
+	if pos == 0 || neg == 0 {
+		return math.NaN()
+	}
+
+	return (rank - float64(pos*(pos+1))/2) / float64(pos*neg)
+}
@@ -0,0 +1,30 @@
+package metrics_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/metrics"
+)
+
+func ExampleROCAUC() {
+	y := matrix.New([]float64{0.1}, []float64{0.4}, []float64{0.35}, []float64{0.8})
+	t := matrix.New([]float64{0}, []float64{0}, []float64{1}, []float64{1})
+	fmt.Printf("%.4f\n", metrics.ROCAUC(y, t))
+
+	// Output:
+	// 0.7500
+}
+
+func ExampleROCAUC_ties() {
+	y := matrix.New([]float64{0.5}, []float64{0.5}, []float64{0.9})
+	t := matrix.New([]float64{0}, []float64{1}, []float64{1})
+	fmt.Printf("%.4f\n", metrics.ROCAUC(y, t))
+
+	// only one class
+	fmt.Printf("%.4f\n", metrics.ROCAUC(y, matrix.New([]float64{1}, []float64{1}, []float64{1})))
+
+	// Output:
+	// 0.7500
+	// NaN
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import "github.com/itsubaki/neu/math/matrix"
+
+// TopKAccuracy returns the ratio of the samples whose true class is in the k classes with the highest scores.
+// TopKAccuracy with k = 1 equals the accuracy. See Labels for y and t.
+func TopKAccuracy(y, t matrix.Matrix, k int) float64 {
+	return div(topK(y, t, k), len(y))
+}
+
+// topK returns the number of the samples whose true class is in the top-k.
+// The true class is in the top-k if less than k classes have the higher score.
+func topK(y, t matrix.Matrix, k int) int {
+	y, t = binary(y, t)
+	label := t.Argmax()
+
+	var correct int
+	for i := range y {
+		var higher int
+		for j := range y[i] {
+			if y[i][j] > y[i][label[i]] {
+				higher++
+			}
+		}
+
+		if higher < k {
+			correct++
+		}
+	}
+
+	return correct
+}
@@ -0,0 +1,22 @@
+package metrics_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/metrics"
+)
+
+func ExampleTopKAccuracy() {
+	y := matrix.New([]float64{0.1, 0.5, 0.4}, []float64{0.3, 0.3, 0.4}, []float64{0.6, 0.3, 0.1}, []float64{0.2, 0.5, 0.3})
+	t := matrix.New([]float64{0, 0, 1}, []float64{0, 1, 0}, []float64{0, 0, 1}, []float64{0, 1, 0})
+
+	for k := 1; k < 4; k++ {
+		fmt.Printf("%.4f\n", metrics.TopKAccuracy(y, t, k))
+	}
+
+	// Output:
+	// 0.2500
+	// 0.7500
+	// 1.0000
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"fmt"
+	randv2 "math/rand/v2"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+)
+
+// AttentionScore is the score function of the attention.
+// It is a name, so that the config can be saved to a file.
+type AttentionScore string
+
+const (
+	DotScore      AttentionScore = "dot"      // hs . h
+	GeneralScore  AttentionScore = "general"  // hs . (h W)
+	AdditiveScore AttentionScore = "additive" // tanh(hs W1 + h W2) . V, with the attention size H
+)
+
+// Validate returns an error if the score is not supported. The empty score is DotScore.
+func (a AttentionScore) Validate() error {
+	switch a {
+	case "", DotScore, GeneralScore, AdditiveScore:
+		return nil
+	default:
+		return fmt.Errorf("attention score=%q is not supported", string(a))
+	}
+}
+
+// New returns the score layer for the hidden size H.
+// It panics if the score is not supported.
+func (a AttentionScore) New(H int, init WeightInit, s randv2.Source) layer.Scorer {
+	switch a {
+	case "", DotScore:
+		return &layer.DotScore{}
+	case GeneralScore:
+		return &layer.GeneralScore{
+			W: matrix.Randn(H, H, s).MulC(init(H)),
+		}
+	case AdditiveScore:
+		return &layer.AdditiveScore{
+			W1: matrix.Randn(H, H, s).MulC(init(H)),
+			W2: matrix.Randn(H, H, s).MulC(init(H)),
+			V:  matrix.Randn(H, 1, s).MulC(init(H)),
+		}
+	default:
+		panic(a.Validate())
+	}
+}
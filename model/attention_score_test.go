@@ -0,0 +1,30 @@
+package model_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+	"github.com/itsubaki/neu/weight"
+)
+
+func ExampleAttentionScore_New() {
+	for _, score := range []model.AttentionScore{"", model.DotScore, model.GeneralScore, model.AdditiveScore} {
+		fmt.Println(score.New(3, weight.Xavier, rand.Const(1)))
+	}
+
+	// Output:
+	// *layer.DotScore
+	// *layer.DotScore
+	// *layer.GeneralScore: W(3, 3): 9
+	// *layer.AdditiveScore: W1(3, 3), W2(3, 3), V(3, 1): 21
+}
+
+func ExampleAttentionScore_Validate() {
+	fmt.Println(model.GeneralScore.Validate())
+	fmt.Println(model.AttentionScore("cosine").Validate())
+
+	// Output:
+	// <nil>
+	// attention score="cosine" is not supported
+}
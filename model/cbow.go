@@ -91,6 +91,10 @@ func (m *CBOW) Params() [][]matrix.Matrix {
 	}
 }
 
+func (m *CBOW) ParamNames() [][]string {
+	return paramNames([]Layer{m.Win0, m.Win1, m.Wout})
+}
+
 func (m *CBOW) Grads() [][]matrix.Matrix {
 	return [][]matrix.Matrix{
 		m.Win0.Grads(),
@@ -96,6 +96,10 @@ func (m *CBOWNegativeSampling) Params() [][]matrix.Matrix {
 	return params
 }
 
+func (m *CBOWNegativeSampling) ParamNames() [][]string {
+	return paramNames(m.Layers())
+}
+
 func (m *CBOWNegativeSampling) Grads() [][]matrix.Matrix {
 	grads := make([][]matrix.Matrix, 0)
 	for _, l := range m.Layers() {
@@ -105,6 +109,10 @@ func (m *CBOWNegativeSampling) Grads() [][]matrix.Matrix {
 	return grads
 }
 
+func (m *CBOWNegativeSampling) SparseGrads() [][]*matrix.Sparse {
+	return sparseGrads(m.Layers())
+}
+
 func (m *CBOWNegativeSampling) SetParams(p [][]matrix.Matrix) {
 	for i, l := range m.Layers() {
 		l.SetParams(p[i]...)
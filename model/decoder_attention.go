@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	randv2 "math/rand/v2"
+	"slices"
 
 	"github.com/itsubaki/neu/layer"
 	"github.com/itsubaki/neu/math/matrix"
@@ -36,7 +37,9 @@ func NewAttentionDecoder(c *RNNLMConfig, s ...randv2.Source) *AttentionDecoder {
 			B:        matrix.Zero(1, 4*H),
 			Stateful: true,
 		},
-		TimeAttention: &layer.TimeAttention{},
+		TimeAttention: &layer.TimeAttention{
+			Score: c.AttentionScore.New(H, c.WeightInit, s[0]),
+		},
 		TimeAffine: &layer.TimeAffine{
 			W: matrix.Randn(2*H, V, s[0]).MulC(c.WeightInit(2 * H)),
 			B: matrix.Zero(1, V),
@@ -109,26 +112,35 @@ func (m *AttentionDecoder) Layers() []TimeLayer {
 	}
 }
 
+// Params returns the params of Layers, followed by the params of the attention, which depend on its score.
 func (l *AttentionDecoder) Params() []matrix.Matrix {
-	return []matrix.Matrix{
+	return append([]matrix.Matrix{
 		l.TimeEmbedding.W,
 		l.TimeLSTM.Wx,
 		l.TimeLSTM.Wh,
 		l.TimeLSTM.B,
 		l.TimeAffine.W,
 		l.TimeAffine.B,
-	}
+	}, l.TimeAttention.Params()...)
+}
+
+// ParamNames returns the names of Params, e.g. TimeAttention.W.
+func (l *AttentionDecoder) ParamNames() []string {
+	return slices.Concat(append(
+		paramNames(l.Layers()),
+		paramNames([]*layer.TimeAttention{l.TimeAttention})...,
+	)...)
 }
 
 func (l *AttentionDecoder) Grads() []matrix.Matrix {
-	return []matrix.Matrix{
+	return append([]matrix.Matrix{
 		l.TimeEmbedding.DW,
 		l.TimeLSTM.DWx,
 		l.TimeLSTM.DWh,
 		l.TimeLSTM.DB,
 		l.TimeAffine.DW,
 		l.TimeAffine.DB,
-	}
+	}, l.TimeAttention.Grads()...)
 }
 
 func (l *AttentionDecoder) SetParams(p ...matrix.Matrix) {
@@ -138,4 +150,5 @@ func (l *AttentionDecoder) SetParams(p ...matrix.Matrix) {
 	l.TimeLSTM.B = p[3]
 	l.TimeAffine.W = p[4]
 	l.TimeAffine.B = p[5]
+	l.TimeAttention.SetParams(p[6:]...)
 }
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"fmt"
+	randv2 "math/rand/v2"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/math/tensor"
+)
+
+type TransformerDecoder struct {
+	TimeEmbedding *layer.TimeEmbedding
+	Position      *layer.TimePositionalEncoding
+	Blocks        []*layer.TransformerDecoderBlock
+	TimeAffine    *layer.TimeAffine
+	Source        randv2.Source
+}
+
+func NewTransformerDecoder(c *TransformerConfig, s ...randv2.Source) *TransformerDecoder {
+	if len(s) == 0 {
+		s = append(s, rand.NewSource(rand.MustRead()))
+	}
+
+	// size
+	V, H, F := c.VocabSize, c.HiddenSize, c.FeedForwardSize
+
+	blocks := make([]*layer.TransformerDecoderBlock, c.Layers)
+	for i := range blocks {
+		blocks[i] = &layer.TransformerDecoderBlock{
+			SelfAttention:  c.attention(true, s[0]),
+			Norm1:          c.norm(),
+			CrossAttention: c.attention(false, s[0]),
+			Norm2:          c.norm(),
+			FF1:            &layer.TimeAffine{W: matrix.Randn(H, F, s[0]).MulC(c.WeightInit(H)), B: matrix.Zero(1, F)},
+			FF2:            &layer.TimeAffine{W: matrix.Randn(F, H, s[0]).MulC(c.WeightInit(F)), B: matrix.Zero(1, H)},
+			Norm3:          c.norm(),
+		}
+	}
+
+	return &TransformerDecoder{
+		TimeEmbedding: &layer.TimeEmbedding{
+			W: matrix.Randn(V, H, s[0]).MulC(1.0 / 100),
+		},
+		Position: c.position(s[0]),
+		Blocks:   blocks,
+		TimeAffine: &layer.TimeAffine{
+			W: matrix.Randn(H, V, s[0]).MulC(c.WeightInit(H)),
+			B: matrix.Zero(1, V),
+		},
+		Source: s[0],
+	}
+}
+
+// Forward returns the scores of xs. Each block attends to the encoder outputs enchs.
+func (m *TransformerDecoder) Forward(xs, enchs []matrix.Matrix, opts ...layer.Opts) []matrix.Matrix {
+	hs := m.TimeEmbedding.Forward(xs, nil)
+	hs = m.Position.Forward(hs, nil)
+	for _, b := range m.Blocks {
+		hs = b.Forward(hs, enchs, opts...)
+	}
+
+	return m.TimeAffine.Forward(hs, nil)
+}
+
+// Backward returns the gradient of the encoder outputs, which is the sum over the blocks.
+func (m *TransformerDecoder) Backward(dscore []matrix.Matrix) []matrix.Matrix {
+	dout := m.TimeAffine.Backward(dscore)
+
+	var denchs []matrix.Matrix
+	for i := len(m.Blocks) - 1; i > -1; i-- {
+		dout = m.Blocks[i].Backward(dout)
+		if denchs == nil {
+			denchs = m.Blocks[i].DHS()
+			continue
+		}
+
+		denchs = tensor.Add(denchs, m.Blocks[i].DHS())
+	}
+
+	dout = m.Position.Backward(dout)
+	m.TimeEmbedding.Backward(dout)
+	return denchs
+}
+
+// Generate returns the greedy decoded ids.
+// The decoder has no state, so the whole prefix is decoded at each step.
+func (m *TransformerDecoder) Generate(enchs []matrix.Matrix, startID, length int) []int {
+	xs := []matrix.Matrix{{{float64(startID)}}}
+	sampled := make([]int, 0)
+
+	for i := 0; i < length; i++ {
+		score := m.Forward(xs, enchs)
+
+		x := tensor.Argmax(score[len(score)-1:])
+		sampled = append(sampled, x)
+		xs = append(xs, matrix.Matrix{{float64(x)}})
+	}
+
+	return sampled
+}
+
+func (m *TransformerDecoder) Summary() []string {
+	s := []string{fmt.Sprintf("%T", m)}
+	for _, l := range m.Layers() {
+		s = append(s, l.String())
+	}
+
+	return s
+}
+
+func (m *TransformerDecoder) Layers() []TimeLayer {
+	layers := []TimeLayer{m.TimeEmbedding, m.Position}
+	for _, b := range m.Blocks {
+		layers = append(layers, b)
+	}
+
+	return append(layers, m.TimeAffine)
+}
+
+func (m *TransformerDecoder) Params() []matrix.Matrix {
+	return flatten(m.Layers(), TimeLayer.Params)
+}
+
+func (m *TransformerDecoder) Grads() []matrix.Matrix {
+	return flatten(m.Layers(), TimeLayer.Grads)
+}
+
+func (m *TransformerDecoder) SetParams(p ...matrix.Matrix) {
+	setParams(m.Layers(), p)
+}
@@ -0,0 +1,50 @@
+package model_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+)
+
+func ExampleTransformerDecoder() {
+	c := transformerConfig()
+	c.Layers = 2
+	m := model.NewTransformerDecoder(c, rand.Const(1))
+
+	xs := []matrix.Matrix{{{0}, {1}}, {{1}, {2}}}
+	enchs := []matrix.Matrix{{{1, 0, 0, 0}, {0, 1, 0, 0}}, {{0, 0, 1, 0}, {0, 0, 0, 1}}, {{1, 1, 0, 0}, {0, 0, 1, 1}}}
+
+	score := m.Forward(xs, enchs)
+	fmt.Println(len(score), len(score[0]), len(score[0][0]))
+
+	denchs := m.Backward(score)
+	fmt.Println(len(denchs), len(denchs[0]), len(denchs[0][0]))
+	fmt.Println(len(m.Params()), len(m.Grads()))
+
+	// the encoder outputs of the sample 0
+	fmt.Println(m.Generate([]matrix.Matrix{enchs[0][:1], enchs[1][:1], enchs[2][:1]}, 0, 4))
+
+	// Output:
+	// 2 2 3
+	// 3 2 4
+	// 51 51
+	// [2 1 1 1]
+}
+
+func ExampleTransformerDecoder_Summary() {
+	m := model.NewTransformerDecoder(transformerConfig())
+
+	fmt.Println(m.Summary()[0])
+	for i, s := range m.Summary()[1:] {
+		fmt.Printf("%2d: %v\n", i, s)
+	}
+
+	// Output:
+	// *model.TransformerDecoder
+	//  0: *layer.TimeEmbedding: W(3, 4): 12
+	//  1: *layer.TimePositionalEncoding: W(8, 4), Learned(false)
+	//  2: *layer.TransformerDecoderBlock: H(4), FF(6), Heads(2): 234
+	//  3: *layer.TimeAffine: W(4, 3), B(1, 3): 15
+}
@@ -0,0 +1,115 @@
+package model
+
+import (
+	"fmt"
+	randv2 "math/rand/v2"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+)
+
+type TransformerEncoder struct {
+	TimeEmbedding *layer.TimeEmbedding
+	Position      *layer.TimePositionalEncoding
+	Blocks        []*layer.TransformerEncoderBlock
+	Source        randv2.Source
+}
+
+func NewTransformerEncoder(c *TransformerConfig, s ...randv2.Source) *TransformerEncoder {
+	if len(s) == 0 {
+		s = append(s, rand.NewSource(rand.MustRead()))
+	}
+
+	// size
+	V, H, F := c.VocabSize, c.HiddenSize, c.FeedForwardSize
+
+	blocks := make([]*layer.TransformerEncoderBlock, c.Layers)
+	for i := range blocks {
+		blocks[i] = &layer.TransformerEncoderBlock{
+			Attention: c.attention(false, s[0]),
+			Norm1:     c.norm(),
+			FF1:       &layer.TimeAffine{W: matrix.Randn(H, F, s[0]).MulC(c.WeightInit(H)), B: matrix.Zero(1, F)},
+			FF2:       &layer.TimeAffine{W: matrix.Randn(F, H, s[0]).MulC(c.WeightInit(F)), B: matrix.Zero(1, H)},
+			Norm2:     c.norm(),
+		}
+	}
+
+	return &TransformerEncoder{
+		TimeEmbedding: &layer.TimeEmbedding{
+			W: matrix.Randn(V, H, s[0]).MulC(1.0 / 100),
+		},
+		Position: c.position(s[0]),
+		Blocks:   blocks,
+		Source:   s[0],
+	}
+}
+
+// Forward returns the outputs of the last block for each time step.
+func (m *TransformerEncoder) Forward(xs []matrix.Matrix, opts ...layer.Opts) []matrix.Matrix {
+	hs := m.TimeEmbedding.Forward(xs, nil)
+	hs = m.Position.Forward(hs, nil)
+	for _, b := range m.Blocks {
+		hs = b.Forward(hs, nil, opts...)
+	}
+
+	return hs
+}
+
+func (m *TransformerEncoder) Backward(dhs []matrix.Matrix) {
+	for i := len(m.Blocks) - 1; i > -1; i-- {
+		dhs = m.Blocks[i].Backward(dhs)
+	}
+
+	dhs = m.Position.Backward(dhs)
+	m.TimeEmbedding.Backward(dhs)
+}
+
+func (m *TransformerEncoder) Summary() []string {
+	s := []string{fmt.Sprintf("%T", m)}
+	for _, l := range m.Layers() {
+		s = append(s, l.String())
+	}
+
+	return s
+}
+
+func (m *TransformerEncoder) Layers() []TimeLayer {
+	layers := []TimeLayer{m.TimeEmbedding, m.Position}
+	for _, b := range m.Blocks {
+		layers = append(layers, b)
+	}
+
+	return layers
+}
+
+func (m *TransformerEncoder) Params() []matrix.Matrix {
+	return flatten(m.Layers(), TimeLayer.Params)
+}
+
+func (m *TransformerEncoder) Grads() []matrix.Matrix {
+	return flatten(m.Layers(), TimeLayer.Grads)
+}
+
+func (m *TransformerEncoder) SetParams(p ...matrix.Matrix) {
+	setParams(m.Layers(), p)
+}
+
+// flatten returns the concatenation of f of the layers, e.g. the params of the layers.
+func flatten(layers []TimeLayer, f func(TimeLayer) []matrix.Matrix) []matrix.Matrix {
+	out := make([]matrix.Matrix, 0)
+	for _, l := range layers {
+		out = append(out, f(l)...)
+	}
+
+	return out
+}
+
+// setParams sets p to the layers in the order of their params.
+func setParams(layers []TimeLayer, p []matrix.Matrix) {
+	for _, l := range layers {
+		n := len(l.Params())
+		l.SetParams(p[:n]...)
+		p = p[n:]
+	}
+}
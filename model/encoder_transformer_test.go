@@ -0,0 +1,41 @@
+package model_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+)
+
+func ExampleTransformerEncoder() {
+	c := transformerConfig()
+	c.Layers = 2
+	m := model.NewTransformerEncoder(c, rand.Const(1))
+
+	xs := []matrix.Matrix{{{0}, {1}}, {{1}, {2}}, {{2}, {0}}}
+	hs := m.Forward(xs)
+	fmt.Println(len(hs), len(hs[0]), len(hs[0][0]))
+
+	m.Backward(hs)
+	fmt.Println(len(m.Params()), len(m.Grads()))
+
+	// Output:
+	// 3 2 4
+	// 31 31
+}
+
+func ExampleTransformerEncoder_Summary() {
+	m := model.NewTransformerEncoder(transformerConfig())
+
+	fmt.Println(m.Summary()[0])
+	for i, s := range m.Summary()[1:] {
+		fmt.Printf("%2d: %v\n", i, s)
+	}
+
+	// Output:
+	// *model.TransformerEncoder
+	//  0: *layer.TimeEmbedding: W(3, 4): 12
+	//  1: *layer.TimePositionalEncoding: W(8, 4), Learned(false)
+	//  2: *layer.TransformerEncoderBlock: H(4), FF(6), Heads(2): 150
+}
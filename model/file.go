@@ -0,0 +1,225 @@
+package model
+
+import (
+	"encoding/gob"
+	"fmt"
+	randv2 "math/rand/v2"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/weight"
+)
+
+// Version is the version of the model file format.
+const Version = 1
+
+// Model is an interface that represents a model that can be saved to a file.
+type Model interface {
+	Summary() []string
+	Params() [][]matrix.Matrix
+	Grads() [][]matrix.Matrix
+	SetParams(p [][]matrix.Matrix)
+}
+
+// File is the model file format.
+type File struct {
+	Version int               // the version of the file format
+	Model   string            // the registered model type, e.g. *model.MLP
+	Config  any               // the config of the model, e.g. *model.MLPConfig
+	Layers  []string          // the summary of the model
+	Params  [][]matrix.Matrix // the params of the model
+}
+
+// Builder is a function that builds a model from the config.
+// It returns an error if the config is not the registered config type.
+type Builder func(config any, s ...randv2.Source) (Model, error)
+
+type registered struct {
+	build  Builder
+	config reflect.Type // the config type, e.g. *model.MLPConfig
+}
+
+var registry sync.Map // map[string]registered
+
+func init() {
+	Register(NewMLP)
+	Register(NewQNet)
+	Register(NewCBOW)
+	Register(func(c *CBOWNegativeSamplingConfig, s ...randv2.Source) *CBOWNegativeSampling {
+		return NewCBOWNegativeSampling(*c, s...)
+	})
+	Register(NewRNNLM)
+	Register(NewLSTMLM)
+	Register(NewGRULM)
+	Register(NewRNNLMGen)
+	Register(NewSeq2Seq)
+	Register(NewPeekySeq2Seq)
+	Register(NewAttentionSeq2Seq)
+	Register(NewTransformerSeq2Seq)
+}
+
+// Register registers the constructor of a model, so that Load can rebuild the model from a file.
+// The model type is the name of M, e.g. *model.MLP.
+// If the config has a Validate method, Load returns its error instead of building the model.
+func Register[C any, M Model](newModel func(c *C, s ...randv2.Source) M) {
+	gob.Register(new(C))
+	registry.Store(TypeOf(*new(M)), registered{
+		build: func(config any, s ...randv2.Source) (Model, error) {
+			c, ok := config.(*C)
+			if !ok || c == nil {
+				return nil, fmt.Errorf("config=%T, want=%T", config, c)
+			}
+
+			if v, ok := any(c).(interface{ Validate() error }); ok {
+				if err := v.Validate(); err != nil {
+					return nil, fmt.Errorf("config: %v", err)
+				}
+			}
+
+			return newModel(c, s...), nil
+		},
+		config: reflect.TypeOf(new(C)),
+	})
+}
+
+// TypeOf returns the model type of m.
+func TypeOf(m Model) string {
+	return fmt.Sprintf("%T", m)
+}
+
+// Save saves the model and its config to a file.
+// The model type must be registered, and config must be the config that the model was built with.
+func Save(filename string, m Model, config any) error {
+	name := TypeOf(m)
+	r, ok := registry.Load(name)
+	if !ok {
+		return fmt.Errorf("model=%v is not registered", name)
+	}
+
+	want := r.(registered).config
+	v := reflect.ValueOf(config)
+	if !v.IsValid() || v.Type() != want {
+		return fmt.Errorf("config=%T, want=%v", config, want)
+	}
+
+	if v.IsNil() {
+		return fmt.Errorf("config=%T is nil", config)
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("create file: %v", err)
+	}
+	defer f.Close()
+
+	if err := gob.NewEncoder(f).Encode(File{
+		Version: Version,
+		Model:   name,
+		Config:  config,
+		Layers:  m.Summary(),
+		Params:  m.Params(),
+	}); err != nil {
+		return fmt.Errorf("encode model: %v", err)
+	}
+
+	return nil
+}
+
+// Load rebuilds the model from a file.
+// The shapes of the params in the file must match the model built with the config.
+func Load(filename string, s ...randv2.Source) (Model, error) {
+	file, err := Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := registry.Load(file.Model)
+	if !ok {
+		return nil, fmt.Errorf("model=%v is not registered", file.Model)
+	}
+
+	// WeightInit is not encoded, and the initial weights are replaced by the params.
+	weightInit(reflect.ValueOf(file.Config))
+
+	m, err := r.(registered).build(file.Config, s...)
+	if err != nil {
+		return nil, fmt.Errorf("model=%v: %v", file.Model, err)
+	}
+
+	if err := Check(m.Params(), file.Params); err != nil {
+		return nil, fmt.Errorf("model=%v: %v", file.Model, err)
+	}
+
+	m.SetParams(file.Params)
+	return m, nil
+}
+
+// Open reads the model file without rebuilding the model.
+func Open(filename string) (*File, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %v", err)
+	}
+	defer f.Close()
+
+	var file File
+	if err := gob.NewDecoder(f).Decode(&file); err != nil {
+		return nil, fmt.Errorf("decode model: %v", err)
+	}
+
+	if file.Version != Version {
+		return nil, fmt.Errorf("version=%v is not supported", file.Version)
+	}
+
+	return &file, nil
+}
+
+// Check returns an error if the shapes of the params are different from want.
+func Check(want, params [][]matrix.Matrix) error {
+	if len(want) != len(params) {
+		return fmt.Errorf("len(params)=%v, want=%v", len(params), len(want))
+	}
+
+	for i := range want {
+		if len(want[i]) != len(params[i]) {
+			return fmt.Errorf("len(params[%v])=%v, want=%v", i, len(params[i]), len(want[i]))
+		}
+
+		for j := range want[i] {
+			a, b := want[i][j].Dim()
+			c, d := params[i][j].Dim()
+			if a != c || b != d {
+				return fmt.Errorf("params[%v][%v]=(%v, %v), want=(%v, %v)", i, j, c, d, a, b)
+			}
+		}
+	}
+
+	return nil
+}
+
+// weightInit sets the nil WeightInit fields of the config, including the embedded configs.
+func weightInit(v reflect.Value) {
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if !f.CanSet() {
+			continue
+		}
+
+		if f.Type() == reflect.TypeOf(WeightInit(nil)) && f.IsNil() {
+			f.Set(reflect.ValueOf(WeightInit(weight.Std(0.01))))
+			continue
+		}
+
+		weightInit(f)
+	}
+}
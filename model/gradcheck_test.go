@@ -0,0 +1,69 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/itsubaki/neu/gradcheck"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+	"github.com/itsubaki/neu/trainer"
+	"github.com/itsubaki/neu/weight"
+)
+
+// tol is the tolerance of the maximum relative error.
+const tol = 1e-4
+
+func TestGradcheck_Model(t *testing.T) {
+	s := rand.Const(1)
+
+	cases := []struct {
+		name string
+		m    trainer.Model
+		x, t matrix.Matrix
+	}{
+		{"MLP", model.NewMLP(&model.MLPConfig{InputSize: 3, OutputSize: 2, HiddenSize: []int{4, 4}, WeightInit: weight.Xavier}, s), matrix.Randn(4, 3, s), matrix.OneHot([]int{0, 1, 1, 0}, 2)},
+		{"QNet", model.NewQNet(&model.QNetConfig{InputSize: 3, OutputSize: 2, HiddenSize: []int{4}, WeightInit: weight.Xavier}, s), matrix.Randn(4, 3, s), matrix.Randn(4, 2, s)},
+	}
+
+	for _, c := range cases {
+		r := gradcheck.Model(c.m, c.x, c.t)
+		if r.Max() > tol {
+			t.Errorf("%v: %+v", c.name, r)
+		}
+	}
+}
+
+// scaled returns the model with the embedding scaled by 100.
+// The initial embedding is small, and the gradients are too small to compare with the numerical gradients.
+func scaled(m trainer.RNNLM) trainer.RNNLM {
+	p := m.Params()
+	p[0][0] = p[0][0].MulC(100)
+	m.SetParams(p)
+	return m
+}
+
+func TestGradcheck_RNNLM(t *testing.T) {
+	s := rand.Const(1)
+	c := model.RNNLMConfig{VocabSize: 5, WordVecSize: 4, HiddenSize: 4, WeightInit: weight.Xavier}
+
+	cases := []struct {
+		name string
+		m    trainer.RNNLM
+	}{
+		{"RNNLM", scaled(model.NewRNNLM(&c, s))},
+		{"LSTMLM", scaled(model.NewLSTMLM(&model.LSTMLMConfig{RNNLMConfig: c}, s))},
+		{"LSTMLM_weightTying", scaled(model.NewLSTMLM(&model.LSTMLMConfig{RNNLMConfig: c, Layers: 2, WeightTying: true}, s))},
+		{"GRULM", scaled(model.NewGRULM(&model.LSTMLMConfig{RNNLMConfig: c}, s))},
+		{"GRULM_weightTying", scaled(model.NewGRULM(&model.LSTMLMConfig{RNNLMConfig: c, Layers: 1, WeightTying: true}, s))},
+	}
+
+	xs := []matrix.Matrix{{{0}, {3}}, {{1}, {4}}, {{2}, {0}}}
+	ts := []matrix.Matrix{{{1}, {4}}, {{2}, {0}}, {{3}, {1}}}
+	for _, c := range cases {
+		r := gradcheck.RNNLM(c.m, xs, ts)
+		if r.Max() > tol {
+			t.Errorf("%v: %+v", c.name, r)
+		}
+	}
+}
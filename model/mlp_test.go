@@ -77,6 +77,27 @@ func ExampleMLP_Layers() {
 	//  4: *layer.SoftmaxWithLoss
 }
 
+func ExampleMLP_ParamNames() {
+	m := model.NewMLP(&model.MLPConfig{
+		InputSize:         2,
+		OutputSize:        2,
+		HiddenSize:        []int{3},
+		WeightInit:        weight.Std(0.01),
+		BatchNormMomentum: 0.9,
+	})
+
+	for i, n := range m.ParamNames() {
+		fmt.Printf("%2d: %v\n", i, n)
+	}
+
+	// Output:
+	//  0: [Affine.W Affine.B]
+	//  1: [BatchNorm.Gamma BatchNorm.Beta]
+	//  2: []
+	//  3: [Affine.W Affine.B]
+	//  4: []
+}
+
 func ExampleMLP_Params() {
 	s := rand.Const(1)
 	m := model.NewMLP(&model.MLPConfig{
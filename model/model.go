@@ -1,24 +1,30 @@
 package model
 
 import (
-	"encoding/gob"
 	"fmt"
-	"os"
+	"strings"
 
+	"github.com/itsubaki/neu/autograd"
 	"github.com/itsubaki/neu/layer"
 	"github.com/itsubaki/neu/math/matrix"
 	"github.com/itsubaki/neu/weight"
 )
 
 var (
+	_ Layer = (*autograd.Layer)(nil)
 	_ Layer = (*layer.Add)(nil)
 	_ Layer = (*layer.Affine)(nil)
+	_ Layer = (*layer.AvgPooling)(nil)
 	_ Layer = (*layer.BatchNorm)(nil)
+	_ Layer = (*layer.Convolution)(nil)
 	_ Layer = (*layer.Dot)(nil)
 	_ Layer = (*layer.Dropout)(nil)
 	_ Layer = (*layer.EmbeddingDot)(nil)
 	_ Layer = (*layer.Embedding)(nil)
+	_ Layer = (*layer.GlobalAvgPooling)(nil)
 	_ Layer = (*layer.GRU)(nil)
+	_ Layer = (*layer.LayerNorm)(nil)
+	_ Layer = (*layer.MaxPooling)(nil)
 	_ Layer = (*layer.MeanSquaredError)(nil)
 	_ Layer = (*layer.Mul)(nil)
 	_ Layer = (*layer.NegativeSamplingLoss)(nil)
@@ -31,20 +37,67 @@ var (
 )
 
 var (
+	_ TimeLayer = (*layer.MultiHeadAttention)(nil)
 	_ TimeLayer = (*layer.TimeAffine)(nil)
 	_ TimeLayer = (*layer.TimeBiLSTM)(nil)
 	_ TimeLayer = (*layer.TimeDropout)(nil)
 	_ TimeLayer = (*layer.TimeEmbedding)(nil)
 	_ TimeLayer = (*layer.TimeGRU)(nil)
+	_ TimeLayer = (*layer.TimeLayerNorm)(nil)
 	_ TimeLayer = (*layer.TimeLSTM)(nil)
 	_ TimeLayer = (*layer.TimeRNN)(nil)
 	_ TimeLayer = (*layer.TimeSoftmaxWithLoss)(nil)
+	_ TimeLayer = (*layer.TimeTiedAffine)(nil)
+	_ TimeLayer = (*layer.TimePositionalEncoding)(nil)
+	_ TimeLayer = (*layer.TransformerEncoderBlock)(nil)
+	_ TimeLayer = (*layer.TransformerDecoderBlock)(nil)
 )
 
 var (
 	_ AttentionLayer = (*layer.Attention)(nil)
 	_ AttentionLayer = (*layer.AttentionWeight)(nil)
 	_ AttentionLayer = (*layer.WeightSum)(nil)
+	_ AttentionLayer = (*layer.DotScore)(nil)
+	_ AttentionLayer = (*layer.GeneralScore)(nil)
+	_ AttentionLayer = (*layer.AdditiveScore)(nil)
+)
+
+var (
+	_ SparseLayer = (*layer.Embedding)(nil)
+	_ SparseLayer = (*layer.EmbeddingDot)(nil)
+	_ SparseLayer = (*layer.NegativeSamplingLoss)(nil)
+	_ SparseLayer = (*layer.TimeEmbedding)(nil)
+)
+
+var (
+	_ NamedLayer = (*layer.AdditiveScore)(nil)
+	_ NamedLayer = (*layer.Affine)(nil)
+	_ NamedLayer = (*layer.Attention)(nil)
+	_ NamedLayer = (*layer.AttentionWeight)(nil)
+	_ NamedLayer = (*layer.BatchNorm)(nil)
+	_ NamedLayer = (*layer.Convolution)(nil)
+	_ NamedLayer = (*layer.Dot)(nil)
+	_ NamedLayer = (*layer.EmbeddingDot)(nil)
+	_ NamedLayer = (*layer.Embedding)(nil)
+	_ NamedLayer = (*layer.GeneralScore)(nil)
+	_ NamedLayer = (*layer.GRU)(nil)
+	_ NamedLayer = (*layer.LayerNorm)(nil)
+	_ NamedLayer = (*layer.LSTM)(nil)
+	_ NamedLayer = (*layer.MultiHeadAttention)(nil)
+	_ NamedLayer = (*layer.NegativeSamplingLoss)(nil)
+	_ NamedLayer = (*layer.RNN)(nil)
+	_ NamedLayer = (*layer.TimeAffine)(nil)
+	_ NamedLayer = (*layer.TimeAttention)(nil)
+	_ NamedLayer = (*layer.TimeBiLSTM)(nil)
+	_ NamedLayer = (*layer.TimeEmbedding)(nil)
+	_ NamedLayer = (*layer.TimeGRU)(nil)
+	_ NamedLayer = (*layer.TimeLayerNorm)(nil)
+	_ NamedLayer = (*layer.TimeLSTM)(nil)
+	_ NamedLayer = (*layer.TimeRNN)(nil)
+	_ NamedLayer = (*layer.TimeTiedAffine)(nil)
+	_ NamedLayer = (*layer.TimePositionalEncoding)(nil)
+	_ NamedLayer = (*layer.TransformerEncoderBlock)(nil)
+	_ NamedLayer = (*layer.TransformerDecoderBlock)(nil)
 )
 
 var (
@@ -89,33 +142,51 @@ type AttentionLayer interface {
 	String() string
 }
 
-// Save saves the params to a file.
-func Save(filename string, params [][]matrix.Matrix) error {
-	f, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("create file: %v", err)
-	}
-	defer f.Close()
+// SparseLayer is an interface that represents a layer with the row-sparse gradients.
+// SparseGrads returns the same gradients as Grads, in the same order.
+type SparseLayer interface {
+	SparseGrads() []*matrix.Sparse
+}
 
-	if err := gob.NewEncoder(f).Encode(params); err != nil {
-		return fmt.Errorf("encode params: %v", err)
+// sparseGrads returns the row-sparse gradients of the layers.
+// It is nil for the layers without the row-sparse gradients.
+func sparseGrads[T any](layers []T) [][]*matrix.Sparse {
+	grads := make([][]*matrix.Sparse, len(layers))
+	for i, l := range layers {
+		if s, ok := any(l).(SparseLayer); ok {
+			grads[i] = s.SparseGrads()
+		}
 	}
 
-	return nil
+	return grads
 }
 
-// Load loads the params from a file.
-func Load(filename string) ([][]matrix.Matrix, bool) {
-	f, err := os.Open(filename)
-	if err != nil {
-		return nil, false
-	}
-	defer f.Close()
+// NamedLayer is an interface that represents a layer with the names of the params.
+// ParamNames returns the names in the same order as Params.
+type NamedLayer interface {
+	ParamNames() []string
+}
 
-	var params [][]matrix.Matrix
-	if err := gob.NewDecoder(f).Decode(&params); err != nil {
-		return nil, false
+// paramNames returns the names of the params of the layers, prefixed with the type of the layer, e.g. Affine.W.
+// The params of the layers without the names are named by their index, e.g. Layer.0.
+func paramNames[T interface{ Params() []matrix.Matrix }](layers []T) [][]string {
+	names := make([][]string, len(layers))
+	for i, l := range layers {
+		typ := strings.TrimSuffix(fmt.Sprintf("%T", l), "Of[float64]")
+		typ = typ[strings.LastIndex(typ, ".")+1:]
+
+		if n, ok := any(l).(NamedLayer); ok {
+			for _, name := range n.ParamNames() {
+				names[i] = append(names[i], typ+"."+name)
+			}
+
+			continue
+		}
+
+		for j := range l.Params() {
+			names[i] = append(names[i], fmt.Sprintf("%v.%v", typ, j))
+		}
 	}
 
-	return params, true
+	return names
 }
@@ -1,8 +1,12 @@
 package model_test
 
 import (
+	"encoding/gob"
 	"fmt"
+	"os"
 
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
 	"github.com/itsubaki/neu/math/rand"
 	"github.com/itsubaki/neu/model"
 	"github.com/itsubaki/neu/weight"
@@ -10,35 +14,28 @@ import (
 
 func ExampleSave() {
 	s := rand.Const(1)
-	m := model.NewSeq2Seq(&model.RNNLMConfig{
+	c := &model.RNNLMConfig{
 		VocabSize:   3, // V
 		WordVecSize: 3, // D
 		HiddenSize:  3, // H
 		WeightInit:  weight.Xavier,
-	}, s)
-
-	if err := model.Save("../testdata/example_save.gob", m.Params()); err != nil {
-		fmt.Println("failed to save params:", err)
-		return
 	}
+	m := model.NewSeq2Seq(c, s)
 
-	params, ok := model.Load("../testdata/example_save.gob")
-	if !ok {
-		fmt.Println("failed to load params")
+	if err := model.Save("../testdata/example_save.gob", m, c); err != nil {
+		fmt.Println("failed to save model:", err)
 		return
 	}
 
-	if len(m.Params()) != len(params) {
-		fmt.Println("invalid length")
+	loaded, err := model.Load("../testdata/example_save.gob")
+	if err != nil {
+		fmt.Println("failed to load model:", err)
 		return
 	}
+	fmt.Printf("%T\n", loaded)
 
+	params := loaded.(*model.Seq2Seq).Params()
 	for i, p := range m.Params() {
-		if len(p) != len(params[i]) {
-			fmt.Println("invalid length")
-			return
-		}
-
 		for j := range p {
 			if p[j].Sub(params[i][j]).Abs().Sum() > 1e-13 {
 				fmt.Println("invalid value")
@@ -48,35 +45,259 @@ func ExampleSave() {
 	}
 
 	// Output:
+	// *model.Seq2Seq
+}
+
+func ExampleSave_attentionScore() {
+	for _, score := range []model.AttentionScore{model.GeneralScore, model.AdditiveScore} {
+		c := &model.RNNLMConfig{
+			VocabSize:      3, // V
+			WordVecSize:    3, // D
+			HiddenSize:     3, // H
+			WeightInit:     weight.Xavier,
+			AttentionScore: score,
+		}
+		m := model.NewAttentionSeq2Seq(c, rand.Const(1))
+
+		if err := model.Save("../testdata/example_save.gob", m, c); err != nil {
+			fmt.Println("failed to save model:", err)
+			return
+		}
+
+		loaded, err := model.Load("../testdata/example_save.gob")
+		if err != nil {
+			fmt.Println("failed to load model:", err)
+			return
+		}
+
+		xs := []matrix.Matrix{{{0, 1, 2}}, {{0, 1, 2}}, {{0, 1, 2}}}
+		fmt.Printf("%.4f\n", m.Forward(xs, xs))
+		fmt.Printf("%.4f\n", loaded.(*model.AttentionSeq2Seq).Forward(xs, xs))
+	}
+
+	// Output:
+	// [[[1.0956]]]
+	// [[[1.0956]]]
+	// [[[1.0999]]]
+	// [[[1.0999]]]
+}
+
+func ExampleSave_transformer() {
+	c := &model.TransformerConfig{
+		VocabSize:       3,
+		HiddenSize:      4,
+		Heads:           2,
+		FeedForwardSize: 6,
+		Layers:          1,
+		MaxLength:       8,
+		DropoutRatio:    0.1,
+		WeightInit:      weight.Xavier,
+	}
+	m := model.NewTransformerSeq2Seq(c, rand.Const(1))
+
+	if err := model.Save("../testdata/example_save.gob", m, c); err != nil {
+		fmt.Println("failed to save model:", err)
+		return
+	}
+
+	loaded, err := model.Load("../testdata/example_save.gob")
+	if err != nil {
+		fmt.Println("failed to load model:", err)
+		return
+	}
+	fmt.Printf("%T\n", loaded)
+
+	xs := []matrix.Matrix{{{0}, {1}}, {{1}, {2}}, {{2}, {0}}}
+	fmt.Printf("%.4f\n", m.Loss(xs, xs))
+	fmt.Printf("%.4f\n", loaded.(*model.TransformerSeq2Seq).Loss(xs, xs))
+
+	// Output:
+	// *model.TransformerSeq2Seq
+	// [[[1.5835]]]
+	// [[[1.5835]]]
+}
+
+func ExampleLoad_attentionScore() {
+	f, err := os.Create("../testdata/example_save.gob")
+	if err != nil {
+		fmt.Println("failed to create file:", err)
+		return
+	}
+
+	// the attention score that is not supported
+	if err := gob.NewEncoder(f).Encode(model.File{
+		Version: model.Version,
+		Model:   "*model.AttentionSeq2Seq",
+		Config:  &model.RNNLMConfig{VocabSize: 3, WordVecSize: 3, HiddenSize: 3, AttentionScore: "cosine"},
+	}); err != nil {
+		fmt.Println("failed to encode model:", err)
+		return
+	}
+	f.Close()
+
+	if _, err := model.Load("../testdata/example_save.gob"); err != nil {
+		fmt.Println("failed to load model:", err)
+		return
+	}
+
+	// Output:
+	// failed to load model: model=*model.AttentionSeq2Seq: config: attention score="cosine" is not supported
+}
+
+func ExampleOpen() {
+	c := &model.MLPConfig{
+		InputSize:  2,
+		OutputSize: 2,
+		HiddenSize: []int{3},
+		WeightInit: weight.Std(0.01),
+	}
+	m := model.NewMLP(c, rand.Const(1))
+
+	if err := model.Save("../testdata/example_save.gob", m, c); err != nil {
+		fmt.Println("failed to save model:", err)
+		return
+	}
+
+	f, err := model.Open("../testdata/example_save.gob")
+	if err != nil {
+		fmt.Println("failed to open file:", err)
+		return
+	}
+
+	fmt.Println(f.Version)
+	fmt.Println(f.Model)
+	fmt.Printf("%+v\n", *f.Config.(*model.MLPConfig))
+	for _, l := range f.Layers {
+		fmt.Println(l)
+	}
 
+	// Output:
+	// 1
+	// *model.MLP
+	// {InputSize:2 OutputSize:2 HiddenSize:[3] WeightInit:<nil> BatchNormMomentum:0}
+	// *model.MLP
+	// *layer.Affine: W(2, 3), B(1, 3): 9
+	// *layer.BatchNorm: G(1, 3), B(1, 3): 6
+	// *layer.ReLU
+	// *layer.Affine: W(3, 2), B(1, 2): 8
+	// *layer.SoftmaxWithLoss
 }
 
 func ExampleSave_nosuchdir() {
-	if err := model.Save("../nosuchdir/hoge.gob", nil); err != nil {
-		fmt.Println("failed to save params:", err)
+	c := &model.QNetConfig{InputSize: 2, OutputSize: 2, HiddenSize: []int{3}, WeightInit: weight.He}
+	if err := model.Save("../nosuchdir/hoge.gob", model.NewQNet(c), c); err != nil {
+		fmt.Println("failed to save model:", err)
+		return
+	}
+
+	// Output:
+	// failed to save model: create file: open ../nosuchdir/hoge.gob: no such file or directory
+}
+
+func ExampleSave_notregistered() {
+	m := model.NewSequential([]model.Layer{&layer.ReLU{}}, rand.Const(1))
+	if err := model.Save("../testdata/example_save.gob", m, nil); err != nil {
+		fmt.Println("failed to save model:", err)
+		return
+	}
+
+	// Output:
+	// failed to save model: model=*model.Sequential is not registered
+}
+
+func ExampleSave_config() {
+	c := &model.MLPConfig{InputSize: 2, OutputSize: 2, HiddenSize: []int{3}, WeightInit: weight.He}
+	m := model.NewMLP(c, rand.Const(1))
+
+	fmt.Println(model.Save("../testdata/example_save.gob", m, nil))
+	fmt.Println(model.Save("../testdata/example_save.gob", m, *c))
+	fmt.Println(model.Save("../testdata/example_save.gob", m, (*model.MLPConfig)(nil)))
+	fmt.Println(model.Save("../testdata/example_save.gob", m, &model.QNetConfig{}))
+
+	// Output:
+	// config=<nil>, want=*model.MLPConfig
+	// config=model.MLPConfig, want=*model.MLPConfig
+	// config=*model.MLPConfig is nil
+	// config=*model.QNetConfig, want=*model.MLPConfig
+}
+
+func ExampleLoad_config() {
+	f, err := os.Create("../testdata/example_save.gob")
+	if err != nil {
+		fmt.Println("failed to create file:", err)
+		return
+	}
+
+	// the config that is not the registered config type
+	if err := gob.NewEncoder(f).Encode(model.File{
+		Version: model.Version,
+		Model:   "*model.MLP",
+		Config:  &model.QNetConfig{InputSize: 2, OutputSize: 2, HiddenSize: []int{3}},
+	}); err != nil {
+		fmt.Println("failed to encode model:", err)
+		return
+	}
+	f.Close()
+
+	if _, err := model.Load("../testdata/example_save.gob"); err != nil {
+		fmt.Println("failed to load model:", err)
 		return
 	}
 
 	// Output:
-	// failed to save params: create file: open ../nosuchdir/hoge.gob: no such file or directory
+	// failed to load model: model=*model.MLP: config=*model.QNetConfig, want=*model.MLPConfig
 }
 
 func ExampleLoad_invaliddir() {
-	if _, ok := model.Load("invalid_dir"); !ok {
-		fmt.Println("failed to save params")
+	if _, err := model.Load("invalid_dir"); err != nil {
+		fmt.Println("failed to load model:", err)
 		return
 	}
 
 	// Output:
-	// failed to save params
+	// failed to load model: open file: open invalid_dir: no such file or directory
 }
 
 func ExampleLoad_invalidfile() {
-	if _, ok := model.Load("../testdata/.gitkeep"); !ok {
-		fmt.Println("failed to save params")
+	if _, err := model.Load("../testdata/.gitkeep"); err != nil {
+		fmt.Println("failed to load model:", err)
 		return
 	}
 
 	// Output:
-	// failed to save params
+	// failed to load model: decode model: EOF
+}
+
+func ExampleLoad_shape() {
+	c := &model.MLPConfig{InputSize: 2, OutputSize: 2, HiddenSize: []int{3}, WeightInit: weight.He}
+	m := model.NewMLP(c, rand.Const(1))
+
+	// the config that the model was not built with
+	if err := model.Save("../testdata/example_save.gob", m, &model.MLPConfig{InputSize: 2, OutputSize: 2, HiddenSize: []int{4}}); err != nil {
+		fmt.Println("failed to save model:", err)
+		return
+	}
+
+	if _, err := model.Load("../testdata/example_save.gob"); err != nil {
+		fmt.Println("failed to load model:", err)
+		return
+	}
+
+	// Output:
+	// failed to load model: model=*model.MLP: params[0][0]=(2, 3), want=(2, 4)
+}
+
+func ExampleCheck() {
+	want := [][]matrix.Matrix{{matrix.Zero(2, 3), matrix.Zero(1, 3)}}
+
+	fmt.Println(model.Check(want, [][]matrix.Matrix{{matrix.Zero(2, 3), matrix.Zero(1, 3)}}))
+	fmt.Println(model.Check(want, [][]matrix.Matrix{{matrix.Zero(2, 3), matrix.Zero(1, 4)}}))
+	fmt.Println(model.Check(want, [][]matrix.Matrix{{matrix.Zero(2, 3)}}))
+	fmt.Println(model.Check(want, nil))
+
+	// Output:
+	// <nil>
+	// params[0][1]=(1, 4), want=(1, 3)
+	// len(params[0])=1, want=2
+	// len(params)=0, want=1
 }
@@ -10,10 +10,16 @@ import (
 )
 
 type RNNLMConfig struct {
-	VocabSize   int
-	WordVecSize int
-	HiddenSize  int
-	WeightInit  WeightInit
+	VocabSize      int
+	WordVecSize    int
+	HiddenSize     int
+	WeightInit     WeightInit
+	AttentionScore AttentionScore // the score of AttentionDecoder. DotScore if empty.
+}
+
+// Validate returns an error if the config is not supported.
+func (c *RNNLMConfig) Validate() error {
+	return c.AttentionScore.Validate()
 }
 
 type RNNLM struct {
@@ -99,6 +105,10 @@ func (m *RNNLM) Params() [][]matrix.Matrix {
 	return params
 }
 
+func (m *RNNLM) ParamNames() [][]string {
+	return paramNames(m.Layer)
+}
+
 func (m *RNNLM) Grads() [][]matrix.Matrix {
 	grads := make([][]matrix.Matrix, 0)
 	for _, l := range m.Layer {
@@ -108,12 +118,37 @@ func (m *RNNLM) Grads() [][]matrix.Matrix {
 	return grads
 }
 
+func (m *RNNLM) SparseGrads() [][]*matrix.Sparse {
+	return sparseGrads(m.Layer)
+}
+
 func (m *RNNLM) SetParams(p [][]matrix.Matrix) {
 	for i, l := range m.Layers() {
 		l.SetParams(p[i]...)
 	}
 }
 
+// State returns the hidden states of the layers. It is nil for the layers without the state.
+func (m *RNNLM) State() [][]matrix.Matrix {
+	state := make([][]matrix.Matrix, len(m.Layer))
+	for i, l := range m.Layer {
+		if s, ok := l.(interface{ State() []matrix.Matrix }); ok {
+			state[i] = s.State()
+		}
+	}
+
+	return state
+}
+
+// SetState sets the hidden states of the layers.
+func (m *RNNLM) SetState(state [][]matrix.Matrix) {
+	for i, l := range m.Layer {
+		if len(state[i]) > 0 {
+			l.SetState(state[i]...)
+		}
+	}
+}
+
 func (m *RNNLM) ResetState() {
 	for _, l := range m.Layer {
 		l.ResetState()
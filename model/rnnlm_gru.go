@@ -18,45 +18,16 @@ func NewGRULM(c *LSTMLMConfig, s ...randv2.Source) *GRULM {
 		s = append(s, rand.NewSource(rand.MustRead()))
 	}
 
-	// size
-	V, D, H := c.VocabSize, c.WordVecSize, c.HiddenSize
-
-	// layer
-	layers := []TimeLayer{
-		&layer.TimeEmbedding{
-			W: matrix.Randn(V, D, s[0]).MulC(1.0 / 100),
-		},
-		&layer.TimeDropout{
-			Ratio: c.DropoutRatio,
-		},
-		&layer.TimeGRU{
-			Wx:       matrix.Randn(D, 3*H, s[0]).MulC(c.WeightInit(D)),
-			Wh:       matrix.Randn(H, 3*H, s[0]).MulC(c.WeightInit(H)),
-			B:        matrix.Zero(1, 3*H),
-			Stateful: true,
-		},
-		&layer.TimeDropout{
-			Ratio: c.DropoutRatio,
-		},
-		&layer.TimeGRU{
-			Wx:       matrix.Randn(H, 3*H, s[0]).MulC(c.WeightInit(H)),
-			Wh:       matrix.Randn(H, 3*H, s[0]).MulC(c.WeightInit(H)),
-			B:        matrix.Zero(1, 3*H),
-			Stateful: true,
-		},
-		&layer.TimeDropout{
-			Ratio: c.DropoutRatio,
-		},
-		&layer.TimeAffine{
-			W: matrix.Randn(D, V, s[0]).MulC(c.WeightInit(H)),
-			B: matrix.Zero(1, V),
-		},
-		&layer.TimeSoftmaxWithLoss{},
-	}
-
 	return &GRULM{
 		RNNLM{
-			Layer:  layers,
+			Layer: stacked(c, s[0], func(D, H int) TimeLayer {
+				return &layer.TimeGRU{
+					Wx:       matrix.Randn(D, 3*H, s[0]).MulC(c.WeightInit(D)),
+					Wh:       matrix.Randn(H, 3*H, s[0]).MulC(c.WeightInit(H)),
+					B:        matrix.Zero(1, 3*H),
+					Stateful: true,
+				}
+			}),
 			Source: s[0],
 		},
 	}
@@ -53,6 +53,54 @@ func ExampleGRULM() {
 	// []
 }
 
+func ExampleGRULM_weightTying() {
+	s := rand.Const(1)
+	m := model.NewGRULM(&model.LSTMLMConfig{
+		RNNLMConfig: model.RNNLMConfig{
+			VocabSize:   5,
+			WordVecSize: 3,
+			HiddenSize:  3,
+			WeightInit:  weight.Xavier,
+		},
+		DropoutRatio: 0.5,
+		Layers:       3,
+		WeightTying:  true,
+	}, s)
+
+	for i, l := range m.Layers() {
+		fmt.Printf("%2d: %v\n", i, l)
+	}
+	fmt.Println()
+
+	// data
+	xs := []matrix.Matrix{{{0, 1, 2}}}
+	ts := []matrix.Matrix{{{0, 1, 2}}}
+
+	loss := m.Forward(xs, ts)
+	m.Backward()
+	fmt.Printf("%.4f\n", loss)
+
+	// the gradient of the embedding includes the gradient of the tied weight
+	fmt.Println(m.Grads()[0][0].Dim())
+	fmt.Println(m.SparseGrads()[0][0] == nil)
+
+	// Output:
+	//  0: *layer.TimeEmbedding: W(5, 3): 15
+	//  1: *layer.TimeDropout: Ratio(0.5)
+	//  2: *layer.TimeGRU: Wx(3, 9), Wh(3, 9), B(1, 9): 63
+	//  3: *layer.TimeDropout: Ratio(0.5)
+	//  4: *layer.TimeGRU: Wx(3, 9), Wh(3, 9), B(1, 9): 63
+	//  5: *layer.TimeDropout: Ratio(0.5)
+	//  6: *layer.TimeGRU: Wx(3, 9), Wh(3, 9), B(1, 9): 63
+	//  7: *layer.TimeDropout: Ratio(0.5)
+	//  8: *layer.TimeTiedAffine: W(3, 5), B(1, 5): 5
+	//  9: *layer.TimeSoftmaxWithLoss
+	//
+	// [[[1.6094]]]
+	// 5 3
+	// true
+}
+
 func ExampleGRULM_Summary() {
 	m := model.NewGRULM(&model.LSTMLMConfig{
 		RNNLMConfig: model.RNNLMConfig{
@@ -11,7 +11,26 @@ import (
 
 type LSTMLMConfig struct {
 	RNNLMConfig
-	DropoutRatio float64
+	DropoutRatio float64 // the ratio of TimeDropout between the layers. The recurrent connections are not dropped
+	Layers       int     // the number of the recurrent layers, 2 if zero
+	WeightTying  bool    // the weight of TimeAffine is the transpose of TimeEmbedding.W, and WordVecSize must equal HiddenSize
+}
+
+// Validate returns an error if the config is not supported.
+func (c *LSTMLMConfig) Validate() error {
+	if err := c.RNNLMConfig.Validate(); err != nil {
+		return err
+	}
+
+	if c.Layers < 0 {
+		return fmt.Errorf("layers=%v must not be negative", c.Layers)
+	}
+
+	if c.WeightTying && c.WordVecSize != c.HiddenSize {
+		return fmt.Errorf("weight tying requires WordVecSize=%v to equal HiddenSize=%v", c.WordVecSize, c.HiddenSize)
+	}
+
+	return nil
 }
 
 type LSTMLM struct {
@@ -23,48 +42,65 @@ func NewLSTMLM(c *LSTMLMConfig, s ...randv2.Source) *LSTMLM {
 		s = append(s, rand.NewSource(rand.MustRead()))
 	}
 
+	return &LSTMLM{
+		RNNLM{
+			Layer: stacked(c, s[0], func(D, H int) TimeLayer {
+				return &layer.TimeLSTM{
+					Wx:       matrix.Randn(D, 4*H, s[0]).MulC(c.WeightInit(D)),
+					Wh:       matrix.Randn(H, 4*H, s[0]).MulC(c.WeightInit(H)),
+					B:        matrix.Zero(1, 4*H),
+					Stateful: true,
+				}
+			}),
+			Source: s[0],
+		},
+	}
+}
+
+// stacked returns the layers of the language model with the recurrent layers of newRNN.
+// TimeEmbedding -> TimeDropout -> (RNN -> TimeDropout) x Layers -> TimeAffine -> TimeSoftmaxWithLoss.
+// It panics if the config is not valid.
+func stacked(c *LSTMLMConfig, s randv2.Source, newRNN func(D, H int) TimeLayer) []TimeLayer {
+	if err := c.Validate(); err != nil {
+		panic(err)
+	}
+
 	// size
 	V, D, H := c.VocabSize, c.WordVecSize, c.HiddenSize
+	L := c.Layers
+	if L == 0 {
+		L = 2
+	}
+
+	embedding := &layer.TimeEmbedding{
+		W: matrix.Randn(V, D, s).MulC(1.0 / 100),
+	}
 
-	// layer
 	layers := []TimeLayer{
-		&layer.TimeEmbedding{
-			W: matrix.Randn(V, D, s[0]).MulC(1.0 / 100),
-		},
-		&layer.TimeDropout{
-			Ratio: c.DropoutRatio,
-		},
-		&layer.TimeLSTM{
-			Wx:       matrix.Randn(D, 4*H, s[0]).MulC(c.WeightInit(D)),
-			Wh:       matrix.Randn(H, 4*H, s[0]).MulC(c.WeightInit(H)),
-			B:        matrix.Zero(1, 4*H),
-			Stateful: true,
-		},
-		&layer.TimeDropout{
-			Ratio: c.DropoutRatio,
-		},
-		&layer.TimeLSTM{
-			Wx:       matrix.Randn(H, 4*H, s[0]).MulC(c.WeightInit(H)),
-			Wh:       matrix.Randn(H, 4*H, s[0]).MulC(c.WeightInit(H)),
-			B:        matrix.Zero(1, 4*H),
-			Stateful: true,
-		},
-		&layer.TimeDropout{
-			Ratio: c.DropoutRatio,
-		},
-		&layer.TimeAffine{
-			W: matrix.Randn(D, V, s[0]).MulC(c.WeightInit(H)),
-			B: matrix.Zero(1, V),
-		},
-		&layer.TimeSoftmaxWithLoss{},
+		embedding,
+		&layer.TimeDropout{Ratio: c.DropoutRatio},
 	}
 
-	return &LSTMLM{
-		RNNLM{
-			Layer:  layers,
-			Source: s[0],
-		},
+	for i := 0; i < L; i++ {
+		in := H
+		if i == 0 {
+			in = D
+		}
+
+		layers = append(layers, newRNN(in, H), &layer.TimeDropout{Ratio: c.DropoutRatio})
+	}
+
+	if c.WeightTying {
+		return append(layers, &layer.TimeTiedAffine{
+			Embedding: embedding,
+			B:         matrix.Zero(1, V),
+		}, &layer.TimeSoftmaxWithLoss{})
 	}
+
+	return append(layers, &layer.TimeAffine{
+		W: matrix.Randn(H, V, s).MulC(c.WeightInit(H)),
+		B: matrix.Zero(1, V),
+	}, &layer.TimeSoftmaxWithLoss{})
 }
 
 func (m *LSTMLM) Summary() []string {
@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	randv2 "math/rand/v2"
+	"slices"
 
 	"github.com/itsubaki/neu/layer"
 	"github.com/itsubaki/neu/math/matrix"
@@ -92,6 +93,14 @@ func (m *Seq2Seq) Params() [][]matrix.Matrix {
 	}
 }
 
+// ParamNames returns the names of the params of the encoder and the decoder, e.g. TimeEmbedding.W.
+func (m *Seq2Seq) ParamNames() [][]string {
+	return [][]string{
+		slices.Concat(paramNames(m.Encoder.Layers())...),
+		slices.Concat(paramNames(m.Decoder.Layers())...),
+	}
+}
+
 func (m *Seq2Seq) Grads() [][]matrix.Matrix {
 	return [][]matrix.Matrix{
 		m.Encoder.Grads(),
@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	randv2 "math/rand/v2"
+	"slices"
 
 	"github.com/itsubaki/neu/layer"
 	"github.com/itsubaki/neu/math/matrix"
@@ -73,6 +74,14 @@ func (m *AttentionSeq2Seq) Params() [][]matrix.Matrix {
 	}
 }
 
+// ParamNames returns the names of the params of the encoder and the decoder, e.g. TimeEmbedding.W.
+func (m *AttentionSeq2Seq) ParamNames() [][]string {
+	return [][]string{
+		slices.Concat(paramNames(m.Encoder.Layers())...),
+		m.Decoder.ParamNames(),
+	}
+}
+
 func (m *AttentionSeq2Seq) Grads() [][]matrix.Matrix {
 	return [][]matrix.Matrix{
 		m.Encoder.Grads(),
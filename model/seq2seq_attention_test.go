@@ -28,8 +28,36 @@ func ExampleAttentionSeq2Seq() {
 	fmt.Println(m.Generate(xs, 1, 10))
 
 	// Output:
-	// [[[1.0958]]]
-	// [1 1 1 1 1 1 1 1 1 1]
+	// [[[1.0981]]]
+	// [0 1 0 1 0 1 0 1 0 1]
+}
+
+func ExampleAttentionSeq2Seq_score() {
+	for _, score := range []model.AttentionScore{model.GeneralScore, model.AdditiveScore} {
+		m := model.NewAttentionSeq2Seq(&model.RNNLMConfig{
+			VocabSize:      3, // V
+			WordVecSize:    3, // D
+			HiddenSize:     3, // H
+			WeightInit:     weight.Xavier,
+			AttentionScore: score,
+		}, rand.Const(1))
+
+		xs := []matrix.Matrix{{{0, 1, 2}}, {{0, 1, 2}}, {{0, 1, 2}}}
+		loss := m.Forward(xs, xs)
+		m.Backward()
+
+		fmt.Printf("%.4f\n", loss)
+		fmt.Println(m.ParamNames()[1][6:])
+		fmt.Println(len(m.Params()[1]), len(m.Grads()[1]))
+	}
+
+	// Output:
+	// [[[1.0956]]]
+	// [TimeAttention.W]
+	// 7 7
+	// [[[1.0999]]]
+	// [TimeAttention.W1 TimeAttention.W2 TimeAttention.V]
+	// 9 9
 }
 
 func ExampleAttentionSeq2Seq_Summary() {
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"fmt"
+	randv2 "math/rand/v2"
+	"slices"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+)
+
+type TransformerConfig struct {
+	VocabSize       int
+	HiddenSize      int  // the size of the embeddings and the outputs of the blocks
+	Heads           int  // the number of the attention heads, which divides HiddenSize
+	FeedForwardSize int  // the hidden size of the position-wise feed-forward network
+	Layers          int  // the number of the encoder and the decoder blocks
+	MaxLength       int  // the max length of the sequences
+	LearnedPosition bool // the positional encoding is learned if true, or sinusoidal
+	DropoutRatio    float64
+	WeightInit      WeightInit
+}
+
+func (c *TransformerConfig) attention(causal bool, s randv2.Source) *layer.MultiHeadAttention {
+	H := c.HiddenSize
+	return &layer.MultiHeadAttention{
+		Wq:           matrix.Randn(H, H, s).MulC(c.WeightInit(H)),
+		Bq:           matrix.Zero(1, H),
+		Wk:           matrix.Randn(H, H, s).MulC(c.WeightInit(H)),
+		Wv:           matrix.Randn(H, H, s).MulC(c.WeightInit(H)),
+		Bv:           matrix.Zero(1, H),
+		Wo:           matrix.Randn(H, H, s).MulC(c.WeightInit(H)),
+		Bo:           matrix.Zero(1, H),
+		Heads:        c.Heads,
+		Causal:       causal,
+		DropoutRatio: c.DropoutRatio,
+	}
+}
+
+func (c *TransformerConfig) norm() *layer.TimeLayerNorm {
+	return &layer.TimeLayerNorm{
+		Gamma: matrix.One(1, c.HiddenSize),
+		Beta:  matrix.Zero(1, c.HiddenSize),
+	}
+}
+
+func (c *TransformerConfig) position(s randv2.Source) *layer.TimePositionalEncoding {
+	if c.LearnedPosition {
+		return &layer.TimePositionalEncoding{
+			W:       matrix.Randn(c.MaxLength, c.HiddenSize, s).MulC(1.0 / 100),
+			Learned: true,
+		}
+	}
+
+	return &layer.TimePositionalEncoding{
+		W: layer.Sinusoidal(c.MaxLength, c.HiddenSize),
+	}
+}
+
+type TransformerSeq2Seq struct {
+	Encoder *TransformerEncoder
+	Decoder *TransformerDecoder
+	Softmax *layer.TimeSoftmaxWithLoss
+	Source  randv2.Source
+}
+
+func NewTransformerSeq2Seq(c *TransformerConfig, s ...randv2.Source) *TransformerSeq2Seq {
+	if len(s) == 0 {
+		s = append(s, rand.NewSource(rand.MustRead()))
+	}
+
+	return &TransformerSeq2Seq{
+		Encoder: NewTransformerEncoder(c, s[0]),
+		Decoder: NewTransformerDecoder(c, s[0]),
+		Softmax: &layer.TimeSoftmaxWithLoss{},
+		Source:  s[0],
+	}
+}
+
+// Forward returns the loss in the training mode, where the dropout uses m.Source.
+func (m *TransformerSeq2Seq) Forward(xs, ts []matrix.Matrix) []matrix.Matrix {
+	return m.forward(xs, ts, layer.Opts{Train: true, Source: m.Source})
+}
+
+// Loss returns the loss in the inference mode, without the dropout.
+// It does not use m.Source, so the loss does not change the training that follows.
+func (m *TransformerSeq2Seq) Loss(xs, ts []matrix.Matrix) []matrix.Matrix {
+	return m.forward(xs, ts, layer.Opts{})
+}
+
+func (m *TransformerSeq2Seq) forward(xs, ts []matrix.Matrix, opts layer.Opts) []matrix.Matrix {
+	dxs, dts := ts[:len(ts)-1], ts[1:]
+	h := m.Encoder.Forward(xs, opts)
+	score := m.Decoder.Forward(dxs, h, opts)
+	loss := m.Softmax.Forward(score, dts)
+	return loss
+}
+
+func (m *TransformerSeq2Seq) Backward() {
+	dout := []matrix.Matrix{{{1}}}
+	dscore := m.Softmax.Backward(dout)
+	dh := m.Decoder.Backward(dscore)
+	m.Encoder.Backward(dh)
+}
+
+func (m *TransformerSeq2Seq) Generate(xs []matrix.Matrix, startID, length int) []int {
+	h := m.Encoder.Forward(xs)
+	sampled := m.Decoder.Generate(h, startID, length)
+	return sampled
+}
+
+func (m *TransformerSeq2Seq) Summary() []string {
+	s := []string{fmt.Sprintf("%T", m)}
+	s = append(s, m.Encoder.Summary()...)
+	s = append(s, m.Decoder.Summary()...)
+	s = append(s, m.Softmax.String())
+	return s
+}
+
+func (m *TransformerSeq2Seq) Layers() []TimeLayer {
+	layers := make([]TimeLayer, 0)
+	layers = append(layers, m.Encoder.Layers()...)
+	layers = append(layers, m.Decoder.Layers()...)
+	layers = append(layers, m.Softmax)
+	return layers
+}
+
+func (m *TransformerSeq2Seq) Params() [][]matrix.Matrix {
+	return [][]matrix.Matrix{
+		m.Encoder.Params(),
+		m.Decoder.Params(),
+	}
+}
+
+// ParamNames returns the names of the params of the encoder and the decoder, e.g. TransformerEncoderBlock.Attention.Wq.
+func (m *TransformerSeq2Seq) ParamNames() [][]string {
+	return [][]string{
+		slices.Concat(paramNames(m.Encoder.Layers())...),
+		slices.Concat(paramNames(m.Decoder.Layers())...),
+	}
+}
+
+func (m *TransformerSeq2Seq) Grads() [][]matrix.Matrix {
+	return [][]matrix.Matrix{
+		m.Encoder.Grads(),
+		m.Decoder.Grads(),
+	}
+}
+
+func (m *TransformerSeq2Seq) SetParams(p [][]matrix.Matrix) {
+	m.Encoder.SetParams(p[0]...)
+	m.Decoder.SetParams(p[1]...)
+}
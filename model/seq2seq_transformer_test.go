@@ -0,0 +1,117 @@
+package model_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+	"github.com/itsubaki/neu/weight"
+)
+
+func transformerConfig() *model.TransformerConfig {
+	return &model.TransformerConfig{
+		VocabSize:       3, // V
+		HiddenSize:      4, // H
+		Heads:           2,
+		FeedForwardSize: 6, // F
+		Layers:          1,
+		MaxLength:       8,
+		WeightInit:      weight.Xavier,
+	}
+}
+
+func ExampleTransformerSeq2Seq() {
+	s := rand.Const(1)
+	m := model.NewTransformerSeq2Seq(transformerConfig(), s)
+
+	// (T, N, 1) = (3, 2, 1)
+	xs := []matrix.Matrix{{{0}, {1}}, {{1}, {2}}, {{2}, {0}}}
+	ts := []matrix.Matrix{{{0}, {1}}, {{1}, {2}}, {{2}, {0}}}
+
+	loss := m.Forward(xs, ts)
+	m.Backward()
+
+	fmt.Printf("%.4f\n", loss)
+	fmt.Println(m.Generate([]matrix.Matrix{{{0}}, {{1}}, {{2}}}, 1, 5))
+
+	// Output:
+	// [[[1.5835]]]
+	// [1 0 2 1 1]
+}
+
+func ExampleTransformerSeq2Seq_learned() {
+	c := transformerConfig()
+	c.LearnedPosition = true
+	c.DropoutRatio = 0.1
+	m := model.NewTransformerSeq2Seq(c, rand.Const(1))
+
+	xs := []matrix.Matrix{{{0}, {1}}, {{1}, {2}}, {{2}, {0}}}
+	loss := m.Forward(xs, xs)
+	m.Backward()
+
+	fmt.Printf("%.4f\n", loss)
+	fmt.Println(m.ParamNames()[0][:2])
+
+	// Output:
+	// [[[1.2444]]]
+	// [TimeEmbedding.W TimePositionalEncoding.W]
+}
+
+func ExampleTransformerSeq2Seq_Summary() {
+	m := model.NewTransformerSeq2Seq(transformerConfig())
+
+	fmt.Println(m.Summary()[0])
+	for i, s := range m.Summary()[1:] {
+		fmt.Printf("%2d: %v\n", i, s)
+	}
+
+	// Output:
+	// *model.TransformerSeq2Seq
+	//  0: *model.TransformerEncoder
+	//  1: *layer.TimeEmbedding: W(3, 4): 12
+	//  2: *layer.TimePositionalEncoding: W(8, 4), Learned(false)
+	//  3: *layer.TransformerEncoderBlock: H(4), FF(6), Heads(2): 150
+	//  4: *model.TransformerDecoder
+	//  5: *layer.TimeEmbedding: W(3, 4): 12
+	//  6: *layer.TimePositionalEncoding: W(8, 4), Learned(false)
+	//  7: *layer.TransformerDecoderBlock: H(4), FF(6), Heads(2): 234
+	//  8: *layer.TimeAffine: W(4, 3), B(1, 3): 15
+	//  9: *layer.TimeSoftmaxWithLoss
+}
+
+func ExampleTransformerSeq2Seq_Layers() {
+	m := model.NewTransformerSeq2Seq(transformerConfig())
+
+	fmt.Printf("%T\n", m)
+	for i, l := range m.Layers() {
+		fmt.Printf("%2d: %v\n", i, l)
+	}
+
+	// Output:
+	// *model.TransformerSeq2Seq
+	//  0: *layer.TimeEmbedding: W(3, 4): 12
+	//  1: *layer.TimePositionalEncoding: W(8, 4), Learned(false)
+	//  2: *layer.TransformerEncoderBlock: H(4), FF(6), Heads(2): 150
+	//  3: *layer.TimeEmbedding: W(3, 4): 12
+	//  4: *layer.TimePositionalEncoding: W(8, 4), Learned(false)
+	//  5: *layer.TransformerDecoderBlock: H(4), FF(6), Heads(2): 234
+	//  6: *layer.TimeAffine: W(4, 3), B(1, 3): 15
+	//  7: *layer.TimeSoftmaxWithLoss
+}
+
+func ExampleTransformerSeq2Seq_Params() {
+	m := model.NewTransformerSeq2Seq(transformerConfig())
+	fmt.Println(len(m.Params()[0]), len(m.Params()[1]))
+	fmt.Println(len(m.ParamNames()[0]), len(m.ParamNames()[1]))
+	fmt.Println(m.ParamNames()[1][:3])
+
+	m.SetParams(m.Grads())
+	fmt.Println(m.Params()[0][:2])
+
+	// Output:
+	// 16 27
+	// 16 27
+	// [TimeEmbedding.W TransformerDecoderBlock.SelfAttention.Wq TransformerDecoderBlock.SelfAttention.Bq]
+	// [[] []]
+}
@@ -65,6 +65,10 @@ func (m *Sequential) Params() [][]matrix.Matrix {
 	return params
 }
 
+func (m *Sequential) ParamNames() [][]string {
+	return paramNames(m.Layer)
+}
+
 func (m *Sequential) Grads() [][]matrix.Matrix {
 	grads := make([][]matrix.Matrix, 0)
 	for _, l := range m.Layer {
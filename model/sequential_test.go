@@ -93,6 +93,119 @@ func ExampleSequential_gradientCheck() {
 	// 21: 3.333775858149757e-08
 }
 
+func ExampleSequential_convolution() {
+	// weight
+	s := rand.Const(1)
+	W1 := matrix.Randn(2*2*2, 3, s).MulC(weight.He(2 * 2 * 2))
+	B1 := matrix.Zero(1, 3)
+	W2 := matrix.Randn(3*2*2, 2, s).MulC(weight.He(3 * 2 * 2))
+	B2 := matrix.Zero(1, 2)
+
+	// model
+	m := model.NewSequential(
+		[]model.Layer{
+			&layer.Convolution{
+				W:            W1,
+				B:            B1,
+				Channel:      2,
+				Height:       3,
+				Width:        3,
+				FilterHeight: 2,
+				FilterWidth:  2,
+				Stride:       1,
+			},
+			&layer.ReLU{},
+			&layer.Affine{W: W2, B: B2},
+			&layer.SoftmaxWithLoss{},
+		},
+		s,
+	)
+
+	// gradients
+	x := matrix.Randn(2, 2*3*3, s)
+	t := matrix.New([]float64{1, 0}, []float64{0, 1})
+
+	m.Forward(x, t)
+	m.Backward()
+	grads := m.Grads()
+	gradsn := numericalGrads(m, x, t)
+
+	// check
+	for i := range gradsn {
+		for j := range gradsn[i] {
+			eps := gradsn[i][j].Sub(grads[i][j]).Abs().Mean() // mean(| A - B |)
+			fmt.Printf("%v%v: %v\n", i, j, eps < 1e-6)
+		}
+	}
+
+	// Output:
+	// 00: true
+	// 01: true
+	// 20: true
+	// 21: true
+}
+
+func ExampleSequential_pooling() {
+	// data
+	s := rand.Const(1)
+	x := matrix.Randn(2, 1*4*4, s)
+	t := matrix.New([]float64{1, 0}, []float64{0, 1})
+
+	for _, pool := range [][]model.Layer{
+		{
+			&layer.MaxPooling{Channel: 2, Height: 4, Width: 4, PoolHeight: 2, PoolWidth: 2, Stride: 2},
+			&layer.Affine{W: matrix.Randn(2*2*2, 2, s).MulC(weight.He(8)), B: matrix.Zero(1, 2)},
+		},
+		{
+			&layer.AvgPooling{Channel: 2, Height: 4, Width: 4, PoolHeight: 2, PoolWidth: 2, Stride: 2},
+			&layer.GlobalAvgPooling{Channel: 2},
+			&layer.Affine{W: matrix.Randn(2, 2, s).MulC(weight.He(2)), B: matrix.Zero(1, 2)},
+		},
+	} {
+		// model
+		layers := []model.Layer{
+			&layer.Convolution{
+				W:            matrix.Randn(1*3*3, 2, s).MulC(weight.He(9)),
+				B:            matrix.Zero(1, 2),
+				Channel:      1,
+				Height:       4,
+				Width:        4,
+				FilterHeight: 3,
+				FilterWidth:  3,
+				Stride:       1,
+				Pad:          1,
+			},
+		}
+		layers = append(layers, pool...)
+		layers = append(layers, &layer.SoftmaxWithLoss{})
+		m := model.NewSequential(layers, s)
+
+		// gradients
+		m.Forward(x, t)
+		m.Backward()
+		grads := m.Grads()
+		gradsn := numericalGrads(m, x, t)
+
+		// check
+		for i := range gradsn {
+			for j := range gradsn[i] {
+				eps := gradsn[i][j].Sub(grads[i][j]).Abs().Mean() // mean(| A - B |)
+				fmt.Printf("%v%v: %v\n", i, j, eps < 1e-6)
+			}
+		}
+	}
+
+	// Output:
+	// 00: true
+	// 01: true
+	// 20: true
+	// 21: true
+	// 00: true
+	// 01: true
+	// 30: true
+	// 31: true
+}
+
 func ExampleSequential_Summary() {
 	m := model.NewSequential(
 		[]model.Layer{
@@ -0,0 +1,72 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
+)
+
+// AdaDelta is an optimizer that implements the AdaDelta algorithm.
+type AdaDelta = AdaDeltaOf[float64]
+
+// AdaDeltaOf is AdaDelta for the parameters of T.
+type AdaDeltaOf[T vector.Float] struct {
+	LearningRate T // the scale of the update. 1.0 in the paper
+	Rho          T // e.g. 0.95
+	Epsilon      T // 1e-6 if zero
+	Hooks        []HookOf[T]
+	Groups       []GroupOf[T]
+	h, s         [][]matrix.Dense[T]
+}
+
+// Update updates the parameters of the model.
+func (o *AdaDeltaOf[T]) Update(m ModelOf[T]) [][]matrix.Dense[T] {
+	params, grads := m.Params(), m.Grads()
+	groups := groupsOf(o.Groups, m, params)
+	grads = hooked(o.Hooks, groups, params, grads)
+	grads = decayed(groups, params, grads)
+
+	if len(o.h) == 0 {
+		o.h, o.s = ZeroLike(params), ZeroLike(params)
+	}
+
+	eps := o.Epsilon
+	if eps == 0 {
+		eps = 1e-6
+	}
+
+	updated := ZeroLike(params)
+	for i := range params {
+		for j := range params[i] {
+			g := group(groups, i, j)
+			if g.frozen() {
+				updated[i][j] = params[i][j]
+				continue
+			}
+
+			o.h[i][j] = matrix.F2(o.h[i][j], grads[i][j], rmsprop(o.Rho))          // h[k] = rho * h[k] + (1 - rho) * grads[k] * grads[k]
+			dx := matrix.F3(grads[i][j], o.h[i][j], o.s[i][j], adadelta(eps))      // dx[k] = sqrt(s[k] + eps) / sqrt(h[k] + eps) * grads[k]
+			o.s[i][j] = matrix.F2(o.s[i][j], dx, rmsprop(o.Rho))                   // s[k] = rho * s[k] + (1 - rho) * dx[k] * dx[k]
+			updated[i][j] = matrix.F2(params[i][j], dx, sgd(g.lr(o.LearningRate))) // params[k] = params[k] - learningRate * dx[k]
+		}
+	}
+
+	m.SetParams(updated)
+	return updated
+}
+
+// State returns the internal state.
+func (o *AdaDeltaOf[T]) State() *StateOf[T] {
+	return &StateOf[T]{Slots: map[string][][]matrix.Dense[T]{"h": Clone(o.h), "s": Clone(o.s)}}
+}
+
+// SetState sets the internal state.
+func (o *AdaDeltaOf[T]) SetState(s *StateOf[T]) { o.h, o.s = Clone(s.Slots["h"]), Clone(s.Slots["s"]) }
+
+// SetLearningRate sets the learning rate.
+func (o *AdaDeltaOf[T]) SetLearningRate(lr T) { o.LearningRate = lr }
+
+func adadelta[T vector.Float](eps T) func(g, h, s T) T {
+	return func(g, h, s T) T { return T(math.Sqrt(float64(s+eps))/math.Sqrt(float64(h+eps))) * g }
+}
@@ -0,0 +1,15 @@
+package optimizer_test
+
+import (
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/optimizer/hook"
+)
+
+func ExampleAdaDelta() {
+	steps(&optimizer.AdaDelta{LearningRate: 1.0, Rho: 0.95, Hooks: []optimizer.Hook{hook.WeightDecay(0.1)}}, 3)
+
+	// Output:
+	// [[0.995528 -1.995528] [2.995528 0.495546]]
+	// [[0.991001 -1.991000] [2.990999 0.491055]]
+	// [[0.986437 -1.986434] [2.986433 0.486552]]
+}
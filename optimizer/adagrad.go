@@ -4,21 +4,26 @@ import (
 	"math"
 
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
 // AdaGrad is an optimizer that implements the AdaGrad algorithm.
-type AdaGrad struct {
-	LearningRate float64
-	Hooks        []Hook
-	h            [][]matrix.Matrix
+type AdaGrad = AdaGradOf[float64]
+
+// AdaGradOf is AdaGrad for the parameters of T.
+type AdaGradOf[T vector.Float] struct {
+	LearningRate T
+	Hooks        []HookOf[T]
+	Groups       []GroupOf[T]
+	h            [][]matrix.Dense[T]
 }
 
 // Update updates the parameters of the model.
-func (o *AdaGrad) Update(m Model) [][]matrix.Matrix {
+func (o *AdaGradOf[T]) Update(m ModelOf[T]) [][]matrix.Dense[T] {
 	params, grads := m.Params(), m.Grads()
-	for _, h := range o.Hooks {
-		grads = h(params, grads)
-	}
+	groups := groupsOf(o.Groups, m, params)
+	grads = hooked(o.Hooks, groups, params, grads)
+	grads = decayed(groups, params, grads)
 
 	if len(o.h) == 0 {
 		o.h = ZeroLike(params)
@@ -27,8 +32,14 @@ func (o *AdaGrad) Update(m Model) [][]matrix.Matrix {
 	updated := ZeroLike(params)
 	for i := range params {
 		for j := range params[i] {
-			o.h[i][j] = o.h[i][j].Add(grads[i][j].Pow2())                                            // h[k] = h[k] + grads[k] * grads[k]
-			updated[i][j] = matrix.F3(params[i][j], grads[i][j], o.h[i][j], adagrad(o.LearningRate)) // params[k] = params[k] - o.LearningRate * grads[k]/sqrt(h[k])
+			g := group(groups, i, j)
+			if g.frozen() {
+				updated[i][j] = params[i][j]
+				continue
+			}
+
+			o.h[i][j] = o.h[i][j].Add(grads[i][j].Pow2())                                                  // h[k] = h[k] + grads[k] * grads[k]
+			updated[i][j] = matrix.F3(params[i][j], grads[i][j], o.h[i][j], adagrad(g.lr(o.LearningRate))) // params[k] = params[k] - o.LearningRate * grads[k]/sqrt(h[k])
 		}
 	}
 
@@ -36,6 +47,17 @@ func (o *AdaGrad) Update(m Model) [][]matrix.Matrix {
 	return updated
 }
 
-func adagrad(learningRate float64) func(p, a, b float64) float64 {
-	return func(p, a, b float64) float64 { return p - learningRate*a/(math.Sqrt(b)+1e-7) }
+// State returns the internal state.
+func (o *AdaGradOf[T]) State() *StateOf[T] {
+	return &StateOf[T]{Slots: map[string][][]matrix.Dense[T]{"h": Clone(o.h)}}
+}
+
+// SetState sets the internal state.
+func (o *AdaGradOf[T]) SetState(s *StateOf[T]) { o.h = Clone(s.Slots["h"]) }
+
+// SetLearningRate sets the learning rate.
+func (o *AdaGradOf[T]) SetLearningRate(lr T) { o.LearningRate = lr }
+
+func adagrad[T vector.Float](learningRate T) func(p, a, b T) T {
+	return func(p, a, b T) T { return p - learningRate*a/(T(math.Sqrt(float64(b)))+1e-7) }
 }
@@ -4,37 +4,81 @@ import (
 	"math"
 
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
-type Adam struct {
-	Alpha        float64
-	Beta1, Beta2 float64
-	Hooks        []Hook
-	m, v         [][]matrix.Matrix
+type Adam = AdamOf[float64]
+
+// AdamOf is Adam for the parameters of T.
+type AdamOf[T vector.Float] struct {
+	Alpha        T
+	Beta1, Beta2 T
+	Hooks        []HookOf[T]
+	Groups       []GroupOf[T]
+	m, v         [][]matrix.Dense[T]
+	active       [][][]bool // rows with non-zero moments
 	iter         int
 }
 
-func (o *Adam) Update(m Model) [][]matrix.Matrix {
+// Update updates the parameters of the model.
+// If the model is a SparseModelOf, the row-sparse gradients update only the rows that have ever had a gradient,
+// since the other rows have zero moments and are not changed by the dense update either.
+// The rows that have ever had a gradient are updated at every step, so the cost grows with them up to all rows.
+func (o *AdamOf[T]) Update(m ModelOf[T]) [][]matrix.Dense[T] {
 	params, grads := m.Params(), m.Grads()
-	for _, h := range o.Hooks {
-		grads = h(params, grads)
-	}
+	raw := grads
+	groups := groupsOf(o.Groups, m, params)
+	grads = hooked(o.Hooks, groups, params, grads)
+	grads = decayed(groups, params, grads)
 
 	if len(o.m) == 0 {
 		o.m, o.v = ZeroLike(params), ZeroLike(params)
+		o.active = make([][][]bool, len(params))
+		for i := range params {
+			o.active[i] = make([][]bool, len(params[i]))
+			for j := range params[i] {
+				o.active[i][j] = make([]bool, len(params[i][j]))
+			}
+		}
 	}
 
 	o.iter++
-	fix1 := 1.0 - math.Pow(o.Beta1, float64(o.iter)) // 1 - beta1^t
-	fix2 := 1.0 - math.Pow(o.Beta2, float64(o.iter)) // 1 - beta2^t
-	lr := o.Alpha * math.Sqrt(fix2) / fix1           // lr * sqrt(1 - beta2^t) / (1 - beta1^t)
+	fix1 := 1.0 - math.Pow(float64(o.Beta1), float64(o.iter)) // 1 - beta1^t
+	fix2 := 1.0 - math.Pow(float64(o.Beta2), float64(o.iter)) // 1 - beta2^t
+	lr := T(float64(o.Alpha) * math.Sqrt(fix2) / fix1)        // lr * sqrt(1 - beta2^t) / (1 - beta1^t)
 
-	updated := ZeroLike(params)
+	index := sparseIndex(m, raw, grads)
+	updated := make([][]matrix.Dense[T], len(params))
 	for i := range params {
+		updated[i] = make([]matrix.Dense[T], len(params[i]))
 		for j := range params[i] {
+			g := group(groups, i, j)
+			if g.frozen() {
+				updated[i][j] = params[i][j]
+				continue
+			}
+
+			if lazy(index, i, j) {
+				for _, k := range index[i][j] {
+					o.active[i][j][k] = true
+				}
+
+				updated[i][j] = shallow(params[i][j])
+				for k, ok := range o.active[i][j] {
+					if !ok {
+						continue
+					}
+
+					updated[i][j][k] = adamRow(params[i][j][k], grads[i][j][k], o.m[i][j][k], o.v[i][j][k], o.Beta1, o.Beta2, adam(g.lr(lr)))
+				}
+
+				continue
+			}
+
 			o.m[i][j] = o.m[i][j].Add(grads[i][j].Sub(o.m[i][j]).MulC(1.0 - o.Beta1))        // m = m + (1 - beta1) * (grads - m)
 			o.v[i][j] = o.v[i][j].Add(grads[i][j].Pow2().Sub(o.v[i][j]).MulC(1.0 - o.Beta2)) // v = v + (1 - beta2) * (grads * grads - v)
-			updated[i][j] = matrix.F3(params[i][j], o.m[i][j], o.v[i][j], adam(lr))          // params = params - lrt * m / (sqrt(v) + 1e-7)
+			updated[i][j] = matrix.F3(params[i][j], o.m[i][j], o.v[i][j], adam(g.lr(lr)))    // params = params - lrt * m / (sqrt(v) + 1e-7)
+			activate(o.active[i][j], grads[i][j])
 		}
 	}
 
@@ -42,6 +86,59 @@ func (o *Adam) Update(m Model) [][]matrix.Matrix {
 	return updated
 }
 
-func adam(learningRate float64) func(p, m, v float64) float64 {
-	return func(p, m, v float64) float64 { return p - learningRate*m/(math.Sqrt(v)+1e-7) }
+// State returns the internal state.
+func (o *AdamOf[T]) State() *StateOf[T] {
+	return &StateOf[T]{Iter: o.iter, Slots: map[string][][]matrix.Dense[T]{"m": Clone(o.m), "v": Clone(o.v)}}
+}
+
+// SetState sets the internal state.
+// The rows with non-zero moments are restored from the moments.
+func (o *AdamOf[T]) SetState(s *StateOf[T]) {
+	o.iter, o.m, o.v = s.Iter, Clone(s.Slots["m"]), Clone(s.Slots["v"])
+
+	o.active = make([][][]bool, len(o.m))
+	for i := range o.m {
+		o.active[i] = make([][]bool, len(o.m[i]))
+		for j := range o.m[i] {
+			o.active[i][j] = make([]bool, len(o.m[i][j]))
+			activate(o.active[i][j], o.m[i][j])
+			activate(o.active[i][j], o.v[i][j])
+		}
+	}
+}
+
+// SetLearningRate sets the learning rate, Alpha.
+func (o *AdamOf[T]) SetLearningRate(lr T) { o.Alpha = lr }
+
+// activate sets active[k] to true if the row k of m is non-zero.
+func activate[T vector.Float](active []bool, m matrix.Dense[T]) {
+	for k := range m {
+		if active[k] {
+			continue
+		}
+
+		for _, v := range m[k] {
+			if v != 0 {
+				active[k] = true
+				break
+			}
+		}
+	}
+}
+
+// adamRow updates the moments m and v of a row in place, and returns the updated row of p.
+// It is the dense update for one row without the intermediate matrices.
+func adamRow[T vector.Float](p, d, m, v []T, beta1, beta2 T, f func(p, m, v T) T) []T {
+	out := make([]T, len(p))
+	for c := range p {
+		m[c] = m[c] + (d[c]-m[c])*(1.0-beta1)
+		v[c] = v[c] + (d[c]*d[c]-v[c])*(1.0-beta2)
+		out[c] = f(p[c], m[c], v[c])
+	}
+
+	return out
+}
+
+func adam[T vector.Float](learningRate T) func(p, m, v T) T {
+	return func(p, m, v T) T { return p - learningRate*m/(T(math.Sqrt(float64(v)))+1e-7) }
 }
@@ -2,6 +2,7 @@ package optimizer_test
 
 import (
 	"fmt"
+	"testing"
 
 	"github.com/itsubaki/neu/math/matrix"
 	"github.com/itsubaki/neu/optimizer"
@@ -39,3 +40,111 @@ func ExampleAdam() {
 	// [[0.5000004566633607 1.5000002283317846 2.500000152221213] [3.5000001141659185 4.500000091332739 5.500000076110618]]
 
 }
+
+func ExampleAdam_sparse() {
+	p := matrix.New([]float64{1, 2, 3}, []float64{4, 5, 6}, []float64{7, 8, 9}, []float64{10, 11, 12})
+	dense := &TestModelOf[float64]{params: [][]matrix.Matrix{{p}}}
+	sparse := &TestSparseModel{TestModelOf: TestModelOf[float64]{params: [][]matrix.Matrix{{p}}}}
+
+	o1 := &optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999}
+	o2 := &optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999}
+	for _, g := range sparseGrads() {
+		dense.grads = [][]matrix.Matrix{{g.Dense()}}
+		sparse.grads, sparse.sparse = [][]matrix.Matrix{{g.View()}}, [][]*matrix.Sparse{{g}}
+
+		d, s := o1.Update(dense)[0][0], o2.Update(sparse)[0][0]
+		fmt.Println(d.Sub(s).Abs().Sum())
+	}
+	fmt.Println(p)
+
+	// Output:
+	// 0
+	// 0
+	// 0
+	// [[1 2 3] [4 5 6] [7 8 9] [10 11 12]]
+}
+
+func ExampleAdam_lazy() {
+	p := matrix.New([]float64{1, 2, 3}, []float64{4, 5, 6}, []float64{7, 8, 9}, []float64{10, 11, 12})
+	m := &TestSparseModel{TestModelOf: TestModelOf[float64]{params: [][]matrix.Matrix{{p}}}}
+
+	// lazy returns true if the row 2, which has never had a gradient, is not updated
+	lazy := func(o *optimizer.Adam) bool {
+		before := m.Params()[0][0]
+		return &o.Update(m)[0][0][2][0] == &before[2][0]
+	}
+
+	o := &optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, Hooks: []optimizer.Hook{hook.GradsClipping(1.0)}}
+	for _, g := range sparseGrads() {
+		m.grads, m.sparse = [][]matrix.Matrix{{g.View()}}, [][]*matrix.Sparse{{g}}
+		fmt.Println(lazy(o))
+	}
+
+	// dense
+	m.grads, m.sparse = [][]matrix.Matrix{{sparseGrads()[0].Dense()}}, nil
+	fmt.Println(lazy(o))
+
+	// resume
+	r := &optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999}
+	r.SetState(o.State())
+
+	g := sparseGrads()[1]
+	m.grads, m.sparse = [][]matrix.Matrix{{g.View()}}, [][]*matrix.Sparse{{g}}
+	fmt.Println(lazy(r))
+
+	// Output:
+	// true
+	// true
+	// true
+	// false
+	// true
+}
+
+func ExampleAdam_State() {
+	m := &TestModel{
+		params: [][]matrix.Matrix{{{{1, 2, 3}, {4, 5, 6}}}},
+		grads:  [][]matrix.Matrix{{{{2, 4, 6}, {8, 10, 12}}}},
+	}
+
+	o1 := &optimizer.Adam{Alpha: 0.5, Beta1: 0.9, Beta2: 0.999}
+	o1.Update(m)
+
+	o2 := &optimizer.Adam{Alpha: 0.5, Beta1: 0.9, Beta2: 0.999}
+	o2.SetState(o1.State())
+
+	fmt.Println(o1.State().Iter)
+	fmt.Println(o1.Update(m)[0][0])
+	fmt.Println(o2.Update(m)[0][0])
+
+	// Output:
+	// 1
+	// [[0.5000005591561794 1.5000002795782477 2.5000001863855346] [3.5000001397891647 4.500000111831339 5.500000093192786]]
+	// [[0.5000005591561794 1.5000002795782477 2.5000001863855346] [3.5000001397891647 4.500000111831339 5.500000093192786]]
+}
+
+func benchmarkAdam(b *testing.B, sparse bool, rows, cols, batch int) {
+	p := matrix.Zero(rows, cols)
+	m := &TestSparseModel{TestModelOf: TestModelOf[float64]{params: [][]matrix.Matrix{{p}}}}
+	o := &optimizer.Adam{Alpha: 0.001, Beta1: 0.9, Beta2: 0.999}
+
+	index := make([]int, batch)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for k := range index {
+			index[k] = (i*batch + k) % rows
+		}
+
+		g := matrix.NewSparse(rows, cols, index, matrix.One(batch, cols))
+		m.grads, m.sparse = [][]matrix.Matrix{{g.View()}}, [][]*matrix.Sparse{{g}}
+		if !sparse {
+			m.grads, m.sparse = [][]matrix.Matrix{{g.Dense()}}, nil
+		}
+
+		o.Update(m)
+	}
+}
+
+// The row-sparse update of an Embedding, (10000, 100), with 20 rows per step.
+// All rows have had a gradient after 500 steps, and the sparse update updates them at every step after that.
+func BenchmarkAdam_sparse(b *testing.B) { benchmarkAdam(b, true, 10000, 100, 20) }
+func BenchmarkAdam_dense(b *testing.B)  { benchmarkAdam(b, false, 10000, 100, 20) }
@@ -0,0 +1,71 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
+)
+
+// AdamW is an optimizer that implements Adam with the decoupled weight decay.
+type AdamW = AdamWOf[float64]
+
+// AdamWOf is AdamW for the parameters of T.
+type AdamWOf[T vector.Float] struct {
+	Alpha        T
+	Beta1, Beta2 T
+	WeightDecay  T // e.g. 0.01
+	Hooks        []HookOf[T]
+	Groups       []GroupOf[T]
+	m, v         [][]matrix.Dense[T]
+	iter         int
+}
+
+// Update updates the parameters of the model.
+// The weight decay is applied to the parameters directly, not to the gradients.
+func (o *AdamWOf[T]) Update(m ModelOf[T]) [][]matrix.Dense[T] {
+	params, grads := m.Params(), m.Grads()
+	groups := groupsOf(o.Groups, m, params)
+	grads = hooked(o.Hooks, groups, params, grads)
+
+	if len(o.m) == 0 {
+		o.m, o.v = ZeroLike(params), ZeroLike(params)
+	}
+
+	o.iter++
+	fix1 := 1.0 - math.Pow(float64(o.Beta1), float64(o.iter)) // 1 - beta1^t
+	fix2 := 1.0 - math.Pow(float64(o.Beta2), float64(o.iter)) // 1 - beta2^t
+	lr := T(float64(o.Alpha) * math.Sqrt(fix2) / fix1)        // lr * sqrt(1 - beta2^t) / (1 - beta1^t)
+
+	updated := ZeroLike(params)
+	for i := range params {
+		for j := range params[i] {
+			g := group(groups, i, j)
+			if g.frozen() {
+				updated[i][j] = params[i][j]
+				continue
+			}
+
+			o.m[i][j] = o.m[i][j].Add(grads[i][j].Sub(o.m[i][j]).MulC(1.0 - o.Beta1))        // m = m + (1 - beta1) * (grads - m)
+			o.v[i][j] = o.v[i][j].Add(grads[i][j].Pow2().Sub(o.v[i][j]).MulC(1.0 - o.Beta2)) // v = v + (1 - beta2) * (grads * grads - v)
+			p := params[i][j].MulC(1.0 - g.lr(o.Alpha)*g.decay(o.WeightDecay))               // params = params - alpha * weightDecay * params
+			updated[i][j] = matrix.F3(p, o.m[i][j], o.v[i][j], adam(g.lr(lr)))               // params = params - lrt * m / (sqrt(v) + 1e-7)
+		}
+	}
+
+	m.SetParams(updated)
+	return updated
+}
+
+// State returns the internal state.
+func (o *AdamWOf[T]) State() *StateOf[T] {
+	return &StateOf[T]{Iter: o.iter, Slots: map[string][][]matrix.Dense[T]{"m": Clone(o.m), "v": Clone(o.v)}}
+}
+
+// SetState sets the internal state.
+func (o *AdamWOf[T]) SetState(s *StateOf[T]) {
+	o.iter, o.m, o.v = s.Iter, Clone(s.Slots["m"]), Clone(s.Slots["v"])
+}
+
+// SetLearningRate sets the learning rate, Alpha.
+func (o *AdamWOf[T]) SetLearningRate(lr T) { o.Alpha = lr }
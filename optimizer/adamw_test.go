@@ -0,0 +1,34 @@
+package optimizer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/optimizer/hook"
+)
+
+func ExampleAdamW() {
+	steps(&optimizer.AdamW{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, WeightDecay: 0.01}, 3)
+
+	// Output:
+	// [[0.899001 -1.898000] [2.897000 0.499500]]
+	// [[0.798102 -1.796103] [2.794103 0.499001]]
+	// [[0.697304 -1.694307] [2.691309 0.498501]]
+}
+
+func ExampleAdamW_decoupled() {
+	// the weight decay as a gradient is normalized by Adam, so the param with no gradient moves by alpha.
+	adam := &TestModelOf[float64]{params: [][]matrix.Matrix{{{{1}}}}, grads: [][]matrix.Matrix{{{{0}}}}}
+	(&optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, Hooks: []optimizer.Hook{hook.WeightDecay(0.01)}}).Update(adam)
+	fmt.Printf("%.6f\n", adam.params[0][0])
+
+	// the decoupled weight decay shrinks the param by alpha * weightDecay.
+	adamw := &TestModelOf[float64]{params: [][]matrix.Matrix{{{{1}}}}, grads: [][]matrix.Matrix{{{{0}}}}}
+	(&optimizer.AdamW{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, WeightDecay: 0.01}).Update(adamw)
+	fmt.Printf("%.6f\n", adamw.params[0][0])
+
+	// Output:
+	// [[0.900032]]
+	// [[0.999000]]
+}
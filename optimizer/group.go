@@ -0,0 +1,179 @@
+package optimizer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
+)
+
+type Group = GroupOf[float64]
+
+// GroupOf is the settings of a group of the parameters.
+// A parameter is in the group if its layer is in Layers and its name is in Names.
+// Empty Layers or Names matches all. The first matching group is used.
+type GroupOf[T vector.Float] struct {
+	Layers        []int    // the indexes of params, e.g. 0 for the encoder of Seq2Seq
+	Names         []string // the names of params, e.g. B, Affine.B or TimeEmbedding.W
+	Scale         T        // the scale of the learning rate. 0 means 1, so use Frozen for no updates
+	WeightDecay   T        // the weight decay. 0 means the WeightDecay of the optimizer or hook.WeightDecay. decoupled for AdamW and LAMB, and added to the gradients for the others
+	NoWeightDecay bool     // the parameters are not decayed, even if the optimizer has the WeightDecay or hook.WeightDecay
+	Frozen        bool     // the parameters are not updated
+}
+
+type NamedModel = NamedModelOf[float64]
+
+// NamedModelOf is a model with the names of the params.
+// ParamNames returns the names in the same order as Params, e.g. Affine.W.
+type NamedModelOf[T vector.Float] interface {
+	ModelOf[T]
+	ParamNames() [][]string
+}
+
+// groupsOf returns the group of each param, or nil for the params in no group.
+// The names of the params are used only if the model is a NamedModelOf.
+func groupsOf[T vector.Float](groups []GroupOf[T], m ModelOf[T], params [][]matrix.Dense[T]) [][]*GroupOf[T] {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	var names [][]string
+	if n, ok := m.(NamedModelOf[T]); ok {
+		names = n.ParamNames()
+	}
+
+	out := make([][]*GroupOf[T], len(params))
+	for i := range params {
+		out[i] = make([]*GroupOf[T], len(params[i]))
+		for j := range params[i] {
+			var name string
+			if i < len(names) && j < len(names[i]) {
+				name = names[i][j]
+			}
+
+			for k := range groups {
+				if groups[k].match(i, name) {
+					out[i][j] = &groups[k]
+					break
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+// match returns true if the param j of the layer i is in the group.
+// A name matches the full name or its last part, e.g. B matches Affine.B.
+func (g *GroupOf[T]) match(i int, name string) bool {
+	if len(g.Layers) > 0 && !slices.Contains(g.Layers, i) {
+		return false
+	}
+
+	if len(g.Names) == 0 {
+		return true
+	}
+
+	last := name[strings.LastIndex(name, ".")+1:]
+	for _, n := range g.Names {
+		if name != "" && (n == name || n == last) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// group returns the group of the param j of the layer i, or nil.
+func group[T vector.Float](groups [][]*GroupOf[T], i, j int) *GroupOf[T] {
+	if groups == nil {
+		return nil
+	}
+
+	return groups[i][j]
+}
+
+// frozen returns true if the params are not updated.
+func (g *GroupOf[T]) frozen() bool {
+	return g != nil && g.Frozen
+}
+
+// lr returns the learning rate of the group.
+func (g *GroupOf[T]) lr(learningRate T) T {
+	if g == nil || g.Scale == 0 {
+		return learningRate
+	}
+
+	return learningRate * g.Scale
+}
+
+// decay returns the weight decay of the group, or the default if the group does not set one.
+func (g *GroupOf[T]) decay(weightDecay T) T {
+	if g == nil {
+		return weightDecay
+	}
+
+	if g.NoWeightDecay {
+		return 0
+	}
+
+	if g.WeightDecay == 0 {
+		return weightDecay
+	}
+
+	return g.WeightDecay
+}
+
+// hooked returns the gradients applied the hooks.
+// The params whose weight decay is set by their group are given to the hooks as nil, so that hook.WeightDecay does not decay them.
+func hooked[T vector.Float](hooks []HookOf[T], groups [][]*GroupOf[T], params, grads [][]matrix.Dense[T]) [][]matrix.Dense[T] {
+	if len(hooks) == 0 {
+		return grads
+	}
+
+	p := params
+	if groups != nil {
+		p = make([][]matrix.Dense[T], len(params))
+		for i := range params {
+			p[i] = make([]matrix.Dense[T], len(params[i]))
+			for j := range params[i] {
+				if g := groups[i][j]; g != nil && (g.NoWeightDecay || g.Frozen || g.WeightDecay != 0) {
+					continue
+				}
+
+				p[i][j] = params[i][j]
+			}
+		}
+	}
+
+	for _, h := range hooks {
+		grads = h(p, grads)
+	}
+
+	return grads
+}
+
+// decayed returns the gradients with the weight decay of the groups, grads + weightDecay * params.
+// The gradients of the params without the weight decay are not copied.
+func decayed[T vector.Float](groups [][]*GroupOf[T], params, grads [][]matrix.Dense[T]) [][]matrix.Dense[T] {
+	if groups == nil {
+		return grads
+	}
+
+	out := make([][]matrix.Dense[T], len(grads))
+	for i := range grads {
+		out[i] = make([]matrix.Dense[T], len(grads[i]))
+		for j := range grads[i] {
+			wd := groups[i][j].decay(0)
+			if wd == 0 || groups[i][j].frozen() {
+				out[i][j] = grads[i][j]
+				continue
+			}
+
+			out[i][j] = matrix.F2(grads[i][j], params[i][j], func(a, b T) T { return a + wd*b })
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,243 @@
+package optimizer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/optimizer/hook"
+	"github.com/itsubaki/neu/weight"
+)
+
+var (
+	_ optimizer.NamedModel = (*TestNamedModel)(nil)
+	_ optimizer.NamedModel = (*model.Sequential)(nil)
+	_ optimizer.NamedModel = (*model.RNNLM)(nil)
+	_ optimizer.NamedModel = (*model.Seq2Seq)(nil)
+	_ optimizer.NamedModel = (*model.AttentionSeq2Seq)(nil)
+	_ optimizer.NamedModel = (*model.CBOW)(nil)
+	_ optimizer.NamedModel = (*model.CBOWNegativeSampling)(nil)
+)
+
+type TestNamedModel struct {
+	TestModelOf[float64]
+	names [][]string
+}
+
+func (m *TestNamedModel) ParamNames() [][]string { return m.names }
+
+// newNamedModel returns a model with two affine layers, Affine.W and Affine.B.
+func newNamedModel() *TestNamedModel {
+	return &TestNamedModel{
+		TestModelOf: TestModelOf[float64]{
+			params: [][]matrix.Matrix{{{{1, 2}}, {{3}}}, {{{4, 5}}, {{6}}}},
+			grads:  [][]matrix.Matrix{{{{1, 1}}, {{1}}}, {{{1, 1}}, {{1}}}},
+		},
+		names: [][]string{{"Affine.W", "Affine.B"}, {"Affine.W", "Affine.B"}},
+	}
+}
+
+func ExampleGroup() {
+	m := newNamedModel()
+	o := &optimizer.SGD{
+		LearningRate: 0.1,
+		Groups: []optimizer.Group{
+			{Layers: []int{0}, Frozen: true},   // freeze the first layer
+			{Names: []string{"B"}, Scale: 0.5}, // the lower learning rate for the biases
+			{Names: []string{"Affine.W"}, WeightDecay: 1.0},
+		},
+	}
+
+	for _, p := range o.Update(m) {
+		fmt.Printf("%.2f\n", p)
+	}
+
+	// Output:
+	// [[[1.00 2.00]] [[3.00]]]
+	// [[[3.50 4.40]] [[5.95]]]
+}
+
+func ExampleGroup_hook() {
+	m := newNamedModel()
+	o := &optimizer.SGD{
+		LearningRate: 0.1,
+		Hooks:        []optimizer.Hook{hook.WeightDecay(1.0)},
+		Groups: []optimizer.Group{
+			{Names: []string{"B"}, NoWeightDecay: true},                // the biases are not decayed by the hook
+			{Layers: []int{1}, Names: []string{"W"}, WeightDecay: 0.5}, // the group overrides the hook
+		},
+	}
+
+	for _, p := range o.Update(m) {
+		fmt.Printf("%.2f\n", p)
+	}
+
+	// Output:
+	// [[[0.80 1.70]] [[2.90]]]
+	// [[[3.70 4.65]] [[5.90]]]
+}
+
+func ExampleGroup_noname() {
+	// the names are not used for the models without the names.
+	m := &TestModelOf[float64]{
+		params: [][]matrix.Matrix{{{{1, 2}}, {{3}}}},
+		grads:  [][]matrix.Matrix{{{{1, 1}}, {{1}}}},
+	}
+
+	o := &optimizer.SGD{
+		LearningRate: 0.1,
+		Groups: []optimizer.Group{
+			{Names: []string{"B"}, Frozen: true},
+			{Layers: []int{0}, Scale: 2},
+		},
+	}
+
+	fmt.Printf("%.2f\n", o.Update(m))
+
+	// Output:
+	// [[[[0.80 1.80]] [[2.80]]]]
+}
+
+func ExampleAdamW_groups() {
+	// no weight decay on the biases.
+	m := &TestNamedModel{
+		TestModelOf: TestModelOf[float64]{
+			params: [][]matrix.Matrix{{{{1}}, {{1}}}},
+			grads:  [][]matrix.Matrix{{{{0}}, {{0}}}},
+		},
+		names: [][]string{{"Affine.W", "Affine.B"}},
+	}
+
+	o := &optimizer.AdamW{
+		Alpha:       0.1,
+		Beta1:       0.9,
+		Beta2:       0.999,
+		WeightDecay: 0.01,
+		Groups: []optimizer.Group{
+			{Names: []string{"B"}, NoWeightDecay: true},
+		},
+	}
+
+	fmt.Printf("%.4f\n", o.Update(m))
+
+	// Output:
+	// [[[[0.9990]] [[1.0000]]]]
+}
+
+func ExampleAdamW_scale() {
+	// the groups without the weight decay use the weight decay of the optimizer.
+	m := &TestNamedModel{
+		TestModelOf: TestModelOf[float64]{
+			params: [][]matrix.Matrix{{{{1}}, {{1}}}},
+			grads:  [][]matrix.Matrix{{{{0}}, {{0}}}},
+		},
+		names: [][]string{{"Affine.W", "Affine.B"}},
+	}
+
+	o := &optimizer.AdamW{
+		Alpha:       0.1,
+		Beta1:       0.9,
+		Beta2:       0.999,
+		WeightDecay: 0.01,
+		Groups: []optimizer.Group{
+			{Names: []string{"B"}, Scale: 0.5},
+		},
+	}
+
+	fmt.Printf("%.4f\n", o.Update(m))
+
+	// Output:
+	// [[[[0.9990]] [[0.9995]]]]
+}
+
+func ExampleSeq2Seq_frozen() {
+	m := model.NewSeq2Seq(&model.RNNLMConfig{
+		VocabSize:   10,
+		WordVecSize: 4,
+		HiddenSize:  4,
+		WeightInit:  weight.Xavier,
+	}, rand.Const(1))
+
+	fmt.Println(m.ParamNames())
+
+	before := m.Params()
+	m.Forward(
+		[]matrix.Matrix{{{1}, {2}}, {{3}, {4}}},
+		[]matrix.Matrix{{{5}, {6}}, {{7}, {8}}, {{9}, {0}}},
+	)
+	m.Backward()
+
+	// freeze the encoder and fine-tune the decoder
+	o := &optimizer.Adam{
+		Alpha: 0.01,
+		Beta1: 0.9,
+		Beta2: 0.999,
+		Groups: []optimizer.Group{
+			{Layers: []int{0}, Frozen: true},
+		},
+	}
+
+	after := o.Update(m)
+	for i := range after {
+		var diff float64
+		for j := range after[i] {
+			diff += after[i][j].Sub(before[i][j]).Abs().Sum()
+		}
+
+		fmt.Println(i, diff > 0)
+	}
+
+	// Output:
+	// [[TimeEmbedding.W TimeLSTM.Wx TimeLSTM.Wh TimeLSTM.B] [TimeEmbedding.W TimeLSTM.Wx TimeLSTM.Wh TimeLSTM.B TimeAffine.W TimeAffine.B]]
+	// 0 false
+	// 1 true
+}
+
+func TestGroups(t *testing.T) {
+	groups := []optimizer.Group{
+		{Layers: []int{0}, Frozen: true},
+		{Names: []string{"B"}, Scale: 0.5, WeightDecay: 0.1},
+	}
+
+	cases := []struct {
+		name string
+		o    TestOptimizer
+	}{
+		{"SGD", &optimizer.SGD{LearningRate: 0.1, Groups: groups}},
+		{"Momentum", &optimizer.Momentum{LearningRate: 0.1, Momentum: 0.9, Groups: groups}},
+		{"AdaGrad", &optimizer.AdaGrad{LearningRate: 0.1, Groups: groups}},
+		{"RMSProp", &optimizer.RMSProp{LearningRate: 0.1, DecayRate: 0.9, Groups: groups}},
+		{"Nesterov", &optimizer.Nesterov{LearningRate: 0.1, Momentum: 0.9, Groups: groups}},
+		{"AdaDelta", &optimizer.AdaDelta{LearningRate: 1.0, Rho: 0.95, Groups: groups}},
+		{"Adam", &optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, Groups: groups}},
+		{"AdamW", &optimizer.AdamW{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, WeightDecay: 0.01, Groups: groups}},
+		{"LAMB", &optimizer.LAMB{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, WeightDecay: 0.01, Groups: groups}},
+	}
+
+	for _, c := range cases {
+		m := newNamedModel()
+		before := m.Params()
+
+		for i := 0; i < 3; i++ {
+			c.o.Update(m)
+		}
+
+		got := m.Params()
+		for j := range got[0] {
+			if got[0][j].Sub(before[0][j]).Abs().Sum() != 0 {
+				t.Errorf("%v: frozen params[0][%d]=%v, want=%v", c.name, j, got[0][j], before[0][j])
+			}
+		}
+
+		if got[1][0].Sub(before[1][0]).Abs().Sum() == 0 {
+			t.Errorf("%v: params[1][0] is not updated", c.name)
+		}
+
+		if got[1][1].Sub(before[1][1]).Abs().Sum() == 0 {
+			t.Errorf("%v: params[1][1] is not updated", c.name)
+		}
+	}
+}
@@ -4,30 +4,86 @@ import (
 	"math"
 
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
 )
 
-func GradsClipping(max float64) func(_, grads [][]matrix.Matrix) [][]matrix.Matrix {
-	return func(_, grads [][]matrix.Matrix) [][]matrix.Matrix {
-		var sum float64
+// GradsClipping returns a hook that returns the scaled gradients, if the norm of all gradients exceeds max.
+// The given gradients are not modified. The zero rows are kept as is,
+// so that the row-sparse gradients, e.g. matrix.SparseOf.View, are still applied lazily.
+func GradsClipping[T vector.Float](max T) func(_, grads [][]matrix.Dense[T]) [][]matrix.Dense[T] {
+	return func(_, grads [][]matrix.Dense[T]) [][]matrix.Dense[T] {
+		var sum T
+		zero := make(map[*T]bool)
 		for i := range grads {
 			for j := range grads[i] {
-				sum += grads[i][j].Pow2().Sum()
+				sum += sumsq(grads[i][j], zero)
 			}
 		}
 
-		rate := max / (math.Sqrt(sum) + 1e-6)
+		rate := max / (T(math.Sqrt(float64(sum))) + 1e-6)
 		if rate >= 1.0 {
 			return grads
 		}
 
-		out := make([][]matrix.Matrix, len(grads))
+		out := make([][]matrix.Dense[T], len(grads))
 		for i := range grads {
-			out[i] = make([]matrix.Matrix, len(grads[i]))
+			out[i] = make([]matrix.Dense[T], len(grads[i]))
 			for j := range grads[i] {
-				out[i][j] = grads[i][j].MulC(rate)
+				out[i][j] = scale(grads[i][j], rate, zero)
 			}
 		}
 
 		return out
 	}
 }
+
+// sumsq returns the sum of squares of m, and adds the zero rows to zero.
+// The rows that share the storage with a zero row, e.g. the zero rows of matrix.SparseOf.View, are skipped.
+func sumsq[T vector.Float](m matrix.Dense[T], zero map[*T]bool) T {
+	var sum T
+	for _, r := range m {
+		if len(r) == 0 || zero[&r[0]] {
+			continue
+		}
+
+		var s T
+		for _, v := range r {
+			s += v * v
+		}
+
+		if s == 0 {
+			zero[&r[0]] = true
+		}
+
+		sum += s
+	}
+
+	return sum
+}
+
+// scale returns m multiplied by rate.
+// The zero rows are shared with m, and the rows that share the storage in m share it in the returned matrix.
+func scale[T vector.Float](m matrix.Dense[T], rate T, zero map[*T]bool) matrix.Dense[T] {
+	out := make(matrix.Dense[T], len(m))
+	seen := make(map[*T][]T)
+	for i, r := range m {
+		if len(r) == 0 || zero[&r[0]] {
+			out[i] = r
+			continue
+		}
+
+		if s, ok := seen[&r[0]]; ok {
+			out[i] = s
+			continue
+		}
+
+		s := make([]T, len(r))
+		for j := range r {
+			s[j] = rate * r[j]
+		}
+
+		seen[&r[0]], out[i] = s, s
+	}
+
+	return out
+}
@@ -35,3 +35,15 @@ func ExampleGradsClipping() {
 	// [[[0.1 0.1] [0.1 0.1]] [[0.01 0.01] [0.01 0.01]]]
 
 }
+
+func ExampleGradsClipping_notModified() {
+	grads := [][]matrix.Matrix{{{[]float64{3, 4}}}}
+	clipped := hook.GradsClipping(1.0)(nil, grads)
+
+	fmt.Println(grads)
+	fmt.Printf("%.4f\n", clipped)
+
+	// Output:
+	// [[[[3 4]]]]
+	// [[[[0.6000 0.8000]]]]
+}
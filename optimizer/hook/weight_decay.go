@@ -1,14 +1,23 @@
 package hook
 
-import "github.com/itsubaki/neu/math/matrix"
+import (
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
+)
 
 // WeightDecay returns a function that applies weight decay to the gradients.
-func WeightDecay(lambda float64) func(params, grads [][]matrix.Matrix) [][]matrix.Matrix {
-	return func(params, grads [][]matrix.Matrix) [][]matrix.Matrix {
-		out := make([][]matrix.Matrix, len(params))
+// The gradients of the nil params, e.g. of an optimizer.GroupOf with NoWeightDecay, are returned as is.
+func WeightDecay[T vector.Float](lambda T) func(params, grads [][]matrix.Dense[T]) [][]matrix.Dense[T] {
+	return func(params, grads [][]matrix.Dense[T]) [][]matrix.Dense[T] {
+		out := make([][]matrix.Dense[T], len(params))
 		for i := range params { // layer
-			out[i] = make([]matrix.Matrix, len(params[i]))
+			out[i] = make([]matrix.Dense[T], len(params[i]))
 			for j := range params[i] { // W, B, ...
+				if params[i][j] == nil {
+					out[i][j] = grads[i][j]
+					continue
+				}
+
 				out[i][j] = matrix.F2(grads[i][j], params[i][j], decay(lambda)) // grad = grad + lambda * param
 			}
 		}
@@ -17,6 +26,6 @@ func WeightDecay(lambda float64) func(params, grads [][]matrix.Matrix) [][]matri
 	}
 }
 
-func decay(lambda float64) func(a, b float64) float64 {
-	return func(a, b float64) float64 { return a + lambda*b }
+func decay[T vector.Float](lambda T) func(a, b T) T {
+	return func(a, b T) T { return a + lambda*b }
 }
@@ -0,0 +1,85 @@
+package optimizer
+
+import (
+	"math"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
+)
+
+// LAMB is an optimizer that implements the Layer-wise Adaptive Moments for Batch training.
+type LAMB = LAMBOf[float64]
+
+// LAMBOf is LAMB for the parameters of T.
+type LAMBOf[T vector.Float] struct {
+	Alpha        T
+	Beta1, Beta2 T
+	WeightDecay  T
+	Hooks        []HookOf[T]
+	Groups       []GroupOf[T]
+	m, v         [][]matrix.Dense[T]
+	iter         int
+}
+
+// Update updates the parameters of the model.
+// The update of each parameter is scaled by the trust ratio, norm(params) / norm(update).
+func (o *LAMBOf[T]) Update(m ModelOf[T]) [][]matrix.Dense[T] {
+	params, grads := m.Params(), m.Grads()
+	groups := groupsOf(o.Groups, m, params)
+	grads = hooked(o.Hooks, groups, params, grads)
+
+	if len(o.m) == 0 {
+		o.m, o.v = ZeroLike(params), ZeroLike(params)
+	}
+
+	o.iter++
+	fix1 := T(1.0 - math.Pow(float64(o.Beta1), float64(o.iter))) // 1 - beta1^t
+	fix2 := T(1.0 - math.Pow(float64(o.Beta2), float64(o.iter))) // 1 - beta2^t
+
+	updated := ZeroLike(params)
+	for i := range params {
+		for j := range params[i] {
+			g := group(groups, i, j)
+			if g.frozen() {
+				updated[i][j] = params[i][j]
+				continue
+			}
+
+			o.m[i][j] = o.m[i][j].Add(grads[i][j].Sub(o.m[i][j]).MulC(1.0 - o.Beta1))        // m = m + (1 - beta1) * (grads - m)
+			o.v[i][j] = o.v[i][j].Add(grads[i][j].Pow2().Sub(o.v[i][j]).MulC(1.0 - o.Beta2)) // v = v + (1 - beta2) * (grads * grads - v)
+
+			r := matrix.F3(o.m[i][j], o.v[i][j], params[i][j], lamb(fix1, fix2, g.decay(o.WeightDecay))) // r = m/fix1 / (sqrt(v/fix2) + 1e-6) + weightDecay * params
+			updated[i][j] = matrix.F2(params[i][j], r, sgd(g.lr(o.Alpha)*trust(params[i][j], r)))        // params = params - alpha * trust * r
+		}
+	}
+
+	m.SetParams(updated)
+	return updated
+}
+
+// State returns the internal state.
+func (o *LAMBOf[T]) State() *StateOf[T] {
+	return &StateOf[T]{Iter: o.iter, Slots: map[string][][]matrix.Dense[T]{"m": Clone(o.m), "v": Clone(o.v)}}
+}
+
+// SetState sets the internal state.
+func (o *LAMBOf[T]) SetState(s *StateOf[T]) {
+	o.iter, o.m, o.v = s.Iter, Clone(s.Slots["m"]), Clone(s.Slots["v"])
+}
+
+// SetLearningRate sets the learning rate, Alpha.
+func (o *LAMBOf[T]) SetLearningRate(lr T) { o.Alpha = lr }
+
+func lamb[T vector.Float](fix1, fix2, weightDecay T) func(m, v, p T) T {
+	return func(m, v, p T) T { return (m/fix1)/(T(math.Sqrt(float64(v/fix2)))+1e-6) + weightDecay*p }
+}
+
+// trust returns norm(p) / norm(r), or 1 if either norm is zero.
+func trust[T vector.Float](p, r matrix.Dense[T]) T {
+	pn, rn := math.Sqrt(float64(p.Pow2().Sum())), math.Sqrt(float64(r.Pow2().Sum()))
+	if pn == 0 || rn == 0 {
+		return 1
+	}
+
+	return T(pn / rn)
+}
@@ -0,0 +1,12 @@
+package optimizer_test
+
+import "github.com/itsubaki/neu/optimizer"
+
+func ExampleLAMB() {
+	steps(&optimizer.LAMB{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, WeightDecay: 0.01}, 3)
+
+	// Output:
+	// [[0.784200 -1.782063] [2.779926 0.498932]]
+	// [[0.588094 -1.584016] [2.579937 0.497961]]
+	// [[0.409509 -1.403661] [2.397815 0.497077]]
+}
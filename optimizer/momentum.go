@@ -1,21 +1,28 @@
 package optimizer
 
-import "github.com/itsubaki/neu/math/matrix"
+import (
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
+)
 
 // Momemtum is an optimizer that the Momentum algorithm.
-type Momentum struct {
-	LearningRate float64
-	Momentum     float64
-	Hooks        []Hook
-	v            [][]matrix.Matrix
+type Momentum = MomentumOf[float64]
+
+// MomentumOf is Momentum for the parameters of T.
+type MomentumOf[T vector.Float] struct {
+	LearningRate T
+	Momentum     T
+	Hooks        []HookOf[T]
+	Groups       []GroupOf[T]
+	v            [][]matrix.Dense[T]
 }
 
 // Update updates the parameters of the model.
-func (o *Momentum) Update(m Model) [][]matrix.Matrix {
+func (o *MomentumOf[T]) Update(m ModelOf[T]) [][]matrix.Dense[T] {
 	params, grads := m.Params(), m.Grads()
-	for _, h := range o.Hooks {
-		grads = h(params, grads)
-	}
+	groups := groupsOf(o.Groups, m, params)
+	grads = hooked(o.Hooks, groups, params, grads)
+	grads = decayed(groups, params, grads)
 
 	if len(o.v) == 0 {
 		o.v = ZeroLike(params)
@@ -24,8 +31,14 @@ func (o *Momentum) Update(m Model) [][]matrix.Matrix {
 	updated := ZeroLike(params)
 	for i := range params {
 		for j := range params[i] {
-			o.v[i][j] = matrix.F2(o.v[i][j], grads[i][j], momentum(o.Momentum, o.LearningRate)) // v[k] = momentum * v[k] - learningRate * grads[k]
-			updated[i][j] = params[i][j].Add(o.v[i][j])                                         // params[k] = params[k] + v[k]
+			g := group(groups, i, j)
+			if g.frozen() {
+				updated[i][j] = params[i][j]
+				continue
+			}
+
+			o.v[i][j] = matrix.F2(o.v[i][j], grads[i][j], momentum(o.Momentum, g.lr(o.LearningRate))) // v[k] = momentum * v[k] - learningRate * grads[k]
+			updated[i][j] = params[i][j].Add(o.v[i][j])                                               // params[k] = params[k] + v[k]
 		}
 	}
 
@@ -33,6 +46,17 @@ func (o *Momentum) Update(m Model) [][]matrix.Matrix {
 	return updated
 }
 
-func momentum(momentum, learningRate float64) func(a, b float64) float64 {
-	return func(a, b float64) float64 { return momentum*a - learningRate*b }
+// State returns the internal state.
+func (o *MomentumOf[T]) State() *StateOf[T] {
+	return &StateOf[T]{Slots: map[string][][]matrix.Dense[T]{"v": Clone(o.v)}}
+}
+
+// SetState sets the internal state.
+func (o *MomentumOf[T]) SetState(s *StateOf[T]) { o.v = Clone(s.Slots["v"]) }
+
+// SetLearningRate sets the learning rate.
+func (o *MomentumOf[T]) SetLearningRate(lr T) { o.LearningRate = lr }
+
+func momentum[T vector.Float](momentum, learningRate T) func(a, b T) T {
+	return func(a, b T) T { return momentum*a - learningRate*b }
 }
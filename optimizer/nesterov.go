@@ -0,0 +1,62 @@
+package optimizer
+
+import (
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
+)
+
+// Nesterov is an optimizer that implements the Nesterov's Accelerated Gradient.
+type Nesterov = NesterovOf[float64]
+
+// NesterovOf is Nesterov for the parameters of T.
+type NesterovOf[T vector.Float] struct {
+	LearningRate T
+	Momentum     T
+	Hooks        []HookOf[T]
+	Groups       []GroupOf[T]
+	v            [][]matrix.Dense[T]
+}
+
+// Update updates the parameters of the model.
+func (o *NesterovOf[T]) Update(m ModelOf[T]) [][]matrix.Dense[T] {
+	params, grads := m.Params(), m.Grads()
+	groups := groupsOf(o.Groups, m, params)
+	grads = hooked(o.Hooks, groups, params, grads)
+	grads = decayed(groups, params, grads)
+
+	if len(o.v) == 0 {
+		o.v = ZeroLike(params)
+	}
+
+	updated := ZeroLike(params)
+	for i := range params {
+		for j := range params[i] {
+			g := group(groups, i, j)
+			if g.frozen() {
+				updated[i][j] = params[i][j]
+				continue
+			}
+
+			o.v[i][j] = matrix.F2(o.v[i][j], grads[i][j], momentum(o.Momentum, g.lr(o.LearningRate)))                   // v[k] = momentum * v[k] - learningRate * grads[k]
+			updated[i][j] = matrix.F3(params[i][j], o.v[i][j], grads[i][j], nesterov(o.Momentum, g.lr(o.LearningRate))) // params[k] = params[k] + momentum^2 * v[k] - (1 + momentum) * learningRate * grads[k]
+		}
+	}
+
+	m.SetParams(updated)
+	return updated
+}
+
+// State returns the internal state.
+func (o *NesterovOf[T]) State() *StateOf[T] {
+	return &StateOf[T]{Slots: map[string][][]matrix.Dense[T]{"v": Clone(o.v)}}
+}
+
+// SetState sets the internal state.
+func (o *NesterovOf[T]) SetState(s *StateOf[T]) { o.v = Clone(s.Slots["v"]) }
+
+// SetLearningRate sets the learning rate.
+func (o *NesterovOf[T]) SetLearningRate(lr T) { o.LearningRate = lr }
+
+func nesterov[T vector.Float](momentum, learningRate T) func(p, v, g T) T {
+	return func(p, v, g T) T { return p + momentum*momentum*v - (1+momentum)*learningRate*g }
+}
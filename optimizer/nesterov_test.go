@@ -0,0 +1,15 @@
+package optimizer_test
+
+import (
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/optimizer/hook"
+)
+
+func ExampleNesterov() {
+	steps(&optimizer.Nesterov{LearningRate: 0.1, Momentum: 0.9, Hooks: []optimizer.Hook{hook.WeightDecay(0.1)}}, 3)
+
+	// Output:
+	// [[0.837400 -1.674800] [2.376700 0.486450]]
+	// [[0.635466 -1.270933] [1.602621 0.469622]]
+	// [[0.400825 -0.801649] [0.703161 0.450069]]
+}
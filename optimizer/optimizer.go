@@ -3,6 +3,7 @@ package optimizer
 import (
 	"github.com/itsubaki/neu/math/matrix"
 	"github.com/itsubaki/neu/math/tensor"
+	"github.com/itsubaki/neu/math/vector"
 	"github.com/itsubaki/neu/model"
 	"github.com/itsubaki/neu/optimizer/hook"
 )
@@ -27,19 +28,128 @@ var (
 	_ Hook = hook.GradsClipping(1.0)
 )
 
-type Model interface {
-	Params() [][]matrix.Matrix
-	Grads() [][]matrix.Matrix
-	SetParams(p [][]matrix.Matrix)
+type Model = ModelOf[float64]
+
+// ModelOf is a model with the parameters of T.
+type ModelOf[T vector.Float] interface {
+	Params() [][]matrix.Dense[T]
+	Grads() [][]matrix.Dense[T]
+	SetParams(p [][]matrix.Dense[T])
 }
 
-type Hook func(params, grads [][]matrix.Matrix) [][]matrix.Matrix
+type Hook = HookOf[float64]
+
+// HookOf is a hook for the gradients of T.
+// The params whose weight decay is set by their GroupOf are nil.
+type HookOf[T vector.Float] func(params, grads [][]matrix.Dense[T]) [][]matrix.Dense[T]
 
-func ZeroLike(param [][]matrix.Matrix) [][]matrix.Matrix {
-	z := make([][]matrix.Matrix, len(param))
+func ZeroLike[T vector.Float](param [][]matrix.Dense[T]) [][]matrix.Dense[T] {
+	z := make([][]matrix.Dense[T], len(param))
 	for i := range param {
 		z[i] = tensor.ZeroLike(param[i])
 	}
 
 	return z
 }
+
+type SparseModel = SparseModelOf[float64]
+
+// SparseModelOf is a model with the row-sparse gradients for some of the parameters.
+// SparseGrads returns nil for the dense gradients.
+type SparseModelOf[T vector.Float] interface {
+	SparseGrads() [][]*matrix.SparseOf[T]
+}
+
+// sparseIndex returns the row indices of the row-sparse gradients, which can be applied lazily.
+// It is nil for the dense gradients and for the gradients whose zero rows are replaced by the hooks.
+func sparseIndex[T vector.Float](m ModelOf[T], raw, grads [][]matrix.Dense[T]) [][][]int {
+	sm, ok := m.(SparseModelOf[T])
+	if !ok {
+		return nil
+	}
+
+	sparse := sm.SparseGrads()
+	index := make([][][]int, len(grads))
+	for i := range grads {
+		index[i] = make([][]int, len(grads[i]))
+		for j := range grads[i] {
+			if i >= len(sparse) || j >= len(sparse[i]) || sparse[i][j] == nil {
+				continue
+			}
+
+			if !zeroKept(raw[i][j], grads[i][j], sparse[i][j].Index) {
+				continue
+			}
+
+			index[i][j] = sparse[i][j].Index
+			if index[i][j] == nil {
+				index[i][j] = []int{}
+			}
+		}
+	}
+
+	return index
+}
+
+// lazy returns true if the gradient of params[i][j] is row-sparse.
+func lazy(index [][][]int, i, j int) bool {
+	return index != nil && index[i][j] != nil
+}
+
+// zeroKept returns true if the rows of y not in index share the storage with the rows of x,
+// which are the zero rows of the row-sparse gradient x.
+func zeroKept[T vector.Float](x, y matrix.Dense[T], index []int) bool {
+	if len(x) == 0 || len(x) != len(y) {
+		return false
+	}
+
+	if &x[0] == &y[0] {
+		return true
+	}
+
+	in := make([]bool, len(x))
+	for _, k := range index {
+		in[k] = true
+	}
+
+	for k := range x {
+		if in[k] {
+			continue
+		}
+
+		if len(x[k]) == 0 || len(y[k]) == 0 || &x[k][0] != &y[k][0] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// shallow returns a copy of the matrix that shares the rows with m.
+func shallow[T vector.Float](m matrix.Dense[T]) matrix.Dense[T] {
+	out := make(matrix.Dense[T], len(m))
+	copy(out, m)
+	return out
+}
+
+type State = StateOf[float64]
+
+// StateOf is the internal state of an optimizer, which can be saved and restored.
+type StateOf[T vector.Float] struct {
+	Iter  int
+	Step  int // the step of the schedule, if scheduled
+	Slots map[string][][]matrix.Dense[T]
+}
+
+// Clone returns a deep copy of the params.
+func Clone[T vector.Float](p [][]matrix.Dense[T]) [][]matrix.Dense[T] {
+	out := make([][]matrix.Dense[T], len(p))
+	for i := range p {
+		out[i] = make([]matrix.Dense[T], len(p[i]))
+		for j := range p[i] {
+			out[i][j] = matrix.F(p[i][j], func(v T) T { return v })
+		}
+	}
+
+	return out
+}
@@ -1,13 +1,26 @@
 package optimizer_test
 
 import (
+	"fmt"
+	"testing"
+
 	"github.com/itsubaki/neu/layer"
 	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
 	"github.com/itsubaki/neu/model"
 	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/optimizer/hook"
+	"github.com/itsubaki/neu/weight"
 )
 
-var _ optimizer.Model = (*TestModel)(nil)
+var (
+	_ optimizer.Model            = (*TestModel)(nil)
+	_ optimizer.ModelOf[float32] = (*TestModelOf[float32])(nil)
+	_ optimizer.ModelOf[float64] = (*TestModelOf[float64])(nil)
+	_ optimizer.SparseModel      = (*TestSparseModel)(nil)
+	_ optimizer.SparseModel      = (*model.RNNLM)(nil)
+	_ optimizer.SparseModel      = (*model.CBOWNegativeSampling)(nil)
+)
 
 type TestModel struct {
 	params [][]matrix.Matrix
@@ -25,3 +38,131 @@ func (m *TestModel) SetParams(p [][]matrix.Matrix) {
 		l.SetParams(p[i]...)
 	}
 }
+
+type TestModelOf[T float32 | float64] struct {
+	params [][]matrix.Dense[T]
+	grads  [][]matrix.Dense[T]
+}
+
+func (m *TestModelOf[T]) Params() [][]matrix.Dense[T]     { return m.params }
+func (m *TestModelOf[T]) Grads() [][]matrix.Dense[T]      { return m.grads }
+func (m *TestModelOf[T]) SetParams(p [][]matrix.Dense[T]) { m.params = p }
+
+type TestSparseModel struct {
+	TestModelOf[float64]
+	sparse [][]*matrix.Sparse
+}
+
+func (m *TestSparseModel) SparseGrads() [][]*matrix.Sparse { return m.sparse }
+
+// sparseGrads returns the row-sparse gradients of a (4, 3) param for each step.
+func sparseGrads() []*matrix.Sparse {
+	return []*matrix.Sparse{
+		matrix.NewSparse(4, 3, []int{1, 3, 1}, matrix.New([]float64{1, 2, 3}, []float64{4, 5, 6}, []float64{7, 8, 9})),
+		matrix.NewSparse(4, 3, []int{0}, matrix.New([]float64{-1, -2, -3})),
+		matrix.NewSparse(4, 3, []int{3}, matrix.New([]float64{0.5, 0.5, 0.5})),
+	}
+}
+
+// DenseModel hides SparseGrads of the model.
+type DenseModel struct {
+	optimizer.Model
+}
+
+func TestUpdate_sparse(t *testing.T) {
+	newRNNLM := func() *model.RNNLM {
+		return model.NewRNNLM(&model.RNNLMConfig{
+			VocabSize:   10,
+			WordVecSize: 4,
+			HiddenSize:  4,
+			WeightInit:  weight.Xavier,
+		}, rand.Const(1))
+	}
+
+	xs := []matrix.Matrix{{{0}, {3}}, {{3}, {5}}, {{5}, {0}}}
+	ts := []matrix.Matrix{{{3}, {5}}, {{5}, {0}}, {{0}, {3}}}
+
+	cases := []struct {
+		name   string
+		o1, o2 interface {
+			Update(optimizer.Model) [][]matrix.Matrix
+		}
+	}{
+		{"SGD", &optimizer.SGD{LearningRate: 0.1}, &optimizer.SGD{LearningRate: 0.1}},
+		{"Adam", &optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999}, &optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999}},
+		{"Adam_GradsClipping", &optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, Hooks: []optimizer.Hook{hook.GradsClipping(0.1)}}, &optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, Hooks: []optimizer.Hook{hook.GradsClipping(0.1)}}},
+	}
+
+	for _, c := range cases {
+		dense, sparse := newRNNLM(), newRNNLM()
+		for i := 0; i < 5; i++ {
+			dense.Forward(xs, ts)
+			dense.Backward()
+			c.o1.Update(&DenseModel{dense})
+
+			sparse.Forward(xs, ts)
+			sparse.Backward()
+			c.o2.Update(sparse)
+		}
+
+		for i, p := range dense.Params() {
+			for j := range p {
+				if d := p[j].Sub(sparse.Params()[i][j]).Abs().Sum(); d != 0 {
+					t.Errorf("%v: params[%v][%v]: %v", c.name, i, j, d)
+				}
+			}
+		}
+	}
+}
+
+type TestOptimizer interface {
+	Update(m optimizer.Model) [][]matrix.Matrix
+}
+
+// steps prints the params after each of the n updates with the constant grads.
+func steps(o TestOptimizer, n int) {
+	m := &TestModelOf[float64]{
+		params: [][]matrix.Matrix{{{{1, -2}, {3, 0.5}}}},
+		grads:  [][]matrix.Matrix{{{{0.5, -1}, {2, 0}}}},
+	}
+
+	for i := 0; i < n; i++ {
+		fmt.Printf("%.6f\n", o.Update(m)[0][0])
+	}
+}
+
+func TestState(t *testing.T) {
+	type Stateful interface {
+		TestOptimizer
+		State() *optimizer.State
+		SetState(s *optimizer.State)
+	}
+
+	cases := []struct {
+		name   string
+		o1, o2 Stateful
+	}{
+		{"RMSProp", &optimizer.RMSProp{LearningRate: 0.1, DecayRate: 0.9}, &optimizer.RMSProp{LearningRate: 0.1, DecayRate: 0.9}},
+		{"Nesterov", &optimizer.Nesterov{LearningRate: 0.1, Momentum: 0.9}, &optimizer.Nesterov{LearningRate: 0.1, Momentum: 0.9}},
+		{"AdaDelta", &optimizer.AdaDelta{LearningRate: 1.0, Rho: 0.95}, &optimizer.AdaDelta{LearningRate: 1.0, Rho: 0.95}},
+		{"AdamW", &optimizer.AdamW{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, WeightDecay: 0.01}, &optimizer.AdamW{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, WeightDecay: 0.01}},
+		{"LAMB", &optimizer.LAMB{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, WeightDecay: 0.01}, &optimizer.LAMB{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999, WeightDecay: 0.01}},
+	}
+
+	for _, c := range cases {
+		m := &TestModelOf[float64]{
+			params: [][]matrix.Matrix{{{{1, -2}, {3, 0.5}}}},
+			grads:  [][]matrix.Matrix{{{{0.5, -1}, {2, 0}}}},
+		}
+
+		c.o1.Update(m)
+		c.o1.Update(m)
+		c.o2.SetState(c.o1.State())
+
+		r := &TestModelOf[float64]{params: m.params, grads: m.grads}
+		got, want := c.o2.Update(r)[0][0], c.o1.Update(m)[0][0]
+		if got.Sub(want).Abs().Sum() != 0 {
+			t.Errorf("%v: got=%v, want=%v", c.name, got, want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package optimizer
+
+import (
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
+)
+
+// RMSProp is an optimizer that implements the RMSProp algorithm.
+type RMSProp = RMSPropOf[float64]
+
+// RMSPropOf is RMSProp for the parameters of T.
+type RMSPropOf[T vector.Float] struct {
+	LearningRate T
+	DecayRate    T // e.g. 0.99
+	Hooks        []HookOf[T]
+	Groups       []GroupOf[T]
+	h            [][]matrix.Dense[T]
+}
+
+// Update updates the parameters of the model.
+func (o *RMSPropOf[T]) Update(m ModelOf[T]) [][]matrix.Dense[T] {
+	params, grads := m.Params(), m.Grads()
+	groups := groupsOf(o.Groups, m, params)
+	grads = hooked(o.Hooks, groups, params, grads)
+	grads = decayed(groups, params, grads)
+
+	if len(o.h) == 0 {
+		o.h = ZeroLike(params)
+	}
+
+	updated := ZeroLike(params)
+	for i := range params {
+		for j := range params[i] {
+			g := group(groups, i, j)
+			if g.frozen() {
+				updated[i][j] = params[i][j]
+				continue
+			}
+
+			o.h[i][j] = matrix.F2(o.h[i][j], grads[i][j], rmsprop(o.DecayRate))                            // h[k] = decayRate * h[k] + (1 - decayRate) * grads[k] * grads[k]
+			updated[i][j] = matrix.F3(params[i][j], grads[i][j], o.h[i][j], adagrad(g.lr(o.LearningRate))) // params[k] = params[k] - learningRate * grads[k]/sqrt(h[k])
+		}
+	}
+
+	m.SetParams(updated)
+	return updated
+}
+
+// State returns the internal state.
+func (o *RMSPropOf[T]) State() *StateOf[T] {
+	return &StateOf[T]{Slots: map[string][][]matrix.Dense[T]{"h": Clone(o.h)}}
+}
+
+// SetState sets the internal state.
+func (o *RMSPropOf[T]) SetState(s *StateOf[T]) { o.h = Clone(s.Slots["h"]) }
+
+// SetLearningRate sets the learning rate.
+func (o *RMSPropOf[T]) SetLearningRate(lr T) { o.LearningRate = lr }
+
+func rmsprop[T vector.Float](decayRate T) func(h, g T) T {
+	return func(h, g T) T { return decayRate*h + (1-decayRate)*g*g }
+}
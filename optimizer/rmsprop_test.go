@@ -0,0 +1,15 @@
+package optimizer_test
+
+import (
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/optimizer/hook"
+)
+
+func ExampleRMSProp() {
+	steps(&optimizer.RMSProp{LearningRate: 0.1, DecayRate: 0.9, Hooks: []optimizer.Hook{hook.WeightDecay(0.1)}}, 3)
+
+	// Output:
+	// [[0.683772 -1.683772] [2.683772 0.183774]]
+	// [[0.460329 -1.457281] [2.455867 0.069533]]
+	// [[0.276314 -1.269120] [2.265799 0.024436]]
+}
@@ -0,0 +1,32 @@
+package schedule
+
+import "math"
+
+// CosineAnnealing is the cosine annealing with warm restarts (SGDR).
+// The learning rate decreases from Initial to Min in Period steps, and restarts from Initial.
+// The period is multiplied by Mult after each restart.
+type CosineAnnealing struct {
+	Initial float64
+	Min     float64
+	Period  int // 0 for no decay
+	Mult    int // 0 or 1 for the same period
+}
+
+// LearningRate returns Min + (Initial - Min) * (1 + cos(pi * t / T)) / 2,
+// where t is the step in the current period and T is the length of the period.
+// It returns Initial if Period is not positive.
+func (s *CosineAnnealing) LearningRate(step int) float64 {
+	if s.Period <= 0 {
+		return s.Initial
+	}
+
+	t, T := step, s.Period
+	for t >= T {
+		t -= T
+		if s.Mult > 1 {
+			T *= s.Mult
+		}
+	}
+
+	return s.Min + (s.Initial-s.Min)*(1+math.Cos(math.Pi*float64(t)/float64(T)))/2
+}
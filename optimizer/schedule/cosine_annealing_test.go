@@ -0,0 +1,51 @@
+package schedule_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/optimizer/schedule"
+)
+
+func ExampleCosineAnnealing() {
+	s := &schedule.CosineAnnealing{Initial: 0.1, Min: 0.0, Period: 4}
+	for i := 0; i < 6; i++ {
+		fmt.Printf("%.4f\n", s.LearningRate(i))
+	}
+
+	// Output:
+	// 0.1000
+	// 0.0854
+	// 0.0500
+	// 0.0146
+	// 0.1000
+	// 0.0854
+}
+
+func ExampleCosineAnnealing_mult() {
+	s := &schedule.CosineAnnealing{Initial: 0.1, Min: 0.01, Period: 2, Mult: 2}
+	for i := 0; i < 8; i++ {
+		fmt.Printf("%.4f\n", s.LearningRate(i))
+	}
+
+	// Output:
+	// 0.1000
+	// 0.0550
+	// 0.1000
+	// 0.0868
+	// 0.0550
+	// 0.0232
+	// 0.1000
+	// 0.0966
+}
+
+func ExampleCosineAnnealing_zero() {
+	s := &schedule.CosineAnnealing{Initial: 0.1, Min: 0.01}
+	for i := 0; i < 3; i++ {
+		fmt.Printf("%.4f\n", s.LearningRate(i))
+	}
+
+	// Output:
+	// 0.1000
+	// 0.1000
+	// 0.1000
+}
@@ -0,0 +1,14 @@
+package schedule
+
+import "math"
+
+// Exponential multiplies the learning rate by Gamma every step.
+type Exponential struct {
+	Initial float64
+	Gamma   float64
+}
+
+// LearningRate returns Initial * Gamma^step.
+func (s *Exponential) LearningRate(step int) float64 {
+	return s.Initial * math.Pow(s.Gamma, float64(step))
+}
@@ -0,0 +1,20 @@
+package schedule_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/optimizer/schedule"
+)
+
+func ExampleExponential() {
+	s := &schedule.Exponential{Initial: 0.1, Gamma: 0.9}
+	for i := 0; i < 4; i++ {
+		fmt.Printf("%.4f\n", s.LearningRate(i))
+	}
+
+	// Output:
+	// 0.1000
+	// 0.0900
+	// 0.0810
+	// 0.0729
+}
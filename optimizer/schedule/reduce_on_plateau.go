@@ -0,0 +1,70 @@
+package schedule
+
+// ReduceOnPlateau multiplies the learning rate by Factor when the monitored value has not improved for more than Patience steps.
+// The value is given by Step, e.g. the validation loss or perplexity after each epoch.
+type ReduceOnPlateau struct {
+	Initial  float64
+	Factor   float64 // e.g. 0.25 to divide the learning rate by 4
+	Patience int     // the number of steps without improvement before reducing
+	MinDelta float64 // the minimum change to be an improvement
+	Min      float64 // the lower bound of the learning rate
+	Max      bool    // higher is better, e.g. the accuracy
+	lr       float64
+	best     float64
+	wait     int
+	seen     bool
+}
+
+// LearningRate returns the current learning rate. The step is ignored.
+func (s *ReduceOnPlateau) LearningRate(_ int) float64 {
+	if s.lr == 0 {
+		return s.Initial
+	}
+
+	return s.lr
+}
+
+// Step records the monitored value and returns the learning rate.
+func (s *ReduceOnPlateau) Step(v float64) float64 {
+	lr := s.LearningRate(0)
+	if !s.seen || s.improved(v) {
+		s.lr, s.best, s.wait, s.seen = lr, v, 0, true
+		return s.lr
+	}
+
+	s.wait++
+	if s.wait > s.Patience {
+		s.lr, s.wait = max(lr*s.Factor, s.Min), 0
+		return s.lr
+	}
+
+	s.lr = lr
+	return s.lr
+}
+
+// State returns the internal state of the schedule, that is the learning rate, the best value, the wait and the seen flag.
+func (s *ReduceOnPlateau) State() []float64 {
+	var seen float64
+	if s.seen {
+		seen = 1
+	}
+
+	return []float64{s.lr, s.best, float64(s.wait), seen}
+}
+
+// SetState sets the internal state of the schedule returned by State.
+func (s *ReduceOnPlateau) SetState(st []float64) {
+	if len(st) != 4 {
+		return
+	}
+
+	s.lr, s.best, s.wait, s.seen = st[0], st[1], int(st[2]), st[3] != 0
+}
+
+func (s *ReduceOnPlateau) improved(v float64) bool {
+	if s.Max {
+		return v > s.best+s.MinDelta
+	}
+
+	return v < s.best-s.MinDelta
+}
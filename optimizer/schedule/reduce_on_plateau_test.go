@@ -0,0 +1,60 @@
+package schedule_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/optimizer/schedule"
+)
+
+func ExampleReduceOnPlateau() {
+	// divide the learning rate by 4 when the perplexity worsens
+	s := &schedule.ReduceOnPlateau{Initial: 20, Factor: 0.25}
+	for _, ppl := range []float64{300, 200, 250, 150, 160, 170} {
+		fmt.Printf("%.4f\n", s.Step(ppl))
+	}
+
+	// Output:
+	// 20.0000
+	// 20.0000
+	// 5.0000
+	// 5.0000
+	// 1.2500
+	// 0.3125
+}
+
+func ExampleReduceOnPlateau_patience() {
+	s := &schedule.ReduceOnPlateau{Initial: 0.1, Factor: 0.5, Patience: 1, Min: 0.04, Max: true}
+	for _, acc := range []float64{0.5, 0.6, 0.6, 0.6, 0.6, 0.6, 0.6} {
+		fmt.Printf("%.4f\n", s.Step(acc))
+	}
+
+	// Output:
+	// 0.1000
+	// 0.1000
+	// 0.1000
+	// 0.0500
+	// 0.0500
+	// 0.0400
+	// 0.0400
+}
+
+func ExampleReduceOnPlateau_State() {
+	s := &schedule.ReduceOnPlateau{Initial: 20, Factor: 0.25}
+	for _, ppl := range []float64{300, 200, 250} {
+		s.Step(ppl)
+	}
+
+	// resume
+	r := &schedule.ReduceOnPlateau{Initial: 20, Factor: 0.25}
+	r.SetState(s.State())
+	fmt.Println(s.State())
+
+	for _, ppl := range []float64{150, 160} {
+		fmt.Printf("%.4f %.4f\n", s.Step(ppl), r.Step(ppl))
+	}
+
+	// Output:
+	// [5 200 0 1]
+	// 5.0000 5.0000
+	// 1.2500 1.2500
+}
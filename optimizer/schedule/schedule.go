@@ -0,0 +1,112 @@
+package schedule
+
+import (
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/optimizer"
+)
+
+var (
+	_ Schedule = Func(nil)
+	_ Schedule = (*StepDecay)(nil)
+	_ Schedule = (*Exponential)(nil)
+	_ Schedule = (*CosineAnnealing)(nil)
+	_ Schedule = (*Warmup)(nil)
+	_ Schedule = (*ReduceOnPlateau)(nil)
+)
+
+var _ Stateful = (*ReduceOnPlateau)(nil)
+
+var (
+	_ Optimizer = (*optimizer.AdaDelta)(nil)
+	_ Optimizer = (*optimizer.AdaGrad)(nil)
+	_ Optimizer = (*optimizer.Adam)(nil)
+	_ Optimizer = (*optimizer.AdamW)(nil)
+	_ Optimizer = (*optimizer.LAMB)(nil)
+	_ Optimizer = (*optimizer.Momentum)(nil)
+	_ Optimizer = (*optimizer.Nesterov)(nil)
+	_ Optimizer = (*optimizer.RMSProp)(nil)
+	_ Optimizer = (*optimizer.SGD)(nil)
+)
+
+// Schedule is a learning rate schedule.
+// The step is the number of updates or epochs done, depending on the caller.
+type Schedule interface {
+	LearningRate(step int) float64
+}
+
+// Stateful is a schedule with the internal state, which can be saved and restored.
+type Stateful interface {
+	State() []float64
+	SetState(s []float64)
+}
+
+// Func is a function as a Schedule.
+type Func func(step int) float64
+
+// LearningRate returns f(step).
+func (f Func) LearningRate(step int) float64 { return f(step) }
+
+// Optimizer is an optimizer with the learning rate that can be changed.
+type Optimizer interface {
+	Update(m optimizer.Model) [][]matrix.Matrix
+	SetLearningRate(lr float64)
+}
+
+// Scheduled is an optimizer that sets the learning rate of each update by the schedule.
+type Scheduled struct {
+	Optimizer Optimizer
+	Schedule  Schedule
+	step      int
+}
+
+// New returns the optimizer scheduled by s.
+func New(o Optimizer, s Schedule) *Scheduled {
+	return &Scheduled{
+		Optimizer: o,
+		Schedule:  s,
+	}
+}
+
+// Update sets the learning rate of the step and updates the parameters of the model.
+func (o *Scheduled) Update(m optimizer.Model) [][]matrix.Matrix {
+	o.Optimizer.SetLearningRate(o.Schedule.LearningRate(o.step))
+	o.step++
+
+	return o.Optimizer.Update(m)
+}
+
+// SetLearningRate is a no-op, since the learning rate is set by the schedule.
+func (o *Scheduled) SetLearningRate(_ float64) {}
+
+// State returns the internal state of the optimizer with the step of the schedule.
+// If the schedule is Stateful, its state is in the "schedule" slot.
+func (o *Scheduled) State() *optimizer.State {
+	st := &optimizer.State{}
+	if s, ok := o.Optimizer.(interface{ State() *optimizer.State }); ok {
+		st = s.State()
+	}
+
+	if s, ok := o.Schedule.(Stateful); ok {
+		if st.Slots == nil {
+			st.Slots = make(map[string][][]matrix.Matrix)
+		}
+
+		st.Slots["schedule"] = [][]matrix.Matrix{{matrix.New(s.State())}}
+	}
+
+	st.Step = o.step
+	return st
+}
+
+// SetState sets the internal state of the optimizer and the step of the schedule.
+func (o *Scheduled) SetState(st *optimizer.State) {
+	if s, ok := o.Optimizer.(interface{ SetState(s *optimizer.State) }); ok {
+		s.SetState(st)
+	}
+
+	if s, ok := o.Schedule.(Stateful); ok && len(st.Slots["schedule"]) > 0 {
+		s.SetState(st.Slots["schedule"][0][0][0])
+	}
+
+	o.step = st.Step
+}
@@ -0,0 +1,95 @@
+package schedule_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/optimizer/schedule"
+)
+
+type TestModel struct {
+	params [][]matrix.Matrix
+}
+
+func (m *TestModel) Params() [][]matrix.Matrix { return m.params }
+func (m *TestModel) Grads() [][]matrix.Matrix {
+	return [][]matrix.Matrix{{matrix.New([]float64{1})}}
+}
+func (m *TestModel) SetParams(p [][]matrix.Matrix) { m.params = p }
+
+func ExampleNew() {
+	m := &TestModel{params: [][]matrix.Matrix{{matrix.New([]float64{1})}}}
+	o := schedule.New(&optimizer.SGD{}, &schedule.StepDecay{Initial: 0.1, StepSize: 2, Gamma: 0.5})
+	for i := 0; i < 4; i++ {
+		fmt.Printf("%.4f\n", o.Update(m)[0][0][0][0])
+	}
+
+	// Output:
+	// 0.9000
+	// 0.8000
+	// 0.7500
+	// 0.7000
+}
+
+func ExampleFunc() {
+	o := &optimizer.Momentum{Momentum: 0.9}
+	s := schedule.New(o, schedule.Func(func(step int) float64 {
+		return 0.1 / float64(step+1)
+	}))
+
+	m := &TestModel{params: [][]matrix.Matrix{{matrix.New([]float64{1})}}}
+	for i := 0; i < 3; i++ {
+		s.Update(m)
+		fmt.Printf("%.4f\n", o.LearningRate)
+	}
+
+	// Output:
+	// 0.1000
+	// 0.0500
+	// 0.0333
+}
+
+func ExampleScheduled_State() {
+	m := &TestModel{params: [][]matrix.Matrix{{matrix.New([]float64{1})}}}
+	s := &schedule.Exponential{Initial: 0.1, Gamma: 0.5}
+
+	o := schedule.New(&optimizer.Adam{Beta1: 0.9, Beta2: 0.999}, s)
+	o.Update(m)
+	o.Update(m)
+	st := o.State()
+	fmt.Println(st.Iter, st.Step)
+
+	// resume
+	r := schedule.New(&optimizer.Adam{Beta1: 0.9, Beta2: 0.999}, s)
+	r.SetState(st)
+	fmt.Printf("%.6f\n", o.Update(&TestModel{params: m.Params()})[0][0][0][0])
+	fmt.Printf("%.6f\n", r.Update(&TestModel{params: m.Params()})[0][0][0][0])
+
+	// Output:
+	// 2 2
+	// 0.825000
+	// 0.825000
+}
+
+func ExampleScheduled_State_stateful() {
+	m := &TestModel{params: [][]matrix.Matrix{{matrix.New([]float64{1})}}}
+	p := &schedule.ReduceOnPlateau{Initial: 0.1, Factor: 0.5}
+
+	o := schedule.New(&optimizer.SGD{}, p)
+	p.Step(2)
+	p.Step(3)
+	o.Update(m)
+	st := o.State()
+
+	// resume
+	q := &schedule.ReduceOnPlateau{Initial: 0.1, Factor: 0.5}
+	r := schedule.New(&optimizer.SGD{}, q)
+	r.SetState(st)
+	fmt.Printf("%.4f %.4f\n", p.LearningRate(0), q.LearningRate(0))
+	fmt.Printf("%.4f %.4f\n", p.Step(2.5), q.Step(2.5))
+
+	// Output:
+	// 0.0500 0.0500
+	// 0.0250 0.0250
+}
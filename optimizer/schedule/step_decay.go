@@ -0,0 +1,20 @@
+package schedule
+
+import "math"
+
+// StepDecay multiplies the learning rate by Gamma every StepSize steps.
+type StepDecay struct {
+	Initial  float64
+	StepSize int // 0 for no decay
+	Gamma    float64
+}
+
+// LearningRate returns Initial * Gamma^(step/StepSize).
+// It returns Initial if StepSize is not positive.
+func (s *StepDecay) LearningRate(step int) float64 {
+	if s.StepSize <= 0 {
+		return s.Initial
+	}
+
+	return s.Initial * math.Pow(s.Gamma, float64(step/s.StepSize))
+}
@@ -0,0 +1,34 @@
+package schedule_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/optimizer/schedule"
+)
+
+func ExampleStepDecay() {
+	s := &schedule.StepDecay{Initial: 0.1, StepSize: 2, Gamma: 0.5}
+	for i := 0; i < 6; i++ {
+		fmt.Printf("%.4f\n", s.LearningRate(i))
+	}
+
+	// Output:
+	// 0.1000
+	// 0.1000
+	// 0.0500
+	// 0.0500
+	// 0.0250
+	// 0.0250
+}
+
+func ExampleStepDecay_zero() {
+	s := &schedule.StepDecay{Initial: 0.1, Gamma: 0.5}
+	for i := 0; i < 3; i++ {
+		fmt.Printf("%.4f\n", s.LearningRate(i))
+	}
+
+	// Output:
+	// 0.1000
+	// 0.1000
+	// 0.1000
+}
@@ -0,0 +1,17 @@
+package schedule
+
+// Warmup increases the learning rate linearly for Steps steps and follows the Schedule after.
+// The Schedule starts from step 0 at the end of the warmup.
+type Warmup struct {
+	Steps    int
+	Schedule Schedule
+}
+
+// LearningRate returns Schedule(0) * (step+1) / Steps in the warmup, and Schedule(step-Steps) after.
+func (s *Warmup) LearningRate(step int) float64 {
+	if step < s.Steps {
+		return s.Schedule.LearningRate(0) * float64(step+1) / float64(s.Steps)
+	}
+
+	return s.Schedule.LearningRate(step - s.Steps)
+}
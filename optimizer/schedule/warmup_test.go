@@ -0,0 +1,28 @@
+package schedule_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/optimizer/schedule"
+)
+
+func ExampleWarmup() {
+	s := &schedule.Warmup{
+		Steps:    4,
+		Schedule: &schedule.StepDecay{Initial: 0.1, StepSize: 2, Gamma: 0.5},
+	}
+
+	for i := 0; i < 8; i++ {
+		fmt.Printf("%.4f\n", s.LearningRate(i))
+	}
+
+	// Output:
+	// 0.0250
+	// 0.0500
+	// 0.0750
+	// 0.1000
+	// 0.1000
+	// 0.1000
+	// 0.0500
+	// 0.0500
+}
@@ -1,24 +1,50 @@
 package optimizer
 
-import "github.com/itsubaki/neu/math/matrix"
+import (
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/vector"
+)
 
 // SGD is an optimizer that the Stochastic Gradient Descent algorithm.
-type SGD struct {
-	LearningRate float64
-	Hooks        []Hook
+type SGD = SGDOf[float64]
+
+// SGDOf is SGD for the parameters of T.
+type SGDOf[T vector.Float] struct {
+	LearningRate T
+	Hooks        []HookOf[T]
+	Groups       []GroupOf[T]
 }
 
 // Update updates the parameters of the model.
-func (o *SGD) Update(m Model) [][]matrix.Matrix {
+// If the model is a SparseModelOf, the row-sparse gradients update only their rows.
+func (o *SGDOf[T]) Update(m ModelOf[T]) [][]matrix.Dense[T] {
 	params, grads := m.Params(), m.Grads()
-	for _, h := range o.Hooks {
-		grads = h(params, grads)
-	}
+	raw := grads
+	groups := groupsOf(o.Groups, m, params)
+	grads = hooked(o.Hooks, groups, params, grads)
+	grads = decayed(groups, params, grads)
 
-	updated := ZeroLike(params)
+	index := sparseIndex(m, raw, grads)
+	updated := make([][]matrix.Dense[T], len(params))
 	for i := range params {
+		updated[i] = make([]matrix.Dense[T], len(params[i]))
 		for j := range params[i] {
-			updated[i][j] = matrix.F2(params[i][j], grads[i][j], sgd(o.LearningRate)) // params[k] = params[k] - o.LearningRate * grads[k]
+			g := group(groups, i, j)
+			if g.frozen() {
+				updated[i][j] = params[i][j]
+				continue
+			}
+
+			if lazy(index, i, j) {
+				updated[i][j] = shallow(params[i][j])
+				for _, k := range index[i][j] {
+					updated[i][j][k] = matrix.F2(params[i][j][k:k+1], grads[i][j][k:k+1], sgd(g.lr(o.LearningRate)))[0]
+				}
+
+				continue
+			}
+
+			updated[i][j] = matrix.F2(params[i][j], grads[i][j], sgd(g.lr(o.LearningRate))) // params[k] = params[k] - o.LearningRate * grads[k]
 		}
 	}
 
@@ -26,6 +52,15 @@ func (o *SGD) Update(m Model) [][]matrix.Matrix {
 	return updated
 }
 
-func sgd(learningRate float64) func(a, b float64) float64 {
-	return func(a, b float64) float64 { return a - learningRate*b }
+// State returns the internal state. SGD has no state.
+func (o *SGDOf[T]) State() *StateOf[T] { return &StateOf[T]{} }
+
+// SetState sets the internal state.
+func (o *SGDOf[T]) SetState(_ *StateOf[T]) {}
+
+// SetLearningRate sets the learning rate.
+func (o *SGDOf[T]) SetLearningRate(lr T) { o.LearningRate = lr }
+
+func sgd[T vector.Float](learningRate T) func(a, b T) T {
+	return func(a, b T) T { return a - learningRate*b }
 }
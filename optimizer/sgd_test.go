@@ -30,3 +30,49 @@ func ExampleSGD() {
 	// [[-1 -2 -3] [-4 -5 -6]]
 
 }
+
+func ExampleSGDOf() {
+	m := &TestModelOf[float32]{
+		params: [][]matrix.Dense[float32]{{{{1, 2, 3}, {4, 5, 6}}}},
+		grads:  [][]matrix.Dense[float32]{{{{2, 4, 6}, {8, 10, 12}}}},
+	}
+
+	opt := optimizer.SGDOf[float32]{
+		LearningRate: 0.1,
+		Hooks: []optimizer.HookOf[float32]{
+			hook.GradsClipping[float32](100),
+		},
+	}
+
+	fmt.Println(opt.Update(m)[0][0])
+	fmt.Println(opt.Update(m)[0][0])
+
+	// Output:
+	// [[0.8 1.6 2.4] [3.2 4 4.8]]
+	// [[0.6 1.2 1.8000001] [2.4 3 3.6000001]]
+}
+
+func ExampleSGD_sparse() {
+	p := matrix.New([]float64{1, 2, 3}, []float64{4, 5, 6}, []float64{7, 8, 9}, []float64{10, 11, 12})
+	dense := &TestModelOf[float64]{params: [][]matrix.Matrix{{p}}}
+	sparse := &TestSparseModel{TestModelOf: TestModelOf[float64]{params: [][]matrix.Matrix{{p}}}}
+
+	o1, o2 := &optimizer.SGD{LearningRate: 0.1}, &optimizer.SGD{LearningRate: 0.1}
+	for _, g := range sparseGrads() {
+		dense.grads = [][]matrix.Matrix{{g.Dense()}}
+		sparse.grads, sparse.sparse = [][]matrix.Matrix{{g.View()}}, [][]*matrix.Sparse{{g}}
+
+		fmt.Println(o1.Update(dense)[0][0])
+		fmt.Println(o2.Update(sparse)[0][0])
+	}
+	fmt.Println(p)
+
+	// Output:
+	// [[1 2 3] [3.2 4 4.8] [7 8 9] [9.6 10.5 11.4]]
+	// [[1 2 3] [3.2 4 4.8] [7 8 9] [9.6 10.5 11.4]]
+	// [[1.1 2.2 3.3] [3.2 4 4.8] [7 8 9] [9.6 10.5 11.4]]
+	// [[1.1 2.2 3.3] [3.2 4 4.8] [7 8 9] [9.6 10.5 11.4]]
+	// [[1.1 2.2 3.3] [3.2 4 4.8] [7 8 9] [9.549999999999999 10.45 11.35]]
+	// [[1.1 2.2 3.3] [3.2 4 4.8] [7 8 9] [9.549999999999999 10.45 11.35]]
+	// [[1 2 3] [4 5 6] [7 8 9] [10 11 12]]
+}
@@ -0,0 +1,150 @@
+package trainer
+
+import (
+	randv2 "math/rand/v2"
+
+	"github.com/itsubaki/neu/optimizer"
+)
+
+var (
+	_ Callback = (*NopCallback)(nil)
+	_ Callback = (Callbacks)(nil)
+	_ Callback = (*Checkpoint)(nil)
+	_ Callback = (*EarlyStopping)(nil)
+	_ Callback = (*Logger)(nil)
+	_ Callback = (*LearningRateScheduler)(nil)
+)
+
+// Callback is the interface of the hooks called by Fit.
+type Callback interface {
+	OnTrainBegin(c *Context)
+	OnEpochBegin(c *Context)
+	OnBatchEnd(c *Context)
+	OnEpochEnd(c *Context)
+	OnTrainEnd(c *Context)
+}
+
+// Context is the state of the training passed to the callbacks.
+type Context struct {
+	Epoch      int
+	Batch      int             // the index of the batch in the epoch
+	Step       int             // the number of iterations done
+	Loss       float64         // the loss of the batch
+	Perplexity float64         // the perplexity of the epoch so far, RNNLMTrainer only
+	Metrics    *Metrics        // the latest validation metrics, or nil
+	Model      optimizer.Model // Model, RNNLM or Seq2Seq
+	Optimizer  Optimizer
+	Err        error // the error returned by Fit
+	stop       bool
+	state      *State
+	source     randv2.Source
+	callbacks  Callbacks
+}
+
+// Stop requests Fit to stop after the current hook.
+func (c *Context) Stop() {
+	c.stop = true
+}
+
+// Fail sets the error and requests Fit to stop.
+func (c *Context) Fail(err error) {
+	c.Err, c.stop = err, true
+}
+
+// Stopped returns true if a stop has been requested.
+func (c *Context) Stopped() bool {
+	return c.stop
+}
+
+// NopCallback is a callback that does nothing.
+// Embed it to implement only some of the hooks.
+type NopCallback struct{}
+
+func (NopCallback) OnTrainBegin(_ *Context) {}
+func (NopCallback) OnEpochBegin(_ *Context) {}
+func (NopCallback) OnBatchEnd(_ *Context)   {}
+func (NopCallback) OnEpochEnd(_ *Context)   {}
+func (NopCallback) OnTrainEnd(_ *Context)   {}
+
+// Callbacks is a list of callbacks called in order.
+type Callbacks []Callback
+
+func (cs Callbacks) OnTrainBegin(c *Context) {
+	for _, cb := range cs {
+		cb.OnTrainBegin(c)
+	}
+}
+
+func (cs Callbacks) OnEpochBegin(c *Context) {
+	for _, cb := range cs {
+		cb.OnEpochBegin(c)
+	}
+}
+
+func (cs Callbacks) OnBatchEnd(c *Context) {
+	for _, cb := range cs {
+		cb.OnBatchEnd(c)
+	}
+}
+
+func (cs Callbacks) OnEpochEnd(c *Context) {
+	for _, cb := range cs {
+		cb.OnEpochEnd(c)
+	}
+}
+
+func (cs Callbacks) OnTrainEnd(c *Context) {
+	for _, cb := range cs {
+		cb.OnTrainEnd(c)
+	}
+}
+
+// checkpoint returns the first checkpoint in the callbacks, or nil.
+func (cs Callbacks) checkpoint() *Checkpoint {
+	for _, cb := range cs {
+		if c, ok := cb.(*Checkpoint); ok {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// earlyStopping returns the first early stopping in the callbacks, or nil.
+func (cs Callbacks) earlyStopping() *EarlyStopping {
+	for _, cb := range cs {
+		if es, ok := cb.(*EarlyStopping); ok {
+			return es
+		}
+	}
+
+	return nil
+}
+
+// resume restores the training state from the checkpoint in the callbacks, and the state of the early stopping.
+func (cs Callbacks) resume(m optimizer.Model, o Optimizer) (*State, error) {
+	st, err := cs.checkpoint().resume(m, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if es := cs.earlyStopping(); es != nil && st.EarlyStopping != nil {
+		es.SetState(st.EarlyStopping)
+	}
+
+	return st, nil
+}
+
+// callbacks returns the checkpoint and the early stopping of the input followed by the callbacks.
+func callbacks(cbs []Callback, c *Checkpoint, es *EarlyStopping) Callbacks {
+	var out Callbacks
+	if c != nil {
+		out = append(out, c)
+	}
+
+	if es != nil {
+		out = append(out, es)
+	}
+
+	return append(out, cbs...)
+}
@@ -0,0 +1,232 @@
+package trainer_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/optimizer/schedule"
+	"github.com/itsubaki/neu/trainer"
+	"github.com/itsubaki/neu/weight"
+)
+
+type TestCallback struct {
+	trainer.NopCallback
+	StopAt int
+}
+
+func (cb *TestCallback) OnEpochBegin(c *trainer.Context) {
+	fmt.Printf("epoch %d begin\n", c.Epoch)
+}
+
+func (cb *TestCallback) OnBatchEnd(c *trainer.Context) {
+	fmt.Printf("%d, %d: step=%d, %T\n", c.Epoch, c.Batch, c.Step, c.Model)
+	if c.Step == cb.StopAt {
+		c.Stop()
+	}
+}
+
+func (cb *TestCallback) OnTrainEnd(c *trainer.Context) {
+	fmt.Printf("end: stopped=%v\n", c.Stopped())
+}
+
+func ExampleCallback() {
+	tr := trainer.New(&TestModel{}, &optimizer.SGD{LearningRate: 0.1})
+	tr.Fit(&trainer.Input{
+		Train:      matrix.New([]float64{0, 1}, []float64{0, 1}, []float64{0, 1}, []float64{0, 1}),
+		TrainLabel: matrix.New([]float64{1, 0}, []float64{1, 0}, []float64{1, 0}, []float64{1, 0}),
+		Epochs:     3,
+		BatchSize:  2,
+		Callbacks:  []trainer.Callback{&TestCallback{StopAt: 3}},
+	}, rand.Const(1))
+
+	// Output:
+	// epoch 0 begin
+	// 0, 0: step=1, *trainer_test.TestModel
+	// 0, 1: step=2, *trainer_test.TestModel
+	// epoch 1 begin
+	// 1, 0: step=3, *trainer_test.TestModel
+	// end: stopped=true
+}
+
+func ExampleCallback_rnnlm() {
+	tr := trainer.NewRNNLM(&TestRNNLM{}, &optimizer.SGD{LearningRate: 0.1})
+	tr.Fit(&trainer.RNNLMInput{
+		Train:      []int{0, 1, 2, 3},
+		TrainLabel: []int{1, 2, 3, 4},
+		Epochs:     3,
+		BatchSize:  1,
+		TimeSize:   2,
+		Callbacks:  []trainer.Callback{&TestCallback{StopAt: 3}},
+	})
+
+	// Output:
+	// epoch 0 begin
+	// 0, 0: step=1, *trainer_test.TestRNNLM
+	// 0, 1: step=2, *trainer_test.TestRNNLM
+	// epoch 1 begin
+	// 1, 0: step=3, *trainer_test.TestRNNLM
+	// end: stopped=true
+}
+
+func ExampleCallback_seq2seq() {
+	s := rand.Const(1)
+	tr := trainer.NewSeq2Seq(model.NewSeq2Seq(&model.RNNLMConfig{
+		VocabSize:   10,
+		WordVecSize: 4,
+		HiddenSize:  4,
+		WeightInit:  weight.Xavier,
+	}, s), &optimizer.SGD{LearningRate: 0.1})
+
+	tr.Fit(&trainer.Seq2SeqInput{
+		Train:      [][]int{{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, {1, 3, 5}},
+		TrainLabel: [][]int{{1, 2}, {4, 5}, {7, 8}, {3, 5}},
+		Epochs:     3,
+		BatchSize:  2,
+		Callbacks:  []trainer.Callback{&TestCallback{StopAt: 3}},
+	}, s)
+
+	// Output:
+	// epoch 0 begin
+	// 0, 0: step=1, *model.Seq2Seq
+	// 0, 1: step=2, *model.Seq2Seq
+	// epoch 1 begin
+	// 1, 0: step=3, *model.Seq2Seq
+	// end: stopped=true
+}
+
+func ExampleLogger() {
+	s := rand.Const(1)
+	x := matrix.New([]float64{0, 0}, []float64{1, 0}, []float64{0, 1}, []float64{1, 1})
+	t := matrix.New([]float64{1, 0}, []float64{0, 1}, []float64{0, 1}, []float64{1, 0})
+
+	tr := trainer.New(newSequential(s), &optimizer.SGD{LearningRate: 0.5})
+	tr.Fit(&trainer.Input{
+		Train:      x,
+		TrainLabel: t,
+		Epochs:     2,
+		BatchSize:  2,
+		Valid:      x,
+		ValidLabel: t,
+		Callbacks:  []trainer.Callback{&trainer.Logger{Writer: os.Stdout, Every: 2}},
+	}, s)
+
+	// Output:
+	//   0,   1: loss=0.6882
+	//   0: valid_loss=0.6211, valid_acc=0.7500
+	//   1,   1: loss=0.6074
+	//   1: valid_loss=0.5744, valid_acc=0.7500
+}
+
+func ExampleLearningRateScheduler() {
+	o := &optimizer.SGD{LearningRate: 0.1}
+	tr := trainer.New(&TestModel{}, o)
+	tr.Fit(&trainer.Input{
+		Train:      matrix.New([]float64{0, 1}, []float64{0, 1}),
+		TrainLabel: matrix.New([]float64{1, 0}, []float64{1, 0}),
+		Epochs:     3,
+		BatchSize:  2,
+		Verbose: func(epoch, _ int, _ float64, _ trainer.Model) {
+			fmt.Printf("%d: %.4f\n", epoch, o.LearningRate)
+		},
+		Callbacks: []trainer.Callback{
+			&trainer.LearningRateScheduler{
+				Schedule: func(c *trainer.Context) float64 {
+					return 0.1 / float64(c.Epoch+1)
+				},
+			},
+		},
+	}, rand.Const(1))
+
+	// Output:
+	// 0: 0.1000
+	// 1: 0.0500
+	// 2: 0.0333
+}
+
+func ExampleLearningRateScheduler_plateau() {
+	s := rand.Const(1)
+	x := matrix.New([]float64{0, 0}, []float64{1, 0}, []float64{0, 1}, []float64{1, 1})
+	t := matrix.New([]float64{1, 0}, []float64{0, 1}, []float64{0, 1}, []float64{1, 0})
+
+	o := &optimizer.SGD{LearningRate: 0.5}
+	p := &schedule.ReduceOnPlateau{Initial: 0.5, Factor: 0.25}
+
+	tr := trainer.New(newSequential(s), o)
+	tr.Fit(&trainer.Input{
+		Train:      x,
+		TrainLabel: t,
+		Epochs:     4,
+		BatchSize:  2,
+		Valid:      x,
+		ValidLabel: t,
+		ValidVerbose: func(epoch int, metrics trainer.Metrics, _ trainer.Model) {
+			fmt.Printf("%d: lr=%.4f, loss=%.4f\n", epoch, o.LearningRate, metrics.Loss)
+		},
+		Callbacks: []trainer.Callback{
+			&trainer.LearningRateScheduler{
+				Schedule: func(c *trainer.Context) float64 {
+					if c.Metrics == nil {
+						return p.LearningRate(c.Epoch)
+					}
+
+					return p.Step(c.Metrics.Loss)
+				},
+			},
+		},
+	}, s)
+
+	// Output:
+	// 0: lr=0.5000, loss=0.6211
+	// 1: lr=0.5000, loss=0.5744
+	// 2: lr=0.5000, loss=0.6632
+	// 3: lr=0.1250, loss=0.6504
+}
+
+type TestOptimizer struct{}
+
+func (o *TestOptimizer) Update(m optimizer.Model) [][]matrix.Matrix { return nil }
+
+func ExampleLearningRateScheduler_notsupported() {
+	tr := trainer.New(&TestModel{}, &TestOptimizer{})
+	err := tr.Fit(&trainer.Input{
+		Train:      matrix.New([]float64{0, 1}, []float64{0, 1}),
+		TrainLabel: matrix.New([]float64{1, 0}, []float64{1, 0}),
+		Epochs:     3,
+		BatchSize:  2,
+		Callbacks: []trainer.Callback{
+			&trainer.LearningRateScheduler{
+				Schedule: func(_ *trainer.Context) float64 { return 0.1 },
+			},
+		},
+	}, rand.Const(1))
+
+	fmt.Println(err)
+
+	// Output:
+	// optimizer=*trainer_test.TestOptimizer does not implement LearningRateSetter
+}
+
+func ExampleLearningRateScheduler_scheduled() {
+	o := schedule.New(&optimizer.SGD{}, &schedule.StepDecay{Initial: 0.1, StepSize: 1, Gamma: 0.5})
+	tr := trainer.New(&TestModel{}, o)
+	err := tr.Fit(&trainer.Input{
+		Train:      matrix.New([]float64{0, 1}, []float64{0, 1}),
+		TrainLabel: matrix.New([]float64{1, 0}, []float64{1, 0}),
+		Epochs:     3,
+		BatchSize:  2,
+		Callbacks: []trainer.Callback{
+			&trainer.LearningRateScheduler{
+				Schedule: func(_ *trainer.Context) float64 { return 0.1 },
+			},
+		},
+	}, rand.Const(1))
+
+	fmt.Println(err)
+
+	// Output:
+	// optimizer=*schedule.Scheduled is scheduled by its Schedule
+}
@@ -0,0 +1,221 @@
+package trainer
+
+import (
+	"encoding"
+	"encoding/gob"
+	"fmt"
+	randv2 "math/rand/v2"
+	"os"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/model"
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/optimizer/schedule"
+)
+
+var (
+	_ StatefulOptimizer = (*optimizer.AdaDelta)(nil)
+	_ StatefulOptimizer = (*optimizer.AdaGrad)(nil)
+	_ StatefulOptimizer = (*optimizer.Adam)(nil)
+	_ StatefulOptimizer = (*optimizer.AdamW)(nil)
+	_ StatefulOptimizer = (*optimizer.LAMB)(nil)
+	_ StatefulOptimizer = (*optimizer.Momentum)(nil)
+	_ StatefulOptimizer = (*optimizer.Nesterov)(nil)
+	_ StatefulOptimizer = (*optimizer.RMSProp)(nil)
+	_ StatefulOptimizer = (*optimizer.SGD)(nil)
+	_ StatefulOptimizer = (*schedule.Scheduled)(nil)
+)
+
+// StatefulOptimizer is an optimizer with the internal state, which is saved in a checkpoint.
+type StatefulOptimizer interface {
+	Optimizer
+	State() *optimizer.State
+	SetState(s *optimizer.State)
+}
+
+// Checkpoint is a callback that writes the checkpoints periodically.
+// Fit resumes from the first checkpoint in the callbacks, if Resume is true.
+type Checkpoint struct {
+	NopCallback
+	Filename string          // the checkpoint file
+	Every    int             // the number of iterations between checkpoints
+	Resume   bool            // resume from the checkpoint file if it exists
+	Sources  []randv2.Source // the other sources to save, e.g. the source of the model for dropout
+}
+
+// State is the training state in a checkpoint file.
+type State struct {
+	Epoch, Iter   int                 // the position of the next batch
+	Step          int                 // the number of iterations done
+	Params        [][]matrix.Matrix   // the params of the model
+	Optimizer     *optimizer.State    // the state of the optimizer
+	Source        []byte              // the state of the source of Fit at the beginning of the epoch
+	Sources       [][]byte            // the states of Checkpoint.Sources
+	TimeIdx       int                 // the time index of RNNLMTrainer
+	Hidden        [][]matrix.Matrix   // the hidden state of the RNNLM
+	Loss          float64             // the total loss of the epoch
+	Count         int                 // the number of the losses
+	EarlyStopping *EarlyStoppingState // the state of the early stopping in the callbacks
+}
+
+// Open reads the training state from a checkpoint file.
+func Open(filename string) (*State, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %v", err)
+	}
+	defer f.Close()
+
+	var st State
+	if err := gob.NewDecoder(f).Decode(&st); err != nil {
+		return nil, fmt.Errorf("decode checkpoint: %v", err)
+	}
+
+	return &st, nil
+}
+
+// due returns true if the checkpoint should be written after the step.
+func (c *Checkpoint) due(step int) bool {
+	return c != nil && c.Every > 0 && step%c.Every == 0
+}
+
+// OnEpochBegin records the state of the source of Fit at the beginning of the epoch.
+func (c *Checkpoint) OnEpochBegin(ctx *Context) {
+	if ctx.source == nil {
+		return
+	}
+
+	b, err := marshal(ctx.source)
+	if err != nil {
+		ctx.Fail(fmt.Errorf("checkpoint: %v", err))
+		return
+	}
+
+	ctx.state.Source = b
+}
+
+// OnBatchEnd writes the checkpoint every c.Every iterations.
+func (c *Checkpoint) OnBatchEnd(ctx *Context) {
+	if !c.due(ctx.Step) {
+		return
+	}
+
+	if err := c.save(ctx.state, ctx.Model, ctx.Optimizer, ctx.callbacks.earlyStopping()); err != nil {
+		ctx.Fail(fmt.Errorf("checkpoint: %v", err))
+	}
+}
+
+// save writes the training state with the params, the optimizer state, the hidden state, the sources and the early stopping state.
+// The file is replaced atomically, so a crash while saving keeps the previous checkpoint.
+func (c *Checkpoint) save(st *State, m optimizer.Model, o Optimizer, es *EarlyStopping) error {
+	st.Params = m.Params()
+	if es != nil {
+		st.EarlyStopping = es.State()
+	}
+
+	if so, ok := o.(StatefulOptimizer); ok {
+		st.Optimizer = so.State()
+	}
+
+	if sm, ok := m.(StatefulRNNLM); ok {
+		st.Hidden = sm.State()
+	}
+
+	st.Sources = make([][]byte, len(c.Sources))
+	for i, s := range c.Sources {
+		b, err := marshal(s)
+		if err != nil {
+			return err
+		}
+
+		st.Sources[i] = b
+	}
+
+	tmp := c.Filename + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("create file: %v", err)
+	}
+
+	if err := gob.NewEncoder(f).Encode(st); err != nil {
+		f.Close()
+		return fmt.Errorf("encode checkpoint: %v", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close file: %v", err)
+	}
+
+	if err := os.Rename(tmp, c.Filename); err != nil {
+		return fmt.Errorf("rename file: %v", err)
+	}
+
+	return nil
+}
+
+// resume restores the params, the optimizer state and the sources from the checkpoint file.
+// It returns the zero state if Resume is false or the file does not exist.
+func (c *Checkpoint) resume(m optimizer.Model, o Optimizer) (*State, error) {
+	if c == nil || !c.Resume {
+		return &State{}, nil
+	}
+
+	if _, err := os.Stat(c.Filename); os.IsNotExist(err) {
+		return &State{}, nil
+	}
+
+	st, err := Open(c.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := model.Check(m.Params(), st.Params); err != nil {
+		return nil, fmt.Errorf("checkpoint: %v", err)
+	}
+	m.SetParams(st.Params)
+
+	if so, ok := o.(StatefulOptimizer); ok && st.Optimizer != nil {
+		so.SetState(st.Optimizer)
+	}
+
+	if len(st.Sources) != len(c.Sources) {
+		return nil, fmt.Errorf("len(sources)=%v, want=%v", len(st.Sources), len(c.Sources))
+	}
+
+	for i, s := range c.Sources {
+		if err := unmarshal(s, st.Sources[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return st, nil
+}
+
+// marshal returns the state of the source.
+func marshal(s randv2.Source) ([]byte, error) {
+	m, ok := s.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, fmt.Errorf("source=%T does not implement encoding.BinaryMarshaler", s)
+	}
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("marshal source: %v", err)
+	}
+
+	return b, nil
+}
+
+// unmarshal restores the state of the source.
+func unmarshal(s randv2.Source, b []byte) error {
+	u, ok := s.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return fmt.Errorf("source=%T does not implement encoding.BinaryUnmarshaler", s)
+	}
+
+	if err := u.UnmarshalBinary(b); err != nil {
+		return fmt.Errorf("unmarshal source: %v", err)
+	}
+
+	return nil
+}
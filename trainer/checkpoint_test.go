@@ -0,0 +1,287 @@
+package trainer_test
+
+import (
+	"fmt"
+	randv2 "math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/trainer"
+	"github.com/itsubaki/neu/weight"
+)
+
+// equals returns true if the params are exactly the same.
+func equals(x, y [][]matrix.Matrix) bool {
+	for i := range x {
+		for j := range x[i] {
+			if x[i][j].Sub(y[i][j]).Abs().Sum() != 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func ExampleOpen() {
+	dir, err := os.MkdirTemp("", "checkpoint")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "checkpoint.gob")
+
+	s := rand.Const(1)
+	tr := trainer.New(model.NewMLP(&model.MLPConfig{
+		InputSize:  2,
+		OutputSize: 2,
+		HiddenSize: []int{3},
+		WeightInit: weight.Xavier,
+	}, s), &optimizer.Adam{Alpha: 0.01, Beta1: 0.9, Beta2: 0.999})
+
+	if err := tr.Fit(&trainer.Input{
+		Train:      matrix.New([]float64{0.5, 0.5}, []float64{1, 0}, []float64{0, 1}, []float64{1, 1}),
+		TrainLabel: matrix.New([]float64{1, 0}, []float64{0, 1}, []float64{0, 1}, []float64{1, 0}),
+		Epochs:     3,
+		BatchSize:  2,
+		Verbose:    func(_, _ int, _ float64, _ trainer.Model) {},
+		Checkpoint: &trainer.Checkpoint{Filename: filename, Every: 3},
+	}, s); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	st, err := trainer.Open(filename)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(st.Epoch, st.Iter, st.Step)
+	fmt.Println(st.Optimizer.Iter, len(st.Optimizer.Slots))
+
+	// Output:
+	// 2 2 6
+	// 6 2
+}
+
+func TestTrainer_resume(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "checkpoint.gob")
+	x := matrix.Randn(8, 2, rand.Const(1))
+	y := matrix.OneHot([]int{0, 1, 1, 0, 1, 0, 0, 1}, 2)
+
+	fit := func(c *trainer.Checkpoint) ([][]matrix.Matrix, []float64) {
+		s := rand.Const(1)
+		m := model.NewMLP(&model.MLPConfig{
+			InputSize:  2,
+			OutputSize: 2,
+			HiddenSize: []int{4},
+			WeightInit: weight.Xavier,
+		}, s)
+
+		if c != nil {
+			c.Sources = []randv2.Source{m.Source}
+		}
+
+		var loss []float64
+		tr := trainer.New(m, &optimizer.Adam{Alpha: 0.01, Beta1: 0.9, Beta2: 0.999})
+		if err := tr.Fit(&trainer.Input{
+			Train:      x,
+			TrainLabel: y,
+			Epochs:     3,
+			BatchSize:  2,
+			Verbose:    func(_, _ int, l float64, _ trainer.Model) { loss = append(loss, l) },
+			Checkpoint: c,
+		}, s); err != nil {
+			t.Fatal(err)
+		}
+
+		return m.Params(), loss
+	}
+
+	want, wloss := fit(nil)
+	fit(&trainer.Checkpoint{Filename: filename, Every: 5}) // the last checkpoint is after 10 of 12 iterations
+	got, gloss := fit(&trainer.Checkpoint{Filename: filename, Every: 5, Resume: true})
+
+	if len(gloss) != 2 || gloss[0] != wloss[10] || gloss[1] != wloss[11] {
+		t.Errorf("loss=%v, want=%v", gloss, wloss[10:])
+	}
+
+	if !equals(got, want) {
+		t.Errorf("params=%v, want=%v", got, want)
+	}
+}
+
+func TestTrainer_resumeEarlyStopping(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "checkpoint.gob")
+	x := matrix.Randn(8, 2, rand.Const(1))
+	y := matrix.OneHot([]int{0, 1, 1, 0, 1, 0, 0, 1}, 2)
+
+	fit := func(c *trainer.Checkpoint) *trainer.EarlyStoppingState {
+		s := rand.Const(1)
+		m := model.NewMLP(&model.MLPConfig{
+			InputSize:  2,
+			OutputSize: 2,
+			HiddenSize: []int{4},
+			WeightInit: weight.Xavier,
+		}, s)
+
+		es := &trainer.EarlyStopping{Monitor: trainer.MonitorAccuracy, Patience: 10}
+		tr := trainer.New(m, &optimizer.SGD{LearningRate: 0.5})
+		if err := tr.Fit(&trainer.Input{
+			Train:         x,
+			TrainLabel:    y,
+			Epochs:        3,
+			BatchSize:     2,
+			Verbose:       func(_, _ int, _ float64, _ trainer.Model) {},
+			Valid:         x,
+			ValidLabel:    y,
+			Checkpoint:    c,
+			EarlyStopping: es,
+		}, s); err != nil {
+			t.Fatal(err)
+		}
+
+		return es.State()
+	}
+
+	want := fit(nil)
+	fit(&trainer.Checkpoint{Filename: filename, Every: 5}) // the last checkpoint is after 10 of 12 iterations
+	got := fit(&trainer.Checkpoint{Filename: filename, Every: 5, Resume: true})
+
+	if got.Best != want.Best || got.BestEpoch != want.BestEpoch || got.Wait != want.Wait || got.Seen != want.Seen {
+		t.Errorf("got=%+v, want=%+v", got, want)
+	}
+}
+
+func TestRNNLMTrainer_resume(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "checkpoint.gob")
+	corpus := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 1, 3, 5, 7, 9, 0, 2, 4, 6, 8, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0}
+
+	fit := func(c *trainer.Checkpoint) ([][]matrix.Matrix, []float64) {
+		m := model.NewLSTMLM(&model.LSTMLMConfig{
+			RNNLMConfig: model.RNNLMConfig{
+				VocabSize:   10,
+				WordVecSize: 4,
+				HiddenSize:  4,
+				WeightInit:  weight.Xavier,
+			},
+			DropoutRatio: 0.5,
+		}, rand.Const(1))
+
+		if c != nil {
+			c.Sources = []randv2.Source{m.Source}
+		}
+
+		var ppl []float64
+		tr := trainer.NewRNNLM(m, &optimizer.Adam{Alpha: 0.01, Beta1: 0.9, Beta2: 0.999})
+		if err := tr.Fit(&trainer.RNNLMInput{
+			Train:      corpus[:len(corpus)-1],
+			TrainLabel: corpus[1:],
+			Epochs:     2,
+			BatchSize:  2,
+			TimeSize:   3,
+			Verbose:    func(_, _ int, p float64, _ trainer.RNNLM) { ppl = append(ppl, p) },
+			Checkpoint: c,
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		return m.Params(), ppl
+	}
+
+	want, wppl := fit(nil)
+	fit(&trainer.Checkpoint{Filename: filename, Every: 7}) // the last checkpoint is after 7 of 10 iterations
+	got, gppl := fit(&trainer.Checkpoint{Filename: filename, Every: 7, Resume: true})
+
+	if fmt.Sprint(gppl) != fmt.Sprint(wppl[7:]) {
+		t.Errorf("perplexity=%v, want=%v", gppl, wppl[7:])
+	}
+
+	if !equals(got, want) {
+		t.Errorf("params=%v, want=%v", got, want)
+	}
+}
+
+func TestSeq2SeqTrainer_resume(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "checkpoint.gob")
+	x := [][]int{{0, 1, 2}, {3, 4, 5}, {6, 7, 8}, {1, 3, 5}, {2, 4, 6}, {7, 8, 9}}
+	y := [][]int{{1, 2}, {4, 5}, {7, 8}, {3, 5}, {4, 6}, {8, 9}}
+
+	fit := func(c *trainer.Checkpoint) ([][]matrix.Matrix, []float64) {
+		s := rand.Const(1)
+		m := model.NewSeq2Seq(&model.RNNLMConfig{
+			VocabSize:   10,
+			WordVecSize: 4,
+			HiddenSize:  4,
+			WeightInit:  weight.Xavier,
+		}, s)
+
+		// the checkpoint in the callbacks
+		var cbs []trainer.Callback
+		if c != nil {
+			cbs = append(cbs, c)
+		}
+
+		var loss []float64
+		tr := trainer.NewSeq2Seq(m, &optimizer.Adam{Alpha: 0.01, Beta1: 0.9, Beta2: 0.999})
+		if err := tr.Fit(&trainer.Seq2SeqInput{
+			Train:      x,
+			TrainLabel: y,
+			Epochs:     3,
+			BatchSize:  2,
+			Verbose:    func(_, _ int, l float64, _ trainer.Seq2Seq) { loss = append(loss, l) },
+			Callbacks:  cbs,
+		}, s); err != nil {
+			t.Fatal(err)
+		}
+
+		return m.Params(), loss
+	}
+
+	want, wloss := fit(nil)
+	fit(&trainer.Checkpoint{Filename: filename, Every: 4}) // the last checkpoint is after 8 of 9 iterations
+	got, gloss := fit(&trainer.Checkpoint{Filename: filename, Every: 4, Resume: true})
+
+	if fmt.Sprint(gloss) != fmt.Sprint(wloss[8:]) {
+		t.Errorf("loss=%v, want=%v", gloss, wloss[8:])
+	}
+
+	if !equals(got, want) {
+		t.Errorf("params=%v, want=%v", got, want)
+	}
+}
+
+func TestCheckpoint_shape(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "checkpoint.gob")
+	in := func(c *trainer.Checkpoint) *trainer.Input {
+		return &trainer.Input{
+			Train:      matrix.New([]float64{0.5, 0.5}, []float64{1, 0}),
+			TrainLabel: matrix.New([]float64{1, 0}, []float64{0, 1}),
+			Epochs:     1,
+			BatchSize:  1,
+			Verbose:    func(_, _ int, _ float64, _ trainer.Model) {},
+			Checkpoint: c,
+		}
+	}
+
+	mlp := func(hidden int) *model.MLP {
+		return model.NewMLP(&model.MLPConfig{InputSize: 2, OutputSize: 2, HiddenSize: []int{hidden}, WeightInit: weight.Xavier}, rand.Const(1))
+	}
+
+	if err := trainer.New(mlp(3), &optimizer.SGD{LearningRate: 0.1}).Fit(in(&trainer.Checkpoint{Filename: filename, Every: 1}), rand.Const(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	err := trainer.New(mlp(4), &optimizer.SGD{LearningRate: 0.1}).Fit(in(&trainer.Checkpoint{Filename: filename, Every: 1, Resume: true}), rand.Const(1))
+	if err == nil || err.Error() != "resume: checkpoint: params[0][0]=(2, 3), want=(2, 4)" {
+		t.Errorf("err=%v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package trainer
+
+import (
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/optimizer"
+)
+
+// Monitor is the metric monitored by EarlyStopping.
+type Monitor string
+
+const (
+	MonitorLoss       Monitor = "loss"       // lower is better
+	MonitorAccuracy   Monitor = "accuracy"   // higher is better
+	MonitorPerplexity Monitor = "perplexity" // lower is better
+)
+
+// EarlyStopping stops the training when the monitored validation metric has not improved for Patience epochs.
+type EarlyStopping struct {
+	NopCallback
+	Monitor     Monitor
+	Patience    int     // the number of epochs without improvement before stopping
+	MinDelta    float64 // the minimum change to be an improvement
+	RestoreBest bool    // restore the params of the best epoch at the end of the training
+	Best        float64 // the best value of the monitored metric
+	BestEpoch   int     // the epoch of the best value
+	wait        int
+	seen        bool
+	params      [][]matrix.Matrix
+}
+
+// EarlyStoppingState is the internal state of EarlyStopping, which is saved in a checkpoint.
+type EarlyStoppingState struct {
+	Best      float64
+	BestEpoch int
+	Wait      int
+	Seen      bool
+	Params    [][]matrix.Matrix // the params of the best epoch, if RestoreBest is true
+}
+
+// State returns the internal state of the early stopping.
+func (es *EarlyStopping) State() *EarlyStoppingState {
+	return &EarlyStoppingState{
+		Best:      es.Best,
+		BestEpoch: es.BestEpoch,
+		Wait:      es.wait,
+		Seen:      es.seen,
+		Params:    optimizer.Clone(es.params),
+	}
+}
+
+// SetState sets the internal state of the early stopping.
+func (es *EarlyStopping) SetState(s *EarlyStoppingState) {
+	es.Best, es.BestEpoch, es.wait, es.seen, es.params = s.Best, s.BestEpoch, s.Wait, s.Seen, optimizer.Clone(s.Params)
+}
+
+// Value returns the monitored value of the metrics.
+func (es *EarlyStopping) Value(m Metrics) float64 {
+	switch es.Monitor {
+	case MonitorAccuracy:
+		return m.Accuracy
+	case MonitorPerplexity:
+		return m.Perplexity
+	default:
+		return m.Loss
+	}
+}
+
+// Update records the metrics of the epoch and returns true if the training should stop.
+func (es *EarlyStopping) Update(epoch int, m Metrics, params [][]matrix.Matrix) bool {
+	v := es.Value(m)
+	if !es.seen || es.improved(v) {
+		es.Best, es.BestEpoch, es.wait, es.seen = v, epoch, 0, true
+		if es.RestoreBest {
+			es.params = optimizer.Clone(params)
+		}
+
+		return false
+	}
+
+	es.wait++
+	return es.wait >= es.Patience
+}
+
+// Restore sets the params of the best epoch to the model, if RestoreBest is true.
+func (es *EarlyStopping) Restore(m interface{ SetParams(p [][]matrix.Matrix) }) {
+	if !es.RestoreBest || len(es.params) == 0 {
+		return
+	}
+
+	m.SetParams(optimizer.Clone(es.params))
+}
+
+func (es *EarlyStopping) improved(v float64) bool {
+	if es.Monitor == MonitorAccuracy {
+		return v > es.Best+es.MinDelta
+	}
+
+	return v < es.Best-es.MinDelta
+}
+
+// OnEpochEnd updates the validation metrics and stops the training if they have not improved.
+func (es *EarlyStopping) OnEpochEnd(c *Context) {
+	if c.Metrics == nil {
+		return
+	}
+
+	if es.Update(c.Epoch, *c.Metrics, c.Model.Params()) {
+		c.Stop()
+	}
+}
+
+// OnTrainEnd restores the params of the best epoch, if RestoreBest is true.
+func (es *EarlyStopping) OnTrainEnd(c *Context) {
+	es.Restore(c.Model)
+}
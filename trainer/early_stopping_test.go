@@ -0,0 +1,63 @@
+package trainer_test
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/trainer"
+)
+
+func ExampleEarlyStopping() {
+	es := &trainer.EarlyStopping{
+		Monitor:  trainer.MonitorLoss,
+		Patience: 2,
+	}
+
+	for i, loss := range []float64{1.0, 0.8, 0.9, 0.7, 0.75, 0.71, 0.6} {
+		stop := es.Update(i, trainer.Metrics{Loss: loss}, nil)
+		fmt.Println(i, loss, stop)
+		if stop {
+			break
+		}
+	}
+	fmt.Println(es.Best, es.BestEpoch)
+
+	// Output:
+	// 0 1 false
+	// 1 0.8 false
+	// 2 0.9 false
+	// 3 0.7 false
+	// 4 0.75 false
+	// 5 0.71 true
+	// 0.7 3
+}
+
+func ExampleEarlyStopping_accuracy() {
+	es := &trainer.EarlyStopping{
+		Monitor:     trainer.MonitorAccuracy,
+		Patience:    1,
+		MinDelta:    0.05,
+		RestoreBest: true,
+	}
+
+	for i, acc := range []float64{0.5, 0.7, 0.72} {
+		params := [][]matrix.Matrix{{{{acc}}}}
+		fmt.Println(i, acc, es.Update(i, trainer.Metrics{Accuracy: acc}, params))
+	}
+
+	m := &TestModelParams{}
+	es.Restore(m)
+	fmt.Println(es.Best, es.BestEpoch, m.params)
+
+	// Output:
+	// 0 0.5 false
+	// 1 0.7 false
+	// 2 0.72 true
+	// 0.7 1 [[[[0.7]]]]
+}
+
+type TestModelParams struct {
+	params [][]matrix.Matrix
+}
+
+func (m *TestModelParams) SetParams(p [][]matrix.Matrix) { m.params = p }
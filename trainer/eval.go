@@ -0,0 +1,115 @@
+package trainer
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/tensor"
+	"github.com/itsubaki/neu/math/vector"
+)
+
+// Metrics is the result of the evaluation.
+type Metrics struct {
+	Loss       float64
+	Accuracy   float64 // Trainer and Seq2SeqTrainer
+	Perplexity float64 // RNNLMTrainer
+}
+
+// Evaluate returns the mean loss and the accuracy of the classification model over all of x.
+// The loss is the softmax cross entropy of Predict, so the layers like dropout are in the inference mode.
+func Evaluate(m Model, x, t matrix.Matrix, batchSize int) Metrics {
+	var loss float64
+	var correct int
+	for begin := 0; begin < len(x); begin += batchSize {
+		end := min(begin+batchSize, len(x))
+		y := m.Predict(x[begin:end])
+
+		loss += (&layer.SoftmaxWithLoss{}).Forward(y, t[begin:end])[0][0] * float64(end-begin)
+		correct += vector.MatchCount(y.Argmax(), t[begin:end].Argmax())
+	}
+
+	return Metrics{
+		Loss:     loss / float64(len(x)),
+		Accuracy: float64(correct) / float64(len(x)),
+	}
+}
+
+// EvaluateRNNLM returns the mean loss and the perplexity of the language model over all of the corpus.
+// The hidden state of the model is reset before the evaluation.
+// If the model is a StatefulRNNLM, the hidden state is restored after the evaluation.
+// It returns an error if the corpus is smaller than batchSize*timeSize.
+func EvaluateRNNLM(m RNNLM, xs, ts []int, batchSize, timeSize int) (Metrics, error) {
+	size := len(xs)
+	maxIter := size / (batchSize * timeSize)
+	if maxIter == 0 {
+		return Metrics{}, fmt.Errorf("len(xs)=%v is smaller than batchSize*timeSize=%v", size, batchSize*timeSize)
+	}
+
+	if s, ok := m.(StatefulRNNLM); ok {
+		state := s.State()
+		defer s.SetState(state)
+	}
+
+	if r, ok := m.(interface{ ResetState() }); ok {
+		r.ResetState()
+	}
+
+	jump := size / batchSize
+
+	var total float64
+	for j := 0; j < maxIter; j++ {
+		xbatch, tbatch := tensor.Zero(timeSize, batchSize, 1), tensor.Zero(timeSize, batchSize, 1)
+		for t := 0; t < timeSize; t++ {
+			for i := 0; i < batchSize; i++ {
+				k := (i*jump + j*timeSize + t) % size
+				xbatch[t][i] = []float64{float64(xs[k])}
+				tbatch[t][i] = []float64{float64(ts[k])}
+			}
+		}
+
+		ys := m.Predict(xbatch)
+		total += (&layer.TimeSoftmaxWithLoss{}).Forward(ys, tbatch)[0][0][0]
+	}
+
+	return Metrics{
+		Loss:       total / float64(maxIter),
+		Perplexity: Perplexity(total, maxIter),
+	}, nil
+}
+
+// EvaluateSeq2Seq returns the mean loss and the accuracy of the model over all of x.
+// The accuracy is the ratio of the generated sequences that exactly match t[1:], with t[0] as the start id.
+// If the model has Loss, e.g. TransformerSeq2Seq, the loss is of Loss, which runs without the dropout.
+func EvaluateSeq2Seq(m Seq2Seq, x, t [][]int, batchSize int) Metrics {
+	xs, ts := matrix.From(x), matrix.From(t)
+
+	forward := m.Forward
+	if l, ok := m.(interface {
+		Loss(xs, ts []matrix.Matrix) []matrix.Matrix
+	}); ok {
+		forward = l.Loss
+	}
+
+	var loss float64
+	for begin := 0; begin < len(x); begin += batchSize {
+		end := min(begin+batchSize, len(x))
+		xbatch := vector.Reverse(Time(xs[begin:end]))
+		tbatch := Time(ts[begin:end])
+
+		loss += forward(xbatch, tbatch)[0][0][0] * float64(end-begin)
+	}
+
+	var correct int
+	for i := range x {
+		q := vector.Reverse(Time(xs[i : i+1]))
+		if vector.Equals(m.Generate(q, t[i][0], len(t[i])-1), t[i][1:]) {
+			correct++
+		}
+	}
+
+	return Metrics{
+		Loss:     loss / float64(len(x)),
+		Accuracy: float64(correct) / float64(len(x)),
+	}
+}
@@ -0,0 +1,194 @@
+package trainer_test
+
+import (
+	"fmt"
+	randv2 "math/rand/v2"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/trainer"
+	"github.com/itsubaki/neu/weight"
+)
+
+// newSequential returns Affine -> ReLU -> Affine -> SoftmaxWithLoss.
+func newSequential(s randv2.Source) *model.Sequential {
+	return model.NewSequential([]model.Layer{
+		&layer.Affine{W: matrix.Randn(2, 8, s).MulC(0.5), B: matrix.Zero(1, 8)},
+		&layer.ReLU{},
+		&layer.Affine{W: matrix.Randn(8, 2, s).MulC(0.5), B: matrix.Zero(1, 2)},
+		&layer.SoftmaxWithLoss{},
+	}, s)
+}
+
+func ExampleEvaluate() {
+	m := newSequential(rand.Const(1))
+
+	x := matrix.New([]float64{0.5, 0.5}, []float64{1, 0}, []float64{0, 1})
+	t := matrix.New([]float64{1, 0}, []float64{0, 1}, []float64{0, 1})
+
+	// the remainder batch is evaluated too
+	fmt.Printf("%.4f\n", trainer.Evaluate(m, x, t, 2))
+	fmt.Printf("%.4f\n", trainer.Evaluate(m, x, t, 3))
+
+	// Output:
+	// {0.6536 1.0000 0.0000}
+	// {0.6536 1.0000 0.0000}
+}
+
+func ExampleTrainer_Fit_validation() {
+	s := rand.Const(1)
+	m := newSequential(s)
+
+	x := matrix.New([]float64{0, 0}, []float64{1, 0}, []float64{0, 1}, []float64{1, 1})
+	t := matrix.New([]float64{1, 0}, []float64{0, 1}, []float64{0, 1}, []float64{1, 0})
+
+	es := &trainer.EarlyStopping{
+		Monitor:     trainer.MonitorLoss,
+		Patience:    2,
+		RestoreBest: true,
+	}
+
+	tr := trainer.New(m, &optimizer.SGD{LearningRate: 0.5})
+	tr.Fit(&trainer.Input{
+		Train:      x,
+		TrainLabel: t,
+		Epochs:     10,
+		BatchSize:  2,
+		Verbose:    func(_, _ int, _ float64, _ trainer.Model) {},
+		Valid:      x,
+		ValidLabel: t,
+		ValidVerbose: func(epoch int, metrics trainer.Metrics, _ trainer.Model) {
+			fmt.Printf("%d: loss=%.4f, acc=%.2f\n", epoch, metrics.Loss, metrics.Accuracy)
+		},
+		EarlyStopping: es,
+	}, s)
+
+	fmt.Printf("best=%.4f, epoch=%d\n", es.Best, es.BestEpoch)
+	fmt.Printf("%.4f\n", trainer.Evaluate(m, x, t, 2).Loss)
+
+	// Output:
+	// 0: loss=0.6211, acc=0.75
+	// 1: loss=0.5744, acc=0.75
+	// 2: loss=0.6632, acc=0.75
+	// 3: loss=0.6198, acc=0.75
+	// best=0.5744, epoch=1
+	// 0.5744
+}
+
+func ExampleEvaluateRNNLM() {
+	m := model.NewRNNLM(&model.RNNLMConfig{
+		VocabSize:   10,
+		WordVecSize: 4,
+		HiddenSize:  4,
+		WeightInit:  weight.Xavier,
+	}, rand.Const(1))
+
+	corpus := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0}
+	metrics, err := trainer.EvaluateRNNLM(m, corpus[:len(corpus)-1], corpus[1:], 2, 5)
+	fmt.Printf("%.4f, %v\n", metrics.Perplexity, err)
+
+	// the corpus is smaller than batchSize*timeSize
+	if _, err := trainer.EvaluateRNNLM(m, corpus[:len(corpus)-1], corpus[1:], 2, 6); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// 10.0728, <nil>
+	// len(xs)=10 is smaller than batchSize*timeSize=12
+}
+
+func ExampleRNNLMTrainer_validation() {
+	m := model.NewRNNLM(&model.RNNLMConfig{
+		VocabSize:   10,
+		WordVecSize: 4,
+		HiddenSize:  4,
+		WeightInit:  weight.Xavier,
+	}, rand.Const(1))
+
+	corpus := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0}
+	tr := trainer.NewRNNLM(m, &optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999})
+	tr.Fit(&trainer.RNNLMInput{
+		Train:      corpus[:len(corpus)-1],
+		TrainLabel: corpus[1:],
+		Epochs:     3,
+		BatchSize:  2,
+		TimeSize:   5,
+		Verbose:    func(_, _ int, _ float64, _ trainer.RNNLM) {},
+		Valid:      corpus[:len(corpus)-1],
+		ValidLabel: corpus[1:],
+		ValidVerbose: func(epoch int, metrics trainer.Metrics, _ trainer.RNNLM) {
+			fmt.Printf("%d: loss=%.4f, ppl=%.4f\n", epoch, metrics.Loss, metrics.Perplexity)
+		},
+	})
+
+	// Output:
+	// 0: loss=2.2749, ppl=9.7271
+	// 1: loss=2.0371, ppl=7.6680
+	// 2: loss=1.8764, ppl=6.5299
+}
+
+func ExampleSeq2SeqTrainer_validation() {
+	s := rand.Const(1)
+	m := model.NewSeq2Seq(&model.RNNLMConfig{
+		VocabSize:   10,
+		WordVecSize: 8,
+		HiddenSize:  8,
+		WeightInit:  weight.Xavier,
+	}, s)
+
+	x := [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {3, 2, 1}}
+	t := [][]int{{0, 1, 2}, {0, 4, 5}, {0, 7, 8}, {0, 3, 2}}
+
+	tr := trainer.NewSeq2Seq(m, &optimizer.Adam{Alpha: 0.1, Beta1: 0.9, Beta2: 0.999})
+	tr.Fit(&trainer.Seq2SeqInput{
+		Train:      x,
+		TrainLabel: t,
+		Epochs:     30,
+		BatchSize:  2,
+		Verbose:    func(_, _ int, _ float64, _ trainer.Seq2Seq) {},
+		Valid:      x,
+		ValidLabel: t,
+		ValidVerbose: func(epoch int, metrics trainer.Metrics, _ trainer.Seq2Seq) {
+			if epoch%10 != 9 {
+				return
+			}
+
+			fmt.Printf("%d: loss=%.4f, acc=%.2f\n", epoch, metrics.Loss, metrics.Accuracy)
+		},
+	}, s)
+
+	// Output:
+	// 9: loss=0.1986, acc=0.75
+	// 19: loss=0.0245, acc=1.00
+	// 29: loss=0.0025, acc=1.00
+}
+
+func ExampleEvaluateSeq2Seq_dropout() {
+	m := model.NewTransformerSeq2Seq(&model.TransformerConfig{
+		VocabSize:       10,
+		HiddenSize:      8,
+		Heads:           2,
+		FeedForwardSize: 16,
+		Layers:          1,
+		MaxLength:       8,
+		DropoutRatio:    0.5,
+		WeightInit:      weight.Xavier,
+	}, rand.Const(1))
+	m.Source = rand.Const(2)
+
+	x := [][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {3, 2, 1}}
+	t := [][]int{{0, 1, 2}, {0, 4, 5}, {0, 7, 8}, {0, 3, 2}}
+
+	// the loss is without the dropout, and the source of the dropout is not used
+	m1 := trainer.EvaluateSeq2Seq(m, x, t, 2)
+	m2 := trainer.EvaluateSeq2Seq(m, x, t, 2)
+	fmt.Println(m1.Loss == m2.Loss)
+	fmt.Println(m.Source.Uint64() == rand.Const(2).Uint64())
+
+	// Output:
+	// true
+	// true
+}
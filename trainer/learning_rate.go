@@ -0,0 +1,49 @@
+package trainer
+
+import (
+	"fmt"
+
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/optimizer/schedule"
+)
+
+var (
+	_ LearningRateSetter = (*optimizer.AdaDelta)(nil)
+	_ LearningRateSetter = (*optimizer.AdaGrad)(nil)
+	_ LearningRateSetter = (*optimizer.Adam)(nil)
+	_ LearningRateSetter = (*optimizer.AdamW)(nil)
+	_ LearningRateSetter = (*optimizer.LAMB)(nil)
+	_ LearningRateSetter = (*optimizer.Momentum)(nil)
+	_ LearningRateSetter = (*optimizer.Nesterov)(nil)
+	_ LearningRateSetter = (*optimizer.RMSProp)(nil)
+	_ LearningRateSetter = (*optimizer.SGD)(nil)
+)
+
+// LearningRateSetter is an optimizer with the learning rate that can be changed during the training.
+type LearningRateSetter interface {
+	SetLearningRate(lr float64)
+}
+
+// LearningRateScheduler is a callback that sets the learning rate of the optimizer at the beginning of each epoch.
+// Use schedule.New to set the learning rate of each update instead.
+// Fit fails if the optimizer is a *schedule.Scheduled, since its learning rate is set by the schedule.
+type LearningRateScheduler struct {
+	NopCallback
+	Schedule func(c *Context) float64 // returns the learning rate of c.Epoch. c.Metrics is of the previous epoch.
+}
+
+// OnEpochBegin sets the learning rate of the epoch.
+func (l *LearningRateScheduler) OnEpochBegin(c *Context) {
+	if _, ok := c.Optimizer.(*schedule.Scheduled); ok {
+		c.Fail(fmt.Errorf("optimizer=%T is scheduled by its Schedule", c.Optimizer))
+		return
+	}
+
+	o, ok := c.Optimizer.(LearningRateSetter)
+	if !ok {
+		c.Fail(fmt.Errorf("optimizer=%T does not implement LearningRateSetter", c.Optimizer))
+		return
+	}
+
+	o.SetLearningRate(l.Schedule(c))
+}
@@ -0,0 +1,50 @@
+package trainer
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Logger is a callback that prints the loss of the batches and the validation metrics.
+type Logger struct {
+	NopCallback
+	Writer io.Writer // os.Stdout if nil
+	Every  int       // the number of batches between the logs, or 0 for no batch logs
+}
+
+// OnBatchEnd prints the loss of the batch every l.Every batches.
+func (l *Logger) OnBatchEnd(c *Context) {
+	if l.Every <= 0 || (c.Batch+1)%l.Every != 0 {
+		return
+	}
+
+	if c.Perplexity > 0 {
+		fmt.Fprintf(l.writer(), "%3d,%4d: loss=%.04f, ppl=%.04f\n", c.Epoch, c.Batch, c.Loss, c.Perplexity)
+		return
+	}
+
+	fmt.Fprintf(l.writer(), "%3d,%4d: loss=%.04f\n", c.Epoch, c.Batch, c.Loss)
+}
+
+// OnEpochEnd prints the validation metrics, if evaluated.
+func (l *Logger) OnEpochEnd(c *Context) {
+	if c.Metrics == nil {
+		return
+	}
+
+	if c.Metrics.Perplexity > 0 {
+		fmt.Fprintf(l.writer(), "%3d: valid_loss=%.04f, valid_ppl=%.04f\n", c.Epoch, c.Metrics.Loss, c.Metrics.Perplexity)
+		return
+	}
+
+	fmt.Fprintf(l.writer(), "%3d: valid_loss=%.04f, valid_acc=%.04f\n", c.Epoch, c.Metrics.Loss, c.Metrics.Accuracy)
+}
+
+func (l *Logger) writer() io.Writer {
+	if l.Writer == nil {
+		return os.Stdout
+	}
+
+	return l.Writer
+}
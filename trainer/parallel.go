@@ -0,0 +1,197 @@
+package trainer
+
+import (
+	"sync"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/model"
+)
+
+var _ StatsLayer = (*layer.BatchNorm)(nil)
+
+// StatsLayer is a layer with the running statistics, e.g. the running mean and variance of BatchNorm.
+type StatsLayer interface {
+	Stats() []matrix.Matrix
+	SetStats(s ...matrix.Matrix)
+}
+
+// averaged is the model with the gradients averaged across the replicas.
+type averaged struct {
+	Model
+	grads [][]matrix.Matrix
+}
+
+func (m *averaged) Grads() [][]matrix.Matrix { return m.grads }
+
+func (m *averaged) ParamNames() [][]string {
+	if n, ok := m.Model.(interface{ ParamNames() [][]string }); ok {
+		return n.ParamNames()
+	}
+
+	return nil
+}
+
+// update runs Forward and Backward of the batch, updates the params and returns the loss.
+// If the trainer has the replicas, the batch is sharded across them.
+func (tr *Trainer) update(x, t matrix.Matrix) float64 {
+	if len(tr.Replicas) == 0 {
+		loss := tr.Model.Forward(x, t)
+		tr.Model.Backward()
+		tr.Optimizer.Update(tr.Model)
+		return loss[0][0]
+	}
+
+	loss, m := tr.parallel(x, t)
+	tr.Optimizer.Update(m)
+	return loss
+}
+
+// parallel runs Forward and Backward of the shards of the batch on the replicas at the same time.
+// It returns the loss and the model with the gradients, which are the means weighted by the size of the shards.
+func (tr *Trainer) parallel(x, t matrix.Matrix) (float64, *averaged) {
+	shards := Shards(len(x), len(tr.Replicas))
+	params := tr.Model.Params()
+	tr.setStats(tr.Replicas[:len(shards)])
+
+	losses := make([]float64, len(shards))
+	grads := make([][][]matrix.Matrix, len(shards))
+
+	var wg sync.WaitGroup
+	for k, s := range shards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			r := tr.Replicas[k]
+			r.SetParams(params)
+			losses[k] = r.Forward(x[s[0]:s[1]], t[s[0]:s[1]])[0][0]
+			r.Backward()
+			grads[k] = r.Grads()
+		}()
+	}
+	wg.Wait()
+
+	w := make([]float64, len(shards))
+	for k, s := range shards {
+		w[k] = float64(s[1]-s[0]) / float64(len(x))
+	}
+
+	var loss float64
+	for k := range shards {
+		loss += w[k] * losses[k]
+	}
+
+	tr.meanStats(tr.Replicas[:len(shards)], w)
+	return loss, &averaged{Model: tr.Model, grads: mean(grads, w)}
+}
+
+// setStats sets the running statistics of the model to the replicas.
+func (tr *Trainer) setStats(replicas []Model) {
+	for i, l := range statsLayers(tr.Model) {
+		s := l.Stats()
+		if s[0] == nil {
+			continue
+		}
+
+		for _, r := range replicas {
+			statsLayers(r)[i].SetStats(s...)
+		}
+	}
+}
+
+// meanStats sets the running statistics of the replicas, which are the means weighted by w, to the model.
+// The running variance also includes the variance between the batch means of the shards,
+// so that both equal the running statistics of the whole batch.
+func (tr *Trainer) meanStats(replicas []Model, w []float64) {
+	for i, l := range statsLayers(tr.Model) {
+		stats := make([][][]matrix.Matrix, len(replicas))
+		for k, r := range replicas {
+			stats[k] = [][]matrix.Matrix{statsLayers(r)[i].Stats()}
+		}
+
+		s := mean(stats, w)[0]
+		if len(s) < 2 || s[0] == nil || momentum(l) >= 1 {
+			l.SetStats(s...)
+			continue
+		}
+
+		// running = momentum * running + (1 - momentum) * batch,
+		// so the difference of the running means is (1 - momentum) times the difference of the batch means.
+		mu, va := s[0], s[1]
+		for k := range stats {
+			d := stats[k][0][0].Sub(mu)
+			va = va.Add(d.Mul(d).MulC(w[k] / (1 - momentum(l))))
+		}
+
+		l.SetStats(mu, va)
+	}
+}
+
+// momentum returns the momentum of the running statistics, or 0 for the layers without it.
+func momentum(l StatsLayer) float64 {
+	if b, ok := l.(*layer.BatchNorm); ok {
+		return b.Momentum
+	}
+
+	return 0
+}
+
+// statsLayers returns the layers with the running statistics of the model.
+func statsLayers(m Model) []StatsLayer {
+	lm, ok := m.(interface{ Layers() []model.Layer })
+	if !ok {
+		return nil
+	}
+
+	out := make([]StatsLayer, 0)
+	for _, l := range lm.Layers() {
+		if s, ok := l.(StatsLayer); ok {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+// mean returns the sum of w[k] * v[k].
+// The nil matrices, e.g. the gradients of the layers without params, are left nil.
+func mean(v [][][]matrix.Matrix, w []float64) [][]matrix.Matrix {
+	out := make([][]matrix.Matrix, len(v[0]))
+	for i := range v[0] {
+		out[i] = make([]matrix.Matrix, len(v[0][i]))
+		for j := range v[0][i] {
+			if v[0][i][j] == nil {
+				continue
+			}
+
+			out[i][j] = v[0][i][j].MulC(w[0])
+			for k := 1; k < len(v); k++ {
+				out[i][j] = out[i][j].Add(v[k][i][j].MulC(w[k]))
+			}
+		}
+	}
+
+	return out
+}
+
+// Shards returns the begin and end index of each shard of the batch.
+// The sizes of the shards differ by at most one, and the empty shards are omitted.
+func Shards(batchSize, n int) [][2]int {
+	out := make([][2]int, 0, n)
+	for k, begin := 0, 0; k < n; k++ {
+		size := batchSize / n
+		if k < batchSize%n {
+			size++
+		}
+
+		if size == 0 {
+			break
+		}
+
+		out = append(out, [2]int{begin, begin + size})
+		begin += size
+	}
+
+	return out
+}
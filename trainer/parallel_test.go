@@ -0,0 +1,112 @@
+package trainer_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/itsubaki/neu/layer"
+	"github.com/itsubaki/neu/math/matrix"
+	"github.com/itsubaki/neu/math/rand"
+	"github.com/itsubaki/neu/model"
+	"github.com/itsubaki/neu/optimizer"
+	"github.com/itsubaki/neu/trainer"
+	"github.com/itsubaki/neu/weight"
+)
+
+func maxdiff(a, b [][]matrix.Matrix) float64 {
+	var out float64
+	for i := range a {
+		for j := range a[i] {
+			d := a[i][j].Sub(b[i][j])
+			for _, r := range d {
+				for _, v := range r {
+					out = math.Max(out, math.Abs(v))
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+func ExampleShards() {
+	fmt.Println(trainer.Shards(10, 3))
+	fmt.Println(trainer.Shards(2, 3))
+
+	// Output:
+	// [[0 4] [4 7] [7 10]]
+	// [[0 1] [1 2]]
+}
+
+func ExampleTrainer_Fit_replicas() {
+	x := matrix.New([]float64{0, 0}, []float64{1, 0}, []float64{0, 1}, []float64{1, 1}, []float64{0.5, 0.5})
+	t := matrix.New([]float64{1, 0}, []float64{0, 1}, []float64{0, 1}, []float64{1, 0}, []float64{1, 0})
+
+	fit := func(n int) (*model.Sequential, float64) {
+		var loss float64
+		tr := trainer.New(newSequential(rand.Const(1)), &optimizer.Adam{Alpha: 0.01, Beta1: 0.9, Beta2: 0.999})
+		for k := 0; k < n; k++ {
+			tr.Replicas = append(tr.Replicas, newSequential(rand.Const(uint64(k+2))))
+		}
+
+		tr.Fit(&trainer.Input{
+			Train:      x,
+			TrainLabel: t,
+			Epochs:     10,
+			BatchSize:  5,
+			Verbose:    func(_, _ int, l float64, _ trainer.Model) { loss = l },
+		}, rand.Const(1))
+
+		return tr.Model.(*model.Sequential), loss
+	}
+
+	m1, loss1 := fit(0)
+	m3, loss3 := fit(3)
+	fmt.Printf("%.4f %.4f\n", loss1, loss3)
+	fmt.Println(maxdiff(m1.Params(), m3.Params()) < 1e-12)
+
+	// Output:
+	// 0.5881 0.5881
+	// true
+}
+
+func ExampleTrainer_Fit_batchNorm() {
+	// any two shards of x have different means
+	x := matrix.New([]float64{0, 0}, []float64{1, 0}, []float64{0, 1}, []float64{1, 3})
+	t := matrix.New([]float64{1, 0}, []float64{0, 1}, []float64{0, 1}, []float64{1, 0})
+
+	mlp := func(s uint64) *model.MLP {
+		return model.NewMLP(&model.MLPConfig{
+			InputSize:         2,
+			OutputSize:        2,
+			HiddenSize:        []int{4},
+			WeightInit:        weight.He,
+			BatchNormMomentum: 0.9,
+		}, rand.Const(s))
+	}
+
+	fit := func(n int) *model.MLP {
+		tr := trainer.New(mlp(1), &optimizer.SGD{LearningRate: 0.1})
+		for k := 0; k < n; k++ {
+			tr.Replicas = append(tr.Replicas, mlp(uint64(k+2)))
+		}
+
+		tr.Fit(&trainer.Input{
+			Train:      x,
+			TrainLabel: t,
+			Epochs:     1,
+			BatchSize:  4,
+		}, rand.Const(1))
+
+		return tr.Model.(*model.MLP)
+	}
+
+	// the running mean and variance are the same, and the batch statistics are of each shard
+	stats := func(m *model.MLP) [][]matrix.Matrix {
+		return [][]matrix.Matrix{m.Layers()[1].(*layer.BatchNorm).Stats()}
+	}
+	fmt.Println(maxdiff(stats(fit(0)), stats(fit(2))) < 1e-12)
+
+	// Output:
+	// true
+}
@@ -1,8 +1,10 @@
 package trainer
 
 import (
+	"fmt"
 	randv2 "math/rand/v2"
 
+	"github.com/itsubaki/neu/dataset"
 	"github.com/itsubaki/neu/layer"
 	"github.com/itsubaki/neu/math/matrix"
 	"github.com/itsubaki/neu/math/rand"
@@ -18,9 +20,14 @@ var (
 )
 
 var (
+	_ Optimizer = (*optimizer.AdaDelta)(nil)
 	_ Optimizer = (*optimizer.AdaGrad)(nil)
 	_ Optimizer = (*optimizer.Adam)(nil)
+	_ Optimizer = (*optimizer.AdamW)(nil)
+	_ Optimizer = (*optimizer.LAMB)(nil)
 	_ Optimizer = (*optimizer.Momentum)(nil)
+	_ Optimizer = (*optimizer.Nesterov)(nil)
+	_ Optimizer = (*optimizer.RMSProp)(nil)
 	_ Optimizer = (*optimizer.SGD)(nil)
 )
 
@@ -43,11 +50,39 @@ type Input struct {
 	Epochs     int
 	BatchSize  int
 	Verbose    func(epoch, j int, loss float64, m Model)
+	Checkpoint *Checkpoint
+	Callbacks  []Callback
+
+	// Loader is used instead of Train, TrainLabel and BatchSize, if set.
+	Loader *dataset.DataLoader
+
+	// Valid and ValidLabel are evaluated after each epoch, if Valid is set.
+	Valid         matrix.Matrix
+	ValidLabel    matrix.Matrix
+	ValidVerbose  func(epoch int, metrics Metrics, m Model)
+	EarlyStopping *EarlyStopping
+}
+
+// loader returns in.Loader, or the loader of Train and TrainLabel, which are shuffled and include the last partial batch.
+func (in *Input) loader() *dataset.DataLoader {
+	if in.Loader != nil {
+		return in.Loader
+	}
+
+	return &dataset.DataLoader{
+		Dataset:   &dataset.Matrix{X: in.Train, T: in.TrainLabel},
+		BatchSize: in.BatchSize,
+		Shuffle:   true,
+	}
 }
 
 type Trainer struct {
 	Model     Model
 	Optimizer Optimizer
+
+	// Replicas are the models with the same layers as Model, which run the shards of each batch in parallel.
+	// The params are copied from Model before each batch. Each replica should have its own Source.
+	Replicas []Model
 }
 
 func New(m Model, o Optimizer) *Trainer {
@@ -58,28 +93,85 @@ func New(m Model, o Optimizer) *Trainer {
 }
 
 // Fit trains the model using the provided optimizer.
-func (tr *Trainer) Fit(in *Input, s ...randv2.Source) {
+// The callbacks are called with in.Checkpoint and in.EarlyStopping first, and can stop the training.
+// If a checkpoint is set, Fit writes checkpoints periodically and can resume from one.
+// If in.Valid is set, Fit evaluates it after each epoch.
+// The last batch is smaller than in.BatchSize, if the size of in.Train is not a multiple of it.
+// If tr.Replicas is set, each batch is sharded across the replicas and the mean gradients are used to update Model.
+func (tr *Trainer) Fit(in *Input, s ...randv2.Source) error {
 	if len(s) == 0 {
 		s = append(s, rand.NewSource(rand.MustRead()))
 	}
 
-	for i := 0; i < in.Epochs; i++ {
+	cbs := callbacks(in.Callbacks, in.Checkpoint, in.EarlyStopping)
+	st, err := cbs.resume(tr.Model, tr.Optimizer)
+	if err != nil {
+		return fmt.Errorf("resume: %v", err)
+	}
+
+	resumed := st.Source != nil
+	if resumed {
+		if err := unmarshal(s[0], st.Source); err != nil {
+			return fmt.Errorf("resume: %v", err)
+		}
+	}
+
+	c := &Context{Step: st.Step, Model: tr.Model, Optimizer: tr.Optimizer, state: st, source: s[0], callbacks: cbs}
+	cbs.OnTrainBegin(c)
+
+	loader := in.loader()
+	for i := st.Epoch; i < in.Epochs && !c.stop; i++ {
+		var j0 int
+		if resumed {
+			// the source is restored to the beginning of the epoch, so the shuffle below is the same.
+			j0, resumed = st.Iter, false
+		}
+
+		c.Epoch = i
+		if cbs.OnEpochBegin(c); c.stop {
+			break
+		}
+
 		// shuffle dataset
-		xs, ts := vector.Shuffle(in.Train, in.TrainLabel, s[0])
-		for j := 0; j < len(in.Train)/in.BatchSize; j++ {
+		it := loader.Iter(j0, s[0])
+		for j := j0; !c.stop && it.Next(); j++ {
 			// batch
-			begin, end := Range(j, in.BatchSize)
-			xbatch, tbatch := xs[begin:end], ts[begin:end]
+			xbatch, tbatch := it.Batch()
 
 			// update
-			loss := tr.Model.Forward(xbatch, tbatch)
-			tr.Model.Backward()
-			tr.Optimizer.Update(tr.Model)
+			loss := tr.update(xbatch, tbatch)
 
 			// verbose
-			in.Verbose(i, j, loss[0][0], tr.Model)
+			if in.Verbose != nil {
+				in.Verbose(i, j, loss, tr.Model)
+			}
+
+			// callbacks
+			st.Epoch, st.Iter, st.Step = i, j+1, st.Step+1
+			c.Batch, c.Step, c.Loss = j, st.Step, loss
+			cbs.OnBatchEnd(c)
+		}
+		it.Close()
+
+		if c.stop {
+			break
+		}
+
+		// validation
+		if len(in.Valid) > 0 {
+			metrics := Evaluate(tr.Model, in.Valid, in.ValidLabel, loader.BatchSize)
+			if in.ValidVerbose != nil {
+				in.ValidVerbose(i, metrics, tr.Model)
+			}
+
+			c.Metrics = &metrics
 		}
+
+		cbs.OnEpochEnd(c)
 	}
+
+	cbs.OnTrainEnd(c)
+	return c.Err
 }
 
 // Range returns begin and end index of batch.
@@ -1,6 +1,7 @@
 package trainer
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/itsubaki/neu/layer"
@@ -32,6 +33,20 @@ type RNNLMInput struct {
 	BatchSize  int
 	TimeSize   int
 	Verbose    func(epoch, j int, perplexity float64, m RNNLM)
+	Checkpoint *Checkpoint
+	Callbacks  []Callback
+
+	// Valid and ValidLabel are evaluated after each epoch, if Valid is set.
+	Valid         []int
+	ValidLabel    []int
+	ValidVerbose  func(epoch int, metrics Metrics, m RNNLM)
+	EarlyStopping *EarlyStopping
+}
+
+// StatefulRNNLM is a RNNLM with the hidden state, which is saved in a checkpoint.
+type StatefulRNNLM interface {
+	State() [][]matrix.Matrix
+	SetState(state [][]matrix.Matrix)
 }
 
 type RNNLMTrainer struct {
@@ -47,7 +62,12 @@ func NewRNNLM(m RNNLM, o Optimizer) *RNNLMTrainer {
 	}
 }
 
-func (tr *RNNLMTrainer) Fit(in *RNNLMInput) {
+// Fit trains the model using the provided optimizer.
+// The callbacks are called with in.Checkpoint and in.EarlyStopping first, and can stop the training.
+// If a checkpoint is set, Fit writes checkpoints periodically and can resume from one.
+// If in.Valid is set, Fit evaluates it after each epoch, and stops with the error if the evaluation fails.
+// OnTrainEnd is called once OnTrainBegin has been called, even if Fit returns an error.
+func (tr *RNNLMTrainer) Fit(in *RNNLMInput) error {
 	xs, ts := in.Train, in.TrainLabel
 	dataSize := len(xs)
 
@@ -57,11 +77,32 @@ func (tr *RNNLMTrainer) Fit(in *RNNLMInput) {
 		offsets[i] = i * jump
 	}
 
+	cbs := callbacks(in.Callbacks, in.Checkpoint, in.EarlyStopping)
+	st, err := cbs.resume(tr.Model, tr.Optimizer)
+	if err != nil {
+		return fmt.Errorf("resume: %v", err)
+	}
+
+	var j0 int
+	if st.Step > 0 {
+		j0, tr.timeIdx = st.Iter, st.TimeIdx
+		if m, ok := tr.Model.(StatefulRNNLM); ok && st.Hidden != nil {
+			m.SetState(st.Hidden)
+		}
+	}
+
+	c := &Context{Step: st.Step, Model: tr.Model, Optimizer: tr.Optimizer, state: st, callbacks: cbs}
+	cbs.OnTrainBegin(c)
+
 	maxIter := dataSize / (in.BatchSize * in.TimeSize)
-	var totalLoss float64
-	var lossCount int
-	for epoch := 0; epoch < in.Epochs; epoch++ {
-		for j := 0; j < maxIter; j++ {
+	totalLoss, lossCount := st.Loss, st.Count
+	for epoch := st.Epoch; epoch < in.Epochs && !c.stop; epoch++ {
+		c.Epoch = epoch
+		if cbs.OnEpochBegin(c); c.stop {
+			break
+		}
+
+		for j := j0; j < maxIter && !c.stop; j++ {
 			// (Time, N, 1)
 			xbatch, tbatch := tr.Batch(xs, ts, offsets, in.TimeSize)
 
@@ -75,11 +116,42 @@ func (tr *RNNLMTrainer) Fit(in *RNNLMInput) {
 
 			// verbose
 			ppl := Perplexity(totalLoss, lossCount)
-			in.Verbose(epoch, j, ppl, tr.Model)
+			if in.Verbose != nil {
+				in.Verbose(epoch, j, ppl, tr.Model)
+			}
+
+			// callbacks
+			st.Epoch, st.Iter, st.Step = epoch, j+1, st.Step+1
+			st.TimeIdx, st.Loss, st.Count = tr.timeIdx, totalLoss, lossCount
+			c.Batch, c.Step, c.Loss, c.Perplexity = j, st.Step, loss[0][0][0], ppl
+			cbs.OnBatchEnd(c)
+		}
+
+		if c.stop {
+			break
+		}
+
+		j0, totalLoss, lossCount = 0, 0, 0
+
+		// validation
+		if len(in.Valid) > 0 {
+			metrics, err := EvaluateRNNLM(tr.Model, in.Valid, in.ValidLabel, in.BatchSize, in.TimeSize)
+			if err != nil {
+				c.Fail(fmt.Errorf("evaluate: %v", err))
+				break
+			}
+			if in.ValidVerbose != nil {
+				in.ValidVerbose(epoch, metrics, tr.Model)
+			}
+
+			c.Metrics = &metrics
 		}
 
-		totalLoss, lossCount = 0, 0
+		cbs.OnEpochEnd(c)
 	}
+
+	cbs.OnTrainEnd(c)
+	return c.Err
 }
 
 func (tr *RNNLMTrainer) Batch(xs, ts, offsets []int, T int) ([]matrix.Matrix, []matrix.Matrix) {
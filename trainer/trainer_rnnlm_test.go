@@ -55,6 +55,29 @@ func ExampleRNNLMTrainer() {
 
 }
 
+func ExampleRNNLMTrainer_validError() {
+	tr := trainer.NewRNNLM(&TestRNNLM{}, &optimizer.SGD{LearningRate: 0.1})
+	err := tr.Fit(&trainer.RNNLMInput{
+		Train:      []int{0, 1, 2, 3},
+		TrainLabel: []int{1, 2, 3, 4},
+		Valid:      []int{0},
+		ValidLabel: []int{1},
+		Epochs:     3,
+		BatchSize:  1,
+		TimeSize:   2,
+		Callbacks:  []trainer.Callback{&TestCallback{}},
+	})
+
+	fmt.Println(err)
+
+	// Output:
+	// epoch 0 begin
+	// 0, 0: step=1, *trainer_test.TestRNNLM
+	// 0, 1: step=2, *trainer_test.TestRNNLM
+	// end: stopped=true
+	// evaluate: len(xs)=1 is smaller than batchSize*timeSize=2
+}
+
 func ExamplePerplexity() {
 	fmt.Println(trainer.Perplexity(1.0, 2))
 	fmt.Println(trainer.Perplexity(1.0, 1))
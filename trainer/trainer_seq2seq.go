@@ -1,8 +1,10 @@
 package trainer
 
 import (
+	"fmt"
 	randv2 "math/rand/v2"
 
+	"github.com/itsubaki/neu/dataset"
 	"github.com/itsubaki/neu/math/matrix"
 	"github.com/itsubaki/neu/math/rand"
 	"github.com/itsubaki/neu/math/tensor"
@@ -14,6 +16,7 @@ var (
 	_ Seq2Seq = (*model.Seq2Seq)(nil)
 	_ Seq2Seq = (*model.PeekySeq2Seq)(nil)
 	_ Seq2Seq = (*model.AttentionSeq2Seq)(nil)
+	_ Seq2Seq = (*model.TransformerSeq2Seq)(nil)
 )
 
 type Seq2Seq interface {
@@ -31,6 +34,31 @@ type Seq2SeqInput struct {
 	Epochs     int
 	BatchSize  int
 	Verbose    func(epoch, j int, loss float64, m Seq2Seq)
+	Checkpoint *Checkpoint
+	Callbacks  []Callback
+
+	// Loader is used instead of Train, TrainLabel and BatchSize, if set.
+	// The samples are the sequences of ids, e.g. dataset.Ints.
+	Loader *dataset.DataLoader
+
+	// Valid and ValidLabel are evaluated after each epoch, if Valid is set.
+	Valid         [][]int
+	ValidLabel    [][]int
+	ValidVerbose  func(epoch int, metrics Metrics, m Seq2Seq)
+	EarlyStopping *EarlyStopping
+}
+
+// loader returns in.Loader, or the loader of Train and TrainLabel, which are shuffled and include the last partial batch.
+func (in *Seq2SeqInput) loader() *dataset.DataLoader {
+	if in.Loader != nil {
+		return in.Loader
+	}
+
+	return &dataset.DataLoader{
+		Dataset:   &dataset.Ints{X: in.Train, T: in.TrainLabel},
+		BatchSize: in.BatchSize,
+		Shuffle:   true,
+	}
 }
 
 type Seq2SeqTrainer struct {
@@ -45,22 +73,52 @@ func NewSeq2Seq(m Seq2Seq, o Optimizer) *Seq2SeqTrainer {
 	}
 }
 
-func (tr *Seq2SeqTrainer) Fit(in *Seq2SeqInput, s ...randv2.Source) {
+// Fit trains the model using the provided optimizer.
+// The callbacks are called with in.Checkpoint and in.EarlyStopping first, and can stop the training.
+// If a checkpoint is set, Fit writes checkpoints periodically and can resume from one.
+// If in.Valid is set, Fit evaluates it after each epoch.
+// The last batch is smaller than in.BatchSize, if the size of in.Train is not a multiple of it.
+func (tr *Seq2SeqTrainer) Fit(in *Seq2SeqInput, s ...randv2.Source) error {
 	if len(s) == 0 {
 		s = append(s, rand.NewSource(rand.MustRead()))
 	}
 
-	var total float64
-	var count int
-	for i := 0; i < in.Epochs; i++ {
-		xt, tt := vector.Shuffle(in.Train, in.TrainLabel, s[0]) // (45000, 7), (45000, 5)
-		xs, ts := matrix.From(xt), matrix.From(tt)              // (45000, 7), (45000, 5)
+	cbs := callbacks(in.Callbacks, in.Checkpoint, in.EarlyStopping)
+	st, err := cbs.resume(tr.Model, tr.Optimizer)
+	if err != nil {
+		return fmt.Errorf("resume: %v", err)
+	}
 
-		for j := 0; j < len(in.Train)/in.BatchSize; j++ {
+	resumed := st.Source != nil
+	if resumed {
+		if err := unmarshal(s[0], st.Source); err != nil {
+			return fmt.Errorf("resume: %v", err)
+		}
+	}
+
+	c := &Context{Step: st.Step, Model: tr.Model, Optimizer: tr.Optimizer, state: st, source: s[0], callbacks: cbs}
+	cbs.OnTrainBegin(c)
+
+	loader := in.loader()
+	total, count := st.Loss, st.Count
+	for i := st.Epoch; i < in.Epochs && !c.stop; i++ {
+		var j0 int
+		if resumed {
+			// the source is restored to the beginning of the epoch, so the shuffle below is the same.
+			j0, resumed = st.Iter, false
+		}
+
+		c.Epoch = i
+		if cbs.OnEpochBegin(c); c.stop {
+			break
+		}
+
+		it := loader.Iter(j0, s[0])
+		for j := j0; !c.stop && it.Next(); j++ {
 			// batch
-			begin, end := Range(j, in.BatchSize)
-			xbatch := vector.Reverse(Time(xs[begin:end])) // (128, 7) -> (7, 128, 1)
-			tbatch := Time(ts[begin:end])                 // (128, 5) -> (5, 128, 1)
+			xs, ts := it.Batch()
+			xbatch := vector.Reverse(Time(xs)) // (128, 7) -> (7, 128, 1)
+			tbatch := Time(ts)                 // (128, 5) -> (5, 128, 1)
 
 			// update
 			loss := tr.Model.Forward(xbatch, tbatch)
@@ -71,11 +129,39 @@ func (tr *Seq2SeqTrainer) Fit(in *Seq2SeqInput, s ...randv2.Source) {
 			count++
 
 			// verbose
-			in.Verbose(i, j, total/float64(count), tr.Model)
+			if in.Verbose != nil {
+				in.Verbose(i, j, total/float64(count), tr.Model)
+			}
+
+			// callbacks
+			st.Epoch, st.Iter, st.Step = i, j+1, st.Step+1
+			st.Loss, st.Count = total, count
+			c.Batch, c.Step, c.Loss = j, st.Step, loss[0][0][0]
+			cbs.OnBatchEnd(c)
+		}
+		it.Close()
+
+		if c.stop {
+			break
 		}
 
 		total, count = 0.0, 0
+
+		// validation
+		if len(in.Valid) > 0 {
+			metrics := EvaluateSeq2Seq(tr.Model, in.Valid, in.ValidLabel, loader.BatchSize)
+			if in.ValidVerbose != nil {
+				in.ValidVerbose(i, metrics, tr.Model)
+			}
+
+			c.Metrics = &metrics
+		}
+
+		cbs.OnEpochEnd(c)
 	}
+
+	cbs.OnTrainEnd(c)
+	return c.Err
 }
 
 func Time(xs matrix.Matrix) []matrix.Matrix {
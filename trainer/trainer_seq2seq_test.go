@@ -70,6 +70,48 @@ func ExampleSeq2SeqTrainer_rand() {
 	// 2: *model.Seq2Seq
 }
 
+func ExampleSeq2SeqTrainer_transformer() {
+	m := model.NewTransformerSeq2Seq(&model.TransformerConfig{
+		VocabSize:       7,
+		HiddenSize:      16,
+		Heads:           2,
+		FeedForwardSize: 32,
+		Layers:          1,
+		MaxLength:       8,
+		WeightInit:      weight.Xavier,
+	}, rand.Const(1))
+
+	tr := trainer.NewSeq2Seq(m, &optimizer.Adam{
+		Alpha: 0.01,
+		Beta1: 0.9,
+		Beta2: 0.999,
+	})
+
+	var first, last float64
+	tr.Fit(&trainer.Seq2SeqInput{
+		Train:      [][]int{{1, 2, 3}, {3, 2, 1}},
+		TrainLabel: [][]int{{0, 1, 2, 3}, {0, 3, 2, 1}},
+		Epochs:     100,
+		BatchSize:  2,
+		Verbose: func(epoch, _ int, loss float64, _ trainer.Seq2Seq) {
+			if epoch == 0 {
+				first = loss
+			}
+
+			last = loss
+		},
+	}, rand.Const(1))
+
+	fmt.Println(last < first/10)
+	fmt.Println(m.Generate([]matrix.Matrix{{{3}}, {{2}}, {{1}}}, 0, 3)) // reversed
+	fmt.Println(m.Generate([]matrix.Matrix{{{1}}, {{2}}, {{3}}}, 0, 3))
+
+	// Output:
+	// true
+	// [1 2 3]
+	// [3 2 1]
+}
+
 func ExampleTime() {
 	xs := matrix.New(
 		// (N, T) (2, 3)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/itsubaki/neu/dataset"
 	"github.com/itsubaki/neu/layer"
 	"github.com/itsubaki/neu/math/matrix"
 	"github.com/itsubaki/neu/math/rand"
@@ -104,3 +105,49 @@ func ExampleRandom() {
 	// [0 1 2 3]
 	// [0]
 }
+
+func ExampleTrainer_Fit_remainder() {
+	tr := trainer.New(&TestModel{}, &optimizer.SGD{LearningRate: 0.1})
+	tr.Fit(&trainer.Input{
+		Train:      matrix.New([]float64{0.5, 0.5}, []float64{1, 0}, []float64{0, 1}),
+		TrainLabel: matrix.New([]float64{1, 0}, []float64{0, 1}, []float64{0, 1}),
+		Epochs:     2,
+		BatchSize:  2,
+		Verbose: func(epoch, j int, loss float64, m trainer.Model) {
+			fmt.Printf("%v,%v\n", epoch, j)
+		},
+	}, rand.Const(1))
+
+	// Output:
+	// 0,0
+	// 0,1
+	// 1,0
+	// 1,1
+}
+
+func ExampleTrainer_Fit_loader() {
+	s := rand.Const(1)
+	x := matrix.New([]float64{0, 0}, []float64{1, 0}, []float64{0, 1}, []float64{1, 1}, []float64{0.5, 0.5})
+	t := matrix.New([]float64{1, 0}, []float64{0, 1}, []float64{0, 1}, []float64{1, 0}, []float64{1, 0})
+
+	tr := trainer.New(newSequential(s), &optimizer.SGD{LearningRate: 0.5})
+	tr.Fit(&trainer.Input{
+		Loader: &dataset.DataLoader{
+			Dataset:   &dataset.Matrix{X: x, T: t},
+			BatchSize: 2,
+			Shuffle:   true,
+			DropLast:  true,
+			Prefetch:  2,
+		},
+		Epochs: 2,
+		Verbose: func(epoch, j int, loss float64, m trainer.Model) {
+			fmt.Printf("%v,%v: loss=%.4f\n", epoch, j, loss)
+		},
+	}, s)
+
+	// Output:
+	// 0,0: loss=0.6395
+	// 0,1: loss=0.6748
+	// 1,0: loss=0.4384
+	// 1,1: loss=0.8228
+}